@@ -2,36 +2,99 @@ package main
 
 import (
 	"context"
+	"expvar"
 	"log"
 	"log/slog"
 	"net/http"
 	"os"
 	"os/signal"
+	"slices"
+	"syscall"
 	"time"
 
+	"github.com/biraneves/fc-labs-weather/internal/application/ports/outbound"
 	"github.com/biraneves/fc-labs-weather/internal/application/usecase"
+	"github.com/biraneves/fc-labs-weather/internal/infrastructure/cache"
 	"github.com/biraneves/fc-labs-weather/internal/infrastructure/config"
+	"github.com/biraneves/fc-labs-weather/internal/infrastructure/ensemble"
+	"github.com/biraneves/fc-labs-weather/internal/infrastructure/http/brasilapi"
+	"github.com/biraneves/fc-labs-weather/internal/infrastructure/http/opencep"
+	"github.com/biraneves/fc-labs-weather/internal/infrastructure/http/openmeteo"
+	"github.com/biraneves/fc-labs-weather/internal/infrastructure/http/retry"
 	"github.com/biraneves/fc-labs-weather/internal/infrastructure/http/server"
+	"github.com/biraneves/fc-labs-weather/internal/infrastructure/http/transport"
 	viacep "github.com/biraneves/fc-labs-weather/internal/infrastructure/http/viacep"
 	weatherapi "github.com/biraneves/fc-labs-weather/internal/infrastructure/http/weather_api"
+	"github.com/biraneves/fc-labs-weather/internal/infrastructure/resilience"
+	"github.com/biraneves/fc-labs-weather/internal/infrastructure/shadow"
 )
 
 const shutdownTimeout = 10 * time.Second
 
+type upstreamClients map[string]*http.Client
+
 func main() {
 	cfg, err := config.Load(".")
 	if err != nil {
 		slog.Warn("config:", slog.String("error", err.Error()))
 	}
 
-	httpClient := &http.Client{Timeout: cfg.HTTP.Timeout}
-
 	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
 
-	zipcodeClient := viacep.NewHTTPClient(httpClient, cfg.ViaCEP.BaseURL, cfg.ViaCEP.Timeout, logger)
-	weatherClient := weatherapi.NewHTTPClient(httpClient, cfg.Weather.BaseURL, cfg.Weather.APIKey, cfg.Weather.Timeout, logger)
+	retryPolicy := retry.Policy{
+		MaxAttempts:       cfg.Retry.MaxAttempts,
+		BaseBackoff:       cfg.Retry.BaseBackoff,
+		MaxBackoff:        cfg.Retry.MaxBackoff,
+		Jitter:            cfg.Retry.Jitter,
+		RetryableStatuses: cfg.Retry.RetryableStatuses,
+	}
+
+	clients := upstreamClients{
+		config.ZipcodeProviderViaCEP:     newHTTPClient(config.ZipcodeProviderViaCEP, cfg.ViaCEP.Transport, retryPolicy, logger),
+		config.ZipcodeProviderBrasilAPI:  newHTTPClient(config.ZipcodeProviderBrasilAPI, cfg.BrasilAPI.Transport, retryPolicy, logger),
+		config.ZipcodeProviderOpenCEP:    newHTTPClient(config.ZipcodeProviderOpenCEP, cfg.OpenCEP.Transport, retryPolicy, logger),
+		config.WeatherProviderWeatherAPI: newHTTPClient(config.WeatherProviderWeatherAPI, cfg.Weather.Transport, weatherapi.RetryPolicy(retryPolicy), logger),
+		config.WeatherProviderOpenMeteo:  newHTTPClient(config.WeatherProviderOpenMeteo, cfg.OpenMeteo.Transport, retryPolicy, logger),
+	}
+
+	zipcodeClient := newZipcodeClient(cfg, clients, logger)
+
+	if cfg.ZipcodeCache.Size > 0 {
+		zipcodeCache := cache.NewZipcodeCache(zipcodeClient, cfg.ZipcodeCache.Size, cfg.ZipcodeCache.TTL, cfg.ZipcodeCache.NegativeTTL, logger)
+		expvar.Publish("zipcode_cache", expvar.Func(func() any { return zipcodeCache.Stats() }))
+		zipcodeClient = zipcodeCache
+	}
 
-	getWeatherUC := usecase.NewGetWeatherByCEPUseCase(zipcodeClient, weatherClient)
+	keyPool, err := weatherapi.NewKeyPool(cfg.Weather.APIKeys, int64(cfg.Weather.SoftLimit), cfg.Weather.UsageFile, logger)
+	if err != nil {
+		logger.Warn("weatherapi: key usage not restored", slog.String("error", err.Error()))
+	}
+	expvar.Publish("weatherapi_keys", expvar.Func(func() any { return keyPool.Snapshot() }))
+
+	weatherClient := newWeatherClient(cfg, clients, keyPool, logger)
+
+	var weatherShadow *shadow.WeatherShadow
+	if cfg.WeatherShadow.Provider != "" {
+		weatherShadow = shadow.NewWeatherShadow(weatherClient, newWeatherProvider(cfg, cfg.WeatherShadow.Provider, clients, keyPool, logger), shadow.Settings{
+			Candidate:      cfg.WeatherShadow.Provider,
+			SampleRate:     cfg.WeatherShadow.SampleRate,
+			MaxInFlight:    cfg.WeatherShadow.MaxInFlight,
+			Timeout:        cfg.WeatherShadow.Timeout,
+			TempThresholdC: cfg.WeatherShadow.TempThresholdC,
+		}, logger)
+		expvar.Publish("weather_shadow", expvar.Func(func() any { return weatherShadow.Stats() }))
+		weatherClient = weatherShadow
+	}
+
+	if cfg.WeatherCache.Size > 0 {
+		weatherCache := cache.NewWeatherCache(weatherClient, cfg.WeatherCache.Size, cfg.WeatherCache.TTL, cfg.WeatherCache.StaleTTL, logger)
+		expvar.Publish("weather_cache", expvar.Func(func() any { return weatherCache.Stats() }))
+		weatherClient = weatherCache
+	}
+
+	getWeatherUC := usecase.NewGetWeatherByCEPUseCaseWithBudget(zipcodeClient, weatherClient, usecase.BudgetSettings{
+		ZipcodeShare: cfg.HTTP.ZipcodeShare,
+	})
 
 	handler := server.NewHandler(getWeatherUC, logger)
 	mux := http.NewServeMux()
@@ -39,25 +102,220 @@ func main() {
 
 	logging := server.NewLoggerMiddleware(logger)
 	recovery := server.NewRecoveryMiddleware(logger)
-	rootHandler := logging.Wrap(recovery.Wrap(mux))
+
+	var rootHandler http.Handler = mux
+	if cfg.Concurrency.Mode != config.ConcurrencyModeOff {
+		concurrency := server.NewConcurrencyMiddleware(server.ConcurrencySettings{
+			Mode:          cfg.Concurrency.Mode,
+			MaxConcurrent: cfg.Concurrency.MaxConcurrent,
+			MinConcurrent: cfg.Concurrency.MinConcurrent,
+			LatencyTarget: cfg.Concurrency.LatencyTarget,
+			QueueSize:     cfg.Concurrency.QueueSize,
+			QueueTimeout:  cfg.Concurrency.QueueTimeout,
+			ExemptPaths:   []string{"/healthz"},
+		}, logger)
+		expvar.Publish("concurrency_limiter", expvar.Func(func() any { return concurrency.Snapshot() }))
+		rootHandler = concurrency.Wrap(rootHandler)
+	}
+
+	deadline := server.NewDeadlineMiddleware(server.DeadlineSettings{
+		Default:     cfg.HTTP.Timeout,
+		Min:         cfg.HTTP.TimeoutMin,
+		Max:         cfg.HTTP.TimeoutMax,
+		ExemptPaths: []string{"/healthz"},
+	}, logger)
+	rootHandler = deadline.Wrap(rootHandler)
+
+	if cfg.RateLimit.Enabled {
+		rateLimit := server.NewRateLimitMiddleware(server.RateLimitSettings{
+			RequestsPerSecond: cfg.RateLimit.RequestsPerSecond,
+			Burst:             cfg.RateLimit.Burst,
+			IdleTTL:           cfg.RateLimit.IdleTTL,
+			TrustedProxies:    cfg.RateLimit.TrustedProxies,
+			APIKeyHeader:      cfg.RateLimit.APIKeyHeader,
+			APIKeys:           cfg.RateLimit.APIKeys,
+			MaxClients:        cfg.RateLimit.MaxClients,
+			ExemptPaths:       []string{"/healthz"},
+		}, logger)
+		rootHandler = rateLimit.Wrap(rootHandler)
+	}
+
+	rootHandler = logging.Wrap(recovery.Wrap(rootHandler))
 
 	srv := &http.Server{
 		Addr:    cfg.HTTP.Addr,
 		Handler: rootHandler,
 	}
 
-	go func() {
-		slog.Info("server listening:", "port", cfg.HTTP.Addr)
-		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-			log.Fatalf("server error: %v", err)
+	servers := []*http.Server{srv}
+	if cfg.HTTP.AdminAddr != "" {
+		adminMux := http.NewServeMux()
+		adminMux.Handle("GET /debug/vars", expvar.Handler())
+		servers = append(servers, &http.Server{
+			Addr:    cfg.HTTP.AdminAddr,
+			Handler: recovery.Wrap(adminMux),
+		})
+	}
+
+	for _, s := range servers {
+		go func() {
+			slog.Info("server listening:", "port", s.Addr)
+			if err := s.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				log.Fatalf("server error: %v", err)
+			}
+		}()
+	}
+
+	gracefulShutdown(servers...)
+	if weatherShadow != nil {
+		weatherShadow.Wait()
+	}
+	keyPool.Flush()
+}
+
+func newHTTPClient(name string, cfg config.TransportConfig, retryPolicy retry.Policy, logger *slog.Logger) *http.Client {
+	base, err := transport.New(transport.Settings{
+		Name:                name,
+		MaxIdleConns:        cfg.MaxIdleConns,
+		MaxIdleConnsPerHost: cfg.MaxIdleConnsPerHost,
+		MaxConnsPerHost:     cfg.MaxConnsPerHost,
+		IdleConnTimeout:     cfg.IdleConnTimeout,
+		KeepAlive:           cfg.KeepAlive,
+		DialTimeout:         cfg.DialTimeout,
+		TLSHandshakeTimeout: cfg.TLSHandshakeTimeout,
+		ProxyURL:            cfg.ProxyURL,
+		CABundle:            cfg.CABundle,
+		DNSCacheTTL:         cfg.DNSCacheTTL,
+	}, logger)
+	if err != nil {
+		log.Fatalf("%s transport: %v", name, err)
+	}
+
+	return &http.Client{Transport: retry.NewTransport(base, retryPolicy, logger)}
+}
+
+func newZipcodeClient(cfg config.AppConfig, clients upstreamClients, logger *slog.Logger) outbound.ZipcodeLookupPort {
+	names := uniqueNames(append([]string{cfg.ZipcodeProvider}, cfg.ZipcodeFailover.FallbackProviders...))
+
+	providers := make([]resilience.ZipcodeProvider, 0, len(names))
+	for _, name := range names {
+		port := newZipcodeProvider(cfg, name, clients, logger)
+		if cfg.Breaker.Enabled {
+			port = resilience.NewZipcodeBreaker(port, newBreaker(cfg.Breaker, name, logger))
+		}
+		providers = append(providers, resilience.ZipcodeProvider{Name: name, Port: port})
+	}
+
+	if len(providers) == 1 {
+		return providers[0].Port
+	}
+
+	failover := resilience.NewZipcodeFailover(providers, resilience.ZipcodeFailoverSettings{
+		NotFoundAuthoritative: cfg.ZipcodeFailover.NotFoundAuthoritative,
+		Health:                resilience.HealthSettings{CoolDown: cfg.ZipcodeFailover.CoolDown},
+	}, logger)
+	expvar.Publish("zipcode_failover", expvar.Func(func() any { return failover.Health() }))
+
+	return failover
+}
+
+func newZipcodeProvider(cfg config.AppConfig, name string, clients upstreamClients, logger *slog.Logger) outbound.ZipcodeLookupPort {
+	switch name {
+	case config.ZipcodeProviderBrasilAPI:
+		return brasilapi.NewHTTPClient(clients[config.ZipcodeProviderBrasilAPI], cfg.BrasilAPI.BaseURL, cfg.BrasilAPI.Timeout, logger)
+	case config.ZipcodeProviderOpenCEP:
+		return opencep.NewHTTPClient(clients[config.ZipcodeProviderOpenCEP], cfg.OpenCEP.BaseURL, cfg.OpenCEP.Timeout, logger)
+	default:
+		return viacep.NewHTTPClient(clients[config.ZipcodeProviderViaCEP], cfg.ViaCEP.BaseURL, cfg.ViaCEP.Timeout, logger)
+	}
+}
+
+func newWeatherClient(cfg config.AppConfig, clients upstreamClients, keyPool *weatherapi.KeyPool, logger *slog.Logger) outbound.WeatherProviderPort {
+	if len(cfg.WeatherEnsemble.Providers) > 1 {
+		return newWeatherEnsemble(cfg, clients, keyPool, logger)
+	}
+
+	names := uniqueNames(append([]string{cfg.WeatherProvider}, cfg.WeatherFailover.FallbackProviders...))
+
+	providers := make([]resilience.WeatherProvider, 0, len(names))
+	for _, name := range names {
+		port := newGuardedWeatherProvider(cfg, name, clients, keyPool, logger)
+		providers = append(providers, resilience.WeatherProvider{Name: name, Port: port})
+	}
+
+	if len(providers) == 1 {
+		return providers[0].Port
+	}
+
+	failover := resilience.NewWeatherFailover(providers, resilience.WeatherFailoverSettings{
+		HedgePercentile: cfg.WeatherFailover.HedgePercentile,
+		HedgeMinDelay:   cfg.WeatherFailover.HedgeMinDelay,
+		Health:          resilience.HealthSettings{CoolDown: cfg.WeatherFailover.CoolDown},
+	}, logger)
+	expvar.Publish("weather_failover", expvar.Func(func() any { return failover.Health() }))
+
+	return failover
+}
+
+func newWeatherEnsemble(cfg config.AppConfig, clients upstreamClients, keyPool *weatherapi.KeyPool, logger *slog.Logger) outbound.WeatherProviderPort {
+	providers := make([]ensemble.WeatherProvider, 0, len(cfg.WeatherEnsemble.Providers))
+	for _, name := range uniqueNames(cfg.WeatherEnsemble.Providers) {
+		port := newGuardedWeatherProvider(cfg, name, clients, keyPool, logger)
+		providers = append(providers, ensemble.WeatherProvider{Name: name, Port: port})
+	}
+
+	return ensemble.NewWeatherEnsemble(providers, ensemble.Settings{
+		Strategy:   cfg.WeatherEnsemble.Strategy,
+		ThresholdC: cfg.WeatherEnsemble.ThresholdC,
+	}, logger)
+}
+
+func newGuardedWeatherProvider(cfg config.AppConfig, name string, clients upstreamClients, keyPool *weatherapi.KeyPool, logger *slog.Logger) outbound.WeatherProviderPort {
+	port := newWeatherProvider(cfg, name, clients, keyPool, logger)
+	if cfg.Breaker.Enabled {
+		port = resilience.NewWeatherBreaker(port, newBreaker(cfg.Breaker, name, logger))
+	}
+
+	return port
+}
+
+func newWeatherProvider(cfg config.AppConfig, name string, clients upstreamClients, keyPool *weatherapi.KeyPool, logger *slog.Logger) outbound.WeatherProviderPort {
+	switch name {
+	case config.WeatherProviderOpenMeteo:
+		return openmeteo.NewHTTPClient(clients[config.WeatherProviderOpenMeteo], cfg.OpenMeteo.GeocodingURL, cfg.OpenMeteo.ForecastURL, cfg.OpenMeteo.Timeout, logger)
+	default:
+		return weatherapi.NewHTTPClientWithKeyPool(clients[config.WeatherProviderWeatherAPI], cfg.Weather.BaseURL, keyPool, cfg.Weather.Timeout, logger)
+	}
+}
+
+func uniqueNames(names []string) []string {
+	unique := make([]string, 0, len(names))
+	for _, name := range names {
+		if !slices.Contains(unique, name) {
+			unique = append(unique, name)
 		}
-	}()
+	}
 
-	gracefulShutdown(srv)
+	return unique
 }
 
-func gracefulShutdown(srv *http.Server) {
-	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+func newBreaker(cfg config.BreakerConfig, name string, logger *slog.Logger) *resilience.Breaker {
+	breaker := resilience.NewBreaker(resilience.BreakerSettings{
+		Name:             name,
+		FailureRatio:     cfg.FailureRatio,
+		MinRequests:      cfg.MinRequests,
+		Window:           cfg.Window,
+		CoolDown:         cfg.CoolDown,
+		HalfOpenRequests: cfg.HalfOpenRequests,
+	}, resilience.IsUpstreamFailure, logger)
+
+	expvar.Publish("breaker_"+name, expvar.Func(func() any { return breaker.Snapshot() }))
+
+	return breaker
+}
+
+func gracefulShutdown(servers ...*http.Server) {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
 	defer stop()
 
 	<-ctx.Done()
@@ -66,10 +324,15 @@ func gracefulShutdown(srv *http.Server) {
 	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
 	defer cancel()
 
-	if err := srv.Shutdown(shutdownCtx); err != nil {
-		slog.Error("graceful shutdown failed:", "error", err.Error())
-		return
+	stopped := true
+	for _, srv := range servers {
+		if err := srv.Shutdown(shutdownCtx); err != nil {
+			slog.Error("graceful shutdown failed:", "addr", srv.Addr, "error", err.Error())
+			stopped = false
+		}
 	}
 
-	slog.Info("server stopped")
+	if stopped {
+		slog.Info("server stopped")
+	}
 }
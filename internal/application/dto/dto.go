@@ -1,15 +1,95 @@
 package dto
 
-import "github.com/biraneves/fc-labs-weather/internal/domain/entity"
+import (
+	"time"
+
+	"github.com/biraneves/fc-labs-weather/internal/domain/entity"
+)
 
 type RequestInDto struct {
 	CEP entity.Cep `json:"cep"`
 }
 
 type RequestOutDto struct {
-	TempC entity.TemperatureCelsius    `json:"temp_C"`
-	TempF entity.TemperatureFahrenheit `json:"temp_F"`
-	TempK entity.TemperatureKelvin     `json:"temp_K"`
+	TempC      entity.Temperature `json:"temp_C,omitzero"`
+	TempF      entity.Temperature `json:"temp_F,omitzero"`
+	TempK      entity.Temperature `json:"temp_K,omitzero"`
+	TempR      entity.Temperature `json:"temp_R,omitzero"`
+	Formatted  *FormattedTempsDto `json:"formatted,omitempty"`
+	Location   *LocationDto       `json:"location,omitempty"`
+	Conditions *ConditionsDto     `json:"conditions,omitempty"`
+	Stale      bool               `json:"stale,omitempty"`
+	ObservedAt time.Time          `json:"-"`
+	Provider   string             `json:"-"`
+	Ensemble   *EnsembleOutDto    `json:"ensemble,omitempty"`
+}
+
+type TemperaturesDto struct {
+	TempC     entity.Temperature `json:"temp_C,omitzero"`
+	TempF     entity.Temperature `json:"temp_F,omitzero"`
+	TempK     entity.Temperature `json:"temp_K,omitzero"`
+	TempR     entity.Temperature `json:"temp_R,omitzero"`
+	Formatted *FormattedTempsDto `json:"formatted,omitempty"`
+}
+
+type FormattedTempsDto struct {
+	TempC string `json:"temp_C,omitempty"`
+	TempF string `json:"temp_F,omitempty"`
+	TempK string `json:"temp_K,omitempty"`
+	TempR string `json:"temp_R,omitempty"`
+}
+
+type LocationDto struct {
+	CEP  string `json:"cep"`
+	City string `json:"city"`
+	UF   string `json:"uf"`
+}
+
+type ConditionsDto struct {
+	ObservedAt time.Time               `json:"observed_at,omitzero"`
+	Text       string                  `json:"text,omitempty"`
+	Code       int                     `json:"code,omitempty"`
+	Humidity   entity.RelativeHumidity `json:"humidity,omitzero"`
+	Wind       WindDto                 `json:"wind,omitzero"`
+	Pressure   entity.Pressure         `json:"pressure_mb,omitzero"`
+	Visibility entity.Visibility       `json:"visibility_km,omitzero"`
+	FeelsLike  TemperaturesDto         `json:"feels_like,omitzero"`
+	HeatIndex  TemperaturesDto         `json:"heat_index,omitzero"`
+	WindChill  TemperaturesDto         `json:"wind_chill,omitzero"`
+	DewPoint   TemperaturesDto         `json:"dew_point,omitzero"`
+}
+
+type WindDto struct {
+	Speed     entity.WindSpeed     `json:"speed_kph,omitzero"`
+	Direction entity.WindDirection `json:"degree,omitzero"`
+}
+
+type EnsembleReadingOutDto struct {
+	Provider string `json:"provider"`
+	TemperaturesDto
+}
+
+type EnsembleOutDto struct {
+	Strategy     string                  `json:"strategy"`
+	SpreadC      float64                 `json:"spread_C"`
+	ThresholdC   float64                 `json:"threshold_C"`
+	Disagreement bool                    `json:"disagreement"`
+	Readings     []EnsembleReadingOutDto `json:"readings"`
+	Failed       []string                `json:"failed,omitempty"`
+}
+
+type EnsembleReadingDto struct {
+	Provider string  `json:"provider"`
+	TempC    float64 `json:"temp_C"`
+}
+
+type EnsembleDto struct {
+	Strategy     string               `json:"strategy"`
+	SpreadC      float64              `json:"spread_C"`
+	ThresholdC   float64              `json:"threshold_C"`
+	Disagreement bool                 `json:"disagreement"`
+	Readings     []EnsembleReadingDto `json:"readings"`
+	Failed       []string             `json:"failed,omitempty"`
 }
 
 type ViaCEPRequestDto struct {
@@ -58,26 +138,29 @@ type WeatherAPIResponseDto struct {
 			Icon string `json:"icon"`
 			Code int    `json:"code"`
 		} `json:"condition"`
-		WindMph    float64 `json:"wind_mph"`
-		WindKph    float64 `json:"wind_kph"`
-		WindDegree float64 `json:"wind_degree"`
-		WindDir    string  `json:"wind_dir"`
-		PressureMb float64 `json:"pressure_mb"`
-		PressureIn float64 `json:"pressure_in"`
-		Humidity   float64 `json:"humidity"`
-		Cloud      float64 `json:"cloud"`
-		FeelsLikeC float64 `json:"feelslike_c"`
-		FeelsLikeF float64 `json:"feelskile_f"`
-		WindChillC float64 `json:"windchill_c"`
-		WindChillF float64 `json:"windchill_f"`
-		HeatIndexC float64 `json:"heatindex_c"`
-		HeatIndexF float64 `json:"heatindex_f"`
-		DewPointC  float64 `json:"dewpoint_c"`
-		DewPointF  float64 `json:"dewpoint_f"`
-		VisKm      float64 `json:"vis_km"`
-		VisMiles   float64 `json:"vis_miles"`
-		Uv         float64 `json:"uv"`
-		GustMph    float64 `json:"gust_mph"`
-		GustKph    float64 `json:"gust_kph"`
+		WindMph    float64  `json:"wind_mph"`
+		WindKph    *float64 `json:"wind_kph"`
+		WindDegree *float64 `json:"wind_degree"`
+		WindDir    string   `json:"wind_dir"`
+		PressureMb *float64 `json:"pressure_mb"`
+		PressureIn float64  `json:"pressure_in"`
+		Humidity   *float64 `json:"humidity"`
+		Cloud      float64  `json:"cloud"`
+		FeelsLikeC *float64 `json:"feelslike_c"`
+		FeelsLikeF float64  `json:"feelskile_f"`
+		WindChillC *float64 `json:"windchill_c"`
+		WindChillF float64  `json:"windchill_f"`
+		HeatIndexC *float64 `json:"heatindex_c"`
+		HeatIndexF float64  `json:"heatindex_f"`
+		DewPointC  *float64 `json:"dewpoint_c"`
+		DewPointF  float64  `json:"dewpoint_f"`
+		VisKm      *float64 `json:"vis_km"`
+		VisMiles   float64  `json:"vis_miles"`
+		Uv         float64  `json:"uv"`
+		GustMph    float64  `json:"gust_mph"`
+		GustKph    float64  `json:"gust_kph"`
 	} `json:"current"`
+	Stale    bool         `json:"-"`
+	Provider string       `json:"-"`
+	Ensemble *EnsembleDto `json:"-"`
 }
@@ -0,0 +1,28 @@
+package outbound
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	ErrUpstreamTimeout          = errors.New("upstream: timeout")
+	ErrUpstreamUnavailable      = errors.New("upstream: unavailable")
+	ErrUpstreamQuotaExceeded    = errors.New("upstream: quota exceeded")
+	ErrUpstreamUnauthorized     = errors.New("upstream: unauthorized")
+	ErrUpstreamMalformedPayload = errors.New("upstream: malformed payload")
+)
+
+type CircuitOpenError struct {
+	Upstream   string
+	RetryAfter time.Duration
+}
+
+func (e *CircuitOpenError) Error() string {
+	return fmt.Sprintf("%s: circuit open, retry after %s", e.Upstream, e.RetryAfter)
+}
+
+func (e *CircuitOpenError) Unwrap() error {
+	return ErrUpstreamUnavailable
+}
@@ -2,10 +2,13 @@ package outbound
 
 import (
 	"context"
+	"errors"
 
 	"github.com/biraneves/fc-labs-weather/internal/application/dto"
 )
 
+var ErrWeatherLocationNotFound = errors.New("weather provider: location not found")
+
 type WeatherProviderPort interface {
 	FetchCurrent(ctx context.Context, req dto.WeatherAPIRequestDto) (dto.WeatherAPIResponseDto, error)
 }
@@ -0,0 +1,193 @@
+package usecase
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+type flightContext struct {
+	context.Context
+
+	done    chan struct{}
+	reserve float64
+
+	mu       sync.Mutex
+	deadline time.Time
+	bounded  bool
+	timer    *time.Timer
+	err      error
+}
+
+func newFlightContext(ctx context.Context, reserve float64) *flightContext {
+	c := &flightContext{
+		Context: context.WithoutCancel(ctx),
+		done:    make(chan struct{}),
+		reserve: reserve,
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.deadline, c.bounded = c.upstreamDeadline(ctx)
+	if c.bounded {
+		c.timer = time.AfterFunc(time.Until(c.deadline), c.expire)
+	}
+
+	return c
+}
+
+func (c *flightContext) Deadline() (time.Time, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.deadline, c.bounded
+}
+
+func (c *flightContext) Done() <-chan struct{} {
+	return c.done
+}
+
+func (c *flightContext) Err() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.err
+}
+
+func (c *flightContext) extend(ctx context.Context) {
+	deadline, ok := c.upstreamDeadline(ctx)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	switch {
+	case !ok:
+		c.bounded = false
+		if c.timer != nil {
+			c.timer.Stop()
+		}
+	case c.bounded && deadline.After(c.deadline):
+		c.deadline = deadline
+		c.timer.Reset(time.Until(deadline))
+	}
+}
+
+func (c *flightContext) upstreamDeadline(ctx context.Context) (time.Time, bool) {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return time.Time{}, false
+	}
+
+	reserve := time.Duration(float64(time.Until(deadline)) * c.reserve)
+	return deadline.Add(-reserve), true
+}
+
+func (c *flightContext) expire() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.bounded {
+		return
+	}
+
+	if wait := time.Until(c.deadline); wait > 0 {
+		c.timer.Reset(wait)
+		return
+	}
+
+	c.cancelLocked(context.DeadlineExceeded)
+}
+
+func (c *flightContext) cancel(err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.cancelLocked(err)
+}
+
+func (c *flightContext) cancelLocked(err error) {
+	if c.err != nil {
+		return
+	}
+
+	c.err = err
+	if c.timer != nil {
+		c.timer.Stop()
+	}
+	close(c.done)
+}
+
+type flightCall[T any] struct {
+	ctx     *flightContext
+	done    chan struct{}
+	waiters int
+	val     T
+	err     error
+}
+
+type flightGroup[T any] struct {
+	reserve float64
+
+	mu    sync.Mutex
+	calls map[string]*flightCall[T]
+}
+
+func (g *flightGroup[T]) do(ctx context.Context, key string, fn func(ctx context.Context) (T, error)) (T, error) {
+	g.mu.Lock()
+	if g.calls == nil {
+		g.calls = make(map[string]*flightCall[T])
+	}
+
+	c, ok := g.calls[key]
+	if ok && c.ctx.Err() == nil {
+		c.ctx.extend(ctx)
+	} else {
+		c = &flightCall[T]{ctx: newFlightContext(ctx, g.reserve), done: make(chan struct{})}
+		g.calls[key] = c
+
+		go g.run(key, c, fn)
+	}
+	c.waiters++
+	g.mu.Unlock()
+
+	select {
+	case <-c.done:
+		return c.val, c.err
+
+	case <-ctx.Done():
+		g.leave(key, c, ctx.Err())
+
+		var zero T
+		return zero, ctx.Err()
+	}
+}
+
+func (g *flightGroup[T]) run(key string, c *flightCall[T], fn func(ctx context.Context) (T, error)) {
+	defer c.ctx.cancel(context.Canceled)
+
+	c.val, c.err = fn(c.ctx)
+
+	g.mu.Lock()
+	if g.calls[key] == c {
+		delete(g.calls, key)
+	}
+	g.mu.Unlock()
+
+	close(c.done)
+}
+
+func (g *flightGroup[T]) leave(key string, c *flightCall[T], err error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	c.waiters--
+	if c.waiters > 0 {
+		return
+	}
+
+	if g.calls[key] == c {
+		delete(g.calls, key)
+	}
+	c.ctx.cancel(err)
+}
@@ -0,0 +1,229 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFlightGroup_Do(t *testing.T) {
+	t.Run("concurrent callers share one execution", func(t *testing.T) {
+		var g flightGroup[int]
+		var calls atomic.Int32
+		release := make(chan struct{})
+
+		fn := func(ctx context.Context) (int, error) {
+			calls.Add(1)
+			<-release
+			return 42, nil
+		}
+
+		const callers = 5
+		results := make([]int, callers)
+		var wg sync.WaitGroup
+		for i := range callers {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				v, err := g.do(context.Background(), "key", fn)
+				assert.NoError(t, err)
+				results[i] = v
+			}()
+		}
+
+		require.Eventually(t, func() bool { return g.InFlight("key") == callers }, time.Second, time.Millisecond)
+		close(release)
+		wg.Wait()
+
+		assert.Equal(t, int32(1), calls.Load())
+		for _, v := range results {
+			assert.Equal(t, 42, v)
+		}
+		assert.Equal(t, 0, g.InFlight("key"))
+	})
+
+	t.Run("errors are shared but not remembered", func(t *testing.T) {
+		var g flightGroup[int]
+		boom := errors.New("boom")
+		var calls atomic.Int32
+
+		fn := func(ctx context.Context) (int, error) {
+			calls.Add(1)
+			return 0, boom
+		}
+
+		_, err := g.do(context.Background(), "key", fn)
+		require.ErrorIs(t, err, boom)
+
+		_, err = g.do(context.Background(), "key", fn)
+		require.ErrorIs(t, err, boom)
+
+		assert.Equal(t, int32(2), calls.Load())
+	})
+
+	t.Run("canceled caller leaves while others keep waiting", func(t *testing.T) {
+		var g flightGroup[int]
+		release := make(chan struct{})
+		var sharedCanceled atomic.Bool
+
+		fn := func(ctx context.Context) (int, error) {
+			select {
+			case <-release:
+				return 7, nil
+			case <-ctx.Done():
+				sharedCanceled.Store(true)
+				return 0, ctx.Err()
+			}
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		firstErr := make(chan error, 1)
+		go func() {
+			_, err := g.do(ctx, "key", fn)
+			firstErr <- err
+		}()
+
+		secondVal := make(chan int, 1)
+		go func() {
+			v, err := g.do(context.Background(), "key", fn)
+			assert.NoError(t, err)
+			secondVal <- v
+		}()
+
+		require.Eventually(t, func() bool { return g.InFlight("key") == 2 }, time.Second, time.Millisecond)
+
+		cancel()
+		assert.ErrorIs(t, <-firstErr, context.Canceled)
+
+		close(release)
+		assert.Equal(t, 7, <-secondVal)
+		assert.False(t, sharedCanceled.Load())
+	})
+
+	t.Run("work is canceled once every caller gives up", func(t *testing.T) {
+		var g flightGroup[int]
+		sharedDone := make(chan error, 1)
+
+		fn := func(ctx context.Context) (int, error) {
+			<-ctx.Done()
+			sharedDone <- ctx.Err()
+			return 0, ctx.Err()
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		errCh := make(chan error, 1)
+		go func() {
+			_, err := g.do(ctx, "key", fn)
+			errCh <- err
+		}()
+
+		require.Eventually(t, func() bool { return g.InFlight("key") == 1 }, time.Second, time.Millisecond)
+		cancel()
+
+		assert.ErrorIs(t, <-errCh, context.Canceled)
+		assert.ErrorIs(t, <-sharedDone, context.Canceled)
+		assert.Equal(t, 0, g.InFlight("key"))
+	})
+
+	t.Run("shared work inherits the caller deadline", func(t *testing.T) {
+		var g flightGroup[bool]
+
+		deadline := time.Now().Add(time.Minute)
+		ctx, cancel := context.WithDeadline(context.Background(), deadline)
+		defer cancel()
+
+		got, err := g.do(ctx, "key", func(ctx context.Context) (bool, error) {
+			shared, ok := ctx.Deadline()
+			return ok && shared.Equal(deadline), nil
+		})
+		require.NoError(t, err)
+		assert.True(t, got)
+	})
+
+	t.Run("shared work outlives the earliest caller deadline", func(t *testing.T) {
+		var g flightGroup[int]
+		release := make(chan struct{})
+		var sharedErr atomic.Value
+		var sharedDeadline atomic.Value
+
+		fn := func(ctx context.Context) (int, error) {
+			select {
+			case <-release:
+				deadline, _ := ctx.Deadline()
+				sharedDeadline.Store(deadline)
+				return 7, nil
+			case <-ctx.Done():
+				sharedErr.Store(ctx.Err())
+				return 0, ctx.Err()
+			}
+		}
+
+		short, cancelShort := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancelShort()
+		shortErr := make(chan error, 1)
+		go func() {
+			_, err := g.do(short, "key", fn)
+			shortErr <- err
+		}()
+
+		require.Eventually(t, func() bool { return g.InFlight("key") == 1 }, time.Second, time.Millisecond)
+
+		deadline := time.Now().Add(time.Minute)
+		long, cancelLong := context.WithDeadline(context.Background(), deadline)
+		defer cancelLong()
+		longVal := make(chan int, 1)
+		go func() {
+			v, err := g.do(long, "key", fn)
+			assert.NoError(t, err)
+			longVal <- v
+		}()
+
+		require.Eventually(t, func() bool { return g.InFlight("key") == 2 }, time.Second, time.Millisecond)
+
+		assert.ErrorIs(t, <-shortErr, context.DeadlineExceeded)
+
+		close(release)
+		assert.Equal(t, 7, <-longVal)
+		assert.Nil(t, sharedErr.Load())
+		assert.True(t, deadline.Equal(sharedDeadline.Load().(time.Time)))
+	})
+
+	t.Run("shared work stops at the latest caller deadline", func(t *testing.T) {
+		var g flightGroup[int]
+		sharedDone := make(chan error, 1)
+
+		fn := func(ctx context.Context) (int, error) {
+			<-ctx.Done()
+			sharedDone <- ctx.Err()
+			return 0, ctx.Err()
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+
+		_, err := g.do(ctx, "key", fn)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.ErrorIs(t, <-sharedDone, context.DeadlineExceeded)
+		assert.Equal(t, 0, g.InFlight("key"))
+	})
+
+	t.Run("shared work ends before the caller deadline", func(t *testing.T) {
+		g := flightGroup[int]{reserve: 0.5}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+
+		_, err := g.do(ctx, "key", func(ctx context.Context) (int, error) {
+			<-ctx.Done()
+			return 0, ctx.Err()
+		})
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.NoError(t, ctx.Err(), "shared work must give up before the caller does")
+	})
+}
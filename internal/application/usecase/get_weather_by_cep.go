@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/biraneves/fc-labs-weather/internal/application/dto"
 	"github.com/biraneves/fc-labs-weather/internal/application/ports/inbound"
@@ -12,62 +13,236 @@ import (
 	"github.com/biraneves/fc-labs-weather/internal/domain/entity"
 )
 
+const (
+	defaultZipcodeShare    = 0.4
+	defaultUpstreamReserve = 0.1
+)
+
 var (
-	ErrInvalidZipCode  = errors.New("invalid zipcode")     // -> 422
-	ErrZipcodeNotFound = errors.New("cannot find zipcode") // -> 404
+	ErrInvalidZipCode   = errors.New("invalid zipcode")           // -> 422
+	ErrZipcodeNotFound  = errors.New("cannot find zipcode")       // -> 404
+	ErrDeadlineExceeded = errors.New("request deadline exceeded") // -> 504
 )
 
+type BudgetSettings struct {
+	ZipcodeShare    float64
+	UpstreamReserve float64
+}
+
 type GetWeatherByCEPUseCase struct {
 	Zipcode outbound.ZipcodeLookupPort
 	Weather outbound.WeatherProviderPort
+
+	budget BudgetSettings
+
+	zipcodeFlight flightGroup[dto.ViaCEPResponseDto]
+	weatherFlight flightGroup[dto.WeatherAPIResponseDto]
 }
 
 func NewGetWeatherByCEPUseCase(zipcode outbound.ZipcodeLookupPort, weather outbound.WeatherProviderPort) inbound.GetWeatherByCEPUseCase {
-	return &GetWeatherByCEPUseCase{zipcode, weather}
+	return NewGetWeatherByCEPUseCaseWithBudget(zipcode, weather, BudgetSettings{})
+}
+
+func NewGetWeatherByCEPUseCaseWithBudget(zipcode outbound.ZipcodeLookupPort, weather outbound.WeatherProviderPort, budget BudgetSettings) inbound.GetWeatherByCEPUseCase {
+	if budget.ZipcodeShare <= 0 || budget.ZipcodeShare >= 1 {
+		budget.ZipcodeShare = defaultZipcodeShare
+	}
+
+	if budget.UpstreamReserve <= 0 || budget.UpstreamReserve >= 1 {
+		budget.UpstreamReserve = defaultUpstreamReserve
+	}
+
+	return &GetWeatherByCEPUseCase{
+		Zipcode:       zipcode,
+		Weather:       weather,
+		budget:        budget,
+		zipcodeFlight: flightGroup[dto.ViaCEPResponseDto]{reserve: budget.UpstreamReserve},
+		weatherFlight: flightGroup[dto.WeatherAPIResponseDto]{reserve: budget.UpstreamReserve},
+	}
 }
 
-func (g GetWeatherByCEPUseCase) Execute(ctx context.Context, request dto.RequestInDto) (dto.RequestOutDto, error) {
+func (g *GetWeatherByCEPUseCase) Execute(ctx context.Context, request dto.RequestInDto) (dto.RequestOutDto, error) {
 	cep, err := entity.NewCep(request.CEP.String())
+	if errors.Is(err, entity.ErrZipCodeUnallocated) {
+		return dto.RequestOutDto{}, ErrZipcodeNotFound
+	}
 	if err != nil {
 		return dto.RequestOutDto{}, ErrInvalidZipCode
 	}
 
-	viaResp, err := g.Zipcode.Find(ctx, dto.ViaCEPRequestDto{CEP: cep})
+	if deadlineExceeded(ctx) {
+		return dto.RequestOutDto{}, fmt.Errorf("before zipcode lookup: %w", ErrDeadlineExceeded)
+	}
+
+	zipcodeCtx, cancel := g.zipcodeContext(ctx)
+	viaResp, err := g.zipcodeFlight.do(zipcodeCtx, cep.String(), func(ctx context.Context) (dto.ViaCEPResponseDto, error) {
+		return g.Zipcode.Find(ctx, dto.ViaCEPRequestDto{CEP: cep})
+	})
+	cancel()
 	if err != nil {
 		if errors.Is(err, outbound.ErrZipcodeNotFound) {
 			return dto.RequestOutDto{}, ErrZipcodeNotFound
 		}
+		if deadlineExceeded(ctx) {
+			return dto.RequestOutDto{}, fmt.Errorf("zipcode lookup failed: %w: %w", ErrDeadlineExceeded, err)
+		}
+		if errors.Is(err, context.DeadlineExceeded) && !errors.Is(err, outbound.ErrUpstreamTimeout) {
+			return dto.RequestOutDto{}, fmt.Errorf("zipcode lookup failed: %w: %w", outbound.ErrUpstreamTimeout, err)
+		}
 		return dto.RequestOutDto{}, fmt.Errorf("zipcode lookup failed: %w", err)
 	}
 
+	if uf := strings.ToUpper(strings.TrimSpace(viaResp.UF)); uf != "" && uf != cep.UF() {
+		return dto.RequestOutDto{}, fmt.Errorf("zipcode lookup returned uf %q for a cep in %q: %w", uf, cep.UF(), outbound.ErrUpstreamMalformedPayload)
+	}
+
 	city := strings.TrimSpace(viaResp.Localidade)
 	if city == "" {
 		return dto.RequestOutDto{}, ErrZipcodeNotFound
 	}
 
-	weatherResp, err := g.Weather.FetchCurrent(ctx, dto.WeatherAPIRequestDto{Q: city})
-	if err != nil {
-		return dto.RequestOutDto{}, fmt.Errorf("weather provider failed: %w", err)
+	if deadlineExceeded(ctx) {
+		return dto.RequestOutDto{}, fmt.Errorf("before weather fetch: %w", ErrDeadlineExceeded)
 	}
 
-	tempC, err := entity.NewTemperatureCelsius(weatherResp.Current.TempC)
+	cityKey := strings.ToLower(strings.Join(strings.Fields(city), " "))
+	weatherResp, err := g.weatherFlight.do(ctx, cityKey, func(ctx context.Context) (dto.WeatherAPIResponseDto, error) {
+		return g.Weather.FetchCurrent(ctx, dto.WeatherAPIRequestDto{Q: city})
+	})
 	if err != nil {
-		return dto.RequestOutDto{}, fmt.Errorf("weather provider returned invalid celsius temperature: %w", err)
+		if deadlineExceeded(ctx) {
+			return dto.RequestOutDto{}, fmt.Errorf("weather provider failed: %w: %w", ErrDeadlineExceeded, err)
+		}
+		if errors.Is(err, context.DeadlineExceeded) && !errors.Is(err, outbound.ErrUpstreamTimeout) {
+			return dto.RequestOutDto{}, fmt.Errorf("weather provider failed: %w: %w", outbound.ErrUpstreamTimeout, err)
+		}
+		return dto.RequestOutDto{}, fmt.Errorf("weather provider failed: %w", err)
 	}
 
-	tempF, err := entity.NewTemperatureFahrenheit(tempC.ToFahrenheit())
+	tempC, err := entity.NewTemperature(weatherResp.Current.TempC, entity.Celsius)
 	if err != nil {
-		return dto.RequestOutDto{}, fmt.Errorf("invalid fahrenheit conversion: %w", err)
+		return dto.RequestOutDto{}, fmt.Errorf("weather provider returned invalid celsius temperature: %w: %w", outbound.ErrUpstreamMalformedPayload, err)
 	}
 
-	tempK, err := entity.NewTemperatureKelvin(tempC.ToKelvin())
-	if err != nil {
-		return dto.RequestOutDto{}, fmt.Errorf("invalid kelvin conversion: %w", err)
+	var observedAt time.Time
+	if weatherResp.Current.LastUpdatedEpoch > 0 {
+		observedAt = time.Unix(int64(weatherResp.Current.LastUpdatedEpoch), 0).UTC()
 	}
 
 	return dto.RequestOutDto{
-		TempC: tempC,
-		TempF: tempF,
-		TempK: tempK,
+		TempC:      tempC,
+		TempF:      tempC.In(entity.Fahrenheit),
+		TempK:      tempC.In(entity.Kelvin),
+		Location:   &dto.LocationDto{CEP: cep.Formatted(), City: city, UF: cep.UF()},
+		Conditions: conditionsFrom(weatherResp, observedAt),
+		Stale:      weatherResp.Stale,
+		ObservedAt: observedAt,
+		Provider:   weatherResp.Provider,
+		Ensemble:   ensembleFrom(weatherResp.Ensemble),
 	}, nil
 }
+
+func conditionsFrom(resp dto.WeatherAPIResponseDto, observedAt time.Time) *dto.ConditionsDto {
+	current := resp.Current
+	conditions := &dto.ConditionsDto{
+		ObservedAt: observedAt,
+		Text:       strings.TrimSpace(current.Condition.Text),
+		Code:       current.Condition.Code,
+		FeelsLike:  optionalTemperatures(current.FeelsLikeC),
+		HeatIndex:  optionalTemperatures(current.HeatIndexC),
+		WindChill:  optionalTemperatures(current.WindChillC),
+		DewPoint:   optionalTemperatures(current.DewPointC),
+	}
+
+	if current.Humidity != nil {
+		if humidity, err := entity.NewRelativeHumidity(*current.Humidity); err == nil {
+			conditions.Humidity = humidity
+		}
+	}
+
+	if current.PressureMb != nil {
+		if pressure, err := entity.NewPressure(*current.PressureMb, entity.Millibar); err == nil {
+			conditions.Pressure = pressure
+		}
+	}
+
+	if current.VisKm != nil {
+		if visibility, err := entity.NewVisibility(*current.VisKm, entity.Kilometers); err == nil {
+			conditions.Visibility = visibility
+		}
+	}
+
+	if current.WindKph != nil {
+		if speed, err := entity.NewWindSpeed(*current.WindKph, entity.KilometersPerHour); err == nil {
+			conditions.Wind.Speed = speed
+		}
+	}
+
+	if current.WindDegree != nil {
+		if direction, err := entity.NewWindDirection(*current.WindDegree); err == nil {
+			conditions.Wind.Direction = direction
+		}
+	}
+
+	return conditions
+}
+
+func ensembleFrom(summary *dto.EnsembleDto) *dto.EnsembleOutDto {
+	if summary == nil {
+		return nil
+	}
+
+	readings := make([]dto.EnsembleReadingOutDto, len(summary.Readings))
+	for i, reading := range summary.Readings {
+		readings[i] = dto.EnsembleReadingOutDto{Provider: reading.Provider, TemperaturesDto: temperaturesFrom(reading.TempC)}
+	}
+
+	return &dto.EnsembleOutDto{
+		Strategy:     summary.Strategy,
+		SpreadC:      summary.SpreadC,
+		ThresholdC:   summary.ThresholdC,
+		Disagreement: summary.Disagreement,
+		Readings:     readings,
+		Failed:       summary.Failed,
+	}
+}
+
+func optionalTemperatures(celsius *float64) dto.TemperaturesDto {
+	if celsius == nil {
+		return dto.TemperaturesDto{}
+	}
+
+	return temperaturesFrom(*celsius)
+}
+
+func temperaturesFrom(celsius float64) dto.TemperaturesDto {
+	temp, err := entity.NewTemperature(celsius, entity.Celsius)
+	if err != nil {
+		return dto.TemperaturesDto{}
+	}
+
+	return dto.TemperaturesDto{TempC: temp, TempF: temp.In(entity.Fahrenheit), TempK: temp.In(entity.Kelvin)}
+}
+
+func (g *GetWeatherByCEPUseCase) zipcodeContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return context.WithCancel(ctx)
+	}
+
+	share := g.budget.ZipcodeShare
+	if share <= 0 || share >= 1 {
+		share = defaultZipcodeShare
+	}
+
+	return context.WithTimeout(ctx, time.Duration(float64(time.Until(deadline))*share))
+}
+
+func deadlineExceeded(ctx context.Context) bool {
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return true
+	}
+
+	deadline, ok := ctx.Deadline()
+	return ok && !time.Now().Before(deadline)
+}
@@ -3,12 +3,20 @@ package usecase_test
 import (
 	"context"
 	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/biraneves/fc-labs-weather/internal/application/dto"
 	"github.com/biraneves/fc-labs-weather/internal/application/ports/outbound"
 	"github.com/biraneves/fc-labs-weather/internal/application/usecase"
 	"github.com/biraneves/fc-labs-weather/internal/domain/entity"
+	"github.com/biraneves/fc-labs-weather/internal/infrastructure/cache"
+	"github.com/biraneves/fc-labs-weather/internal/infrastructure/resilience"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -56,12 +64,52 @@ func TestGetWeatherByCEPUseCase_Execute(t *testing.T) {
 	baseWeatherResp := dto.WeatherAPIResponseDto{}
 	baseWeatherResp.Current.TempC = 25.0
 
+	staleWeatherResp := baseWeatherResp
+	staleWeatherResp.Stale = true
+
+	invalidWeatherResp := baseWeatherResp
+	invalidWeatherResp.Current.TempC = -300
+
+	observedWeatherResp := baseWeatherResp
+	observedWeatherResp.Current.LastUpdatedEpoch = 1735732800
+
+	providerWeatherResp := baseWeatherResp
+	providerWeatherResp.Provider = "openmeteo"
+
+	ensembleWeatherResp := baseWeatherResp
+	ensembleWeatherResp.Ensemble = &dto.EnsembleDto{
+		Strategy: "median",
+		SpreadC:  1.5,
+		Readings: []dto.EnsembleReadingDto{{Provider: "weatherapi", TempC: 24.25}, {Provider: "openmeteo", TempC: 25.75}},
+		Failed:   []string{"backup"},
+	}
+
+	detailedWeatherResp := observedWeatherResp
+	detailedWeatherResp.Current.Condition.Text = " Partly cloudy "
+	detailedWeatherResp.Current.Condition.Code = 1003
+	detailedWeatherResp.Current.Humidity = float64Ptr(65)
+	detailedWeatherResp.Current.WindKph = float64Ptr(11.2)
+	detailedWeatherResp.Current.WindDegree = float64Ptr(220)
+	detailedWeatherResp.Current.PressureMb = float64Ptr(1015)
+	detailedWeatherResp.Current.VisKm = float64Ptr(10)
+	detailedWeatherResp.Current.FeelsLikeC = float64Ptr(26.1)
+	detailedWeatherResp.Current.HeatIndexC = float64Ptr(26.4)
+	detailedWeatherResp.Current.WindChillC = float64Ptr(25.0)
+	detailedWeatherResp.Current.DewPointC = float64Ptr(17.9)
+
+	malformedDetailsResp := baseWeatherResp
+	malformedDetailsResp.Current.Humidity = float64Ptr(140)
+	malformedDetailsResp.Current.WindKph = float64Ptr(-3)
+	malformedDetailsResp.Current.VisKm = float64Ptr(-1)
+	malformedDetailsResp.Current.FeelsLikeC = float64Ptr(-400)
+
 	tests := []struct {
 		name          string
 		input         dto.RequestInDto
 		zipcodeStub   outbound.ZipcodeLookupPort
 		weatherStub   outbound.WeatherProviderPort
 		expectedError string
+		wantError     error
 		assertSuccess func(t *testing.T, out dto.RequestOutDto)
 	}{
 		{
@@ -71,6 +119,21 @@ func TestGetWeatherByCEPUseCase_Execute(t *testing.T) {
 			weatherStub:   fakeWeatherPort{},
 			expectedError: usecase.ErrInvalidZipCode.Error(),
 		},
+		{
+			name:          "unallocated zipcode",
+			input:         dto.RequestInDto{CEP: entity.Cep("00999999")},
+			zipcodeStub:   fakeZipcodePort{},
+			weatherStub:   fakeWeatherPort{},
+			expectedError: usecase.ErrZipcodeNotFound.Error(),
+		},
+		{
+			name:          "zipcode state mismatch",
+			input:         dto.RequestInDto{CEP: validCEP},
+			zipcodeStub:   fakeZipcodePort{resp: dto.ViaCEPResponseDto{Localidade: "Rio de Janeiro", UF: "RJ"}},
+			weatherStub:   fakeWeatherPort{},
+			expectedError: `zipcode lookup returned uf "RJ" for a cep in "SP"`,
+			wantError:     outbound.ErrUpstreamMalformedPayload,
+		},
 		{
 			name:          "zipcode not found",
 			input:         dto.RequestInDto{CEP: validCEP},
@@ -92,6 +155,22 @@ func TestGetWeatherByCEPUseCase_Execute(t *testing.T) {
 			weatherStub:   fakeWeatherPort{},
 			expectedError: usecase.ErrZipcodeNotFound.Error(),
 		},
+		{
+			name:          "upstream error is propagated",
+			input:         dto.RequestInDto{CEP: validCEP},
+			zipcodeStub:   fakeZipcodePort{resp: dto.ViaCEPResponseDto{Localidade: "São Paulo"}},
+			weatherStub:   fakeWeatherPort{err: outbound.ErrUpstreamTimeout},
+			expectedError: "weather provider failed",
+			wantError:     outbound.ErrUpstreamTimeout,
+		},
+		{
+			name:          "invalid temperature from provider",
+			input:         dto.RequestInDto{CEP: validCEP},
+			zipcodeStub:   fakeZipcodePort{resp: dto.ViaCEPResponseDto{Localidade: "São Paulo"}},
+			weatherStub:   fakeWeatherPort{resp: invalidWeatherResp},
+			expectedError: "weather provider returned invalid celsius temperature",
+			wantError:     outbound.ErrUpstreamMalformedPayload,
+		},
 		{
 			name:          "weather service failed",
 			input:         dto.RequestInDto{CEP: validCEP},
@@ -109,6 +188,123 @@ func TestGetWeatherByCEPUseCase_Execute(t *testing.T) {
 				require.True(t, out.TempF.IsValid())
 				require.True(t, out.TempK.IsValid())
 				assert.InDelta(t, 25.0, out.TempC.Value(), 1e-3)
+				assert.False(t, out.Stale)
+				assert.True(t, out.ObservedAt.IsZero())
+			},
+		},
+		{
+			name:        "location and conditions",
+			input:       dto.RequestInDto{CEP: validCEP},
+			zipcodeStub: fakeZipcodePort{resp: dto.ViaCEPResponseDto{Localidade: " São Paulo ", UF: "SP"}},
+			weatherStub: fakeWeatherPort{resp: detailedWeatherResp},
+			assertSuccess: func(t *testing.T, out dto.RequestOutDto) {
+				require.NotNil(t, out.Location)
+				assert.Equal(t, dto.LocationDto{CEP: "01001-000", City: "São Paulo", UF: "SP"}, *out.Location)
+
+				require.NotNil(t, out.Conditions)
+				c := out.Conditions
+				assert.Equal(t, time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC), c.ObservedAt)
+				assert.Equal(t, "Partly cloudy", c.Text)
+				assert.Equal(t, 1003, c.Code)
+				assert.Equal(t, 65.0, c.Humidity.Percent())
+				assert.Equal(t, 11.2, c.Wind.Speed.Kph())
+				assert.Equal(t, 220.0, c.Wind.Direction.Degrees())
+				assert.Equal(t, 1015.0, c.Pressure.Hpa())
+				assert.InDelta(t, 29.97, c.Pressure.InHg(), 1e-2)
+				assert.Equal(t, 10.0, c.Visibility.Kilometers())
+				assert.InDelta(t, 6.21, c.Visibility.Miles(), 1e-2)
+				assert.Equal(t, 26.1, c.FeelsLike.TempC.Value())
+				assert.InDelta(t, 78.98, c.FeelsLike.TempF.Value(), 1e-9)
+				assert.InDelta(t, 299.25, c.FeelsLike.TempK.Value(), 1e-9)
+				assert.Equal(t, 26.4, c.HeatIndex.TempC.Value())
+				assert.Equal(t, 25.0, c.WindChill.TempC.Value())
+				assert.Equal(t, 17.9, c.DewPoint.TempC.Value())
+			},
+		},
+		{
+			name:        "malformed conditions are dropped",
+			input:       dto.RequestInDto{CEP: validCEP},
+			zipcodeStub: fakeZipcodePort{resp: dto.ViaCEPResponseDto{Localidade: "São Paulo"}},
+			weatherStub: fakeWeatherPort{resp: malformedDetailsResp},
+			assertSuccess: func(t *testing.T, out dto.RequestOutDto) {
+				assert.InDelta(t, 25.0, out.TempC.Value(), 1e-3)
+				require.NotNil(t, out.Conditions)
+				assert.False(t, out.Conditions.Humidity.IsValid())
+				assert.False(t, out.Conditions.Wind.Speed.IsValid())
+				assert.False(t, out.Conditions.Pressure.IsValid())
+				assert.False(t, out.Conditions.Visibility.IsValid())
+				assert.Equal(t, dto.TemperaturesDto{}, out.Conditions.FeelsLike)
+			},
+		},
+		{
+			name:        "missing conditions are left out",
+			input:       dto.RequestInDto{CEP: validCEP},
+			zipcodeStub: fakeZipcodePort{resp: dto.ViaCEPResponseDto{Localidade: "São Paulo"}},
+			weatherStub: fakeWeatherPort{resp: baseWeatherResp},
+			assertSuccess: func(t *testing.T, out dto.RequestOutDto) {
+				require.NotNil(t, out.Conditions)
+				assert.False(t, out.Conditions.Humidity.IsValid())
+				assert.False(t, out.Conditions.Wind.Speed.IsValid())
+				assert.False(t, out.Conditions.Wind.Direction.IsValid())
+				assert.False(t, out.Conditions.Pressure.IsValid())
+				assert.False(t, out.Conditions.Visibility.IsValid())
+				assert.Equal(t, dto.TemperaturesDto{}, out.Conditions.FeelsLike)
+				assert.Equal(t, dto.TemperaturesDto{}, out.Conditions.HeatIndex)
+				assert.Equal(t, dto.TemperaturesDto{}, out.Conditions.WindChill)
+				assert.Equal(t, dto.TemperaturesDto{}, out.Conditions.DewPoint)
+			},
+		},
+		{
+			name:        "matching zipcode state",
+			input:       dto.RequestInDto{CEP: validCEP},
+			zipcodeStub: fakeZipcodePort{resp: dto.ViaCEPResponseDto{Localidade: "São Paulo", UF: "sp"}},
+			weatherStub: fakeWeatherPort{resp: baseWeatherResp},
+			assertSuccess: func(t *testing.T, out dto.RequestOutDto) {
+				assert.InDelta(t, 25.0, out.TempC.Value(), 1e-3)
+			},
+		},
+		{
+			name:        "observation time",
+			input:       dto.RequestInDto{CEP: validCEP},
+			zipcodeStub: fakeZipcodePort{resp: dto.ViaCEPResponseDto{Localidade: "São Paulo"}},
+			weatherStub: fakeWeatherPort{resp: observedWeatherResp},
+			assertSuccess: func(t *testing.T, out dto.RequestOutDto) {
+				assert.Equal(t, time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC), out.ObservedAt)
+			},
+		},
+		{
+			name:        "stale weather data",
+			input:       dto.RequestInDto{CEP: validCEP},
+			zipcodeStub: fakeZipcodePort{resp: dto.ViaCEPResponseDto{Localidade: "São Paulo"}},
+			weatherStub: fakeWeatherPort{resp: staleWeatherResp},
+			assertSuccess: func(t *testing.T, out dto.RequestOutDto) {
+				assert.InDelta(t, 25.0, out.TempC.Value(), 1e-3)
+				assert.True(t, out.Stale)
+			},
+		},
+		{
+			name:        "serving provider",
+			input:       dto.RequestInDto{CEP: validCEP},
+			zipcodeStub: fakeZipcodePort{resp: dto.ViaCEPResponseDto{Localidade: "São Paulo"}},
+			weatherStub: fakeWeatherPort{resp: providerWeatherResp},
+			assertSuccess: func(t *testing.T, out dto.RequestOutDto) {
+				assert.Equal(t, "openmeteo", out.Provider)
+			},
+		},
+		{
+			name:        "ensemble summary",
+			input:       dto.RequestInDto{CEP: validCEP},
+			zipcodeStub: fakeZipcodePort{resp: dto.ViaCEPResponseDto{Localidade: "São Paulo"}},
+			weatherStub: fakeWeatherPort{resp: ensembleWeatherResp},
+			assertSuccess: func(t *testing.T, out dto.RequestOutDto) {
+				require.NotNil(t, out.Ensemble)
+				assert.Equal(t, "median", out.Ensemble.Strategy)
+				assert.Equal(t, 1.5, out.Ensemble.SpreadC)
+				assert.Equal(t, []string{"backup"}, out.Ensemble.Failed)
+				require.Len(t, out.Ensemble.Readings, 2)
+				assert.Equal(t, "openmeteo", out.Ensemble.Readings[1].Provider)
+				assert.Equal(t, 25.75, out.Ensemble.Readings[1].TempC.Value())
+				assert.InDelta(t, 78.35, out.Ensemble.Readings[1].TempF.Value(), 1e-9)
 			},
 		},
 	}
@@ -125,6 +321,9 @@ func TestGetWeatherByCEPUseCase_Execute(t *testing.T) {
 			if tt.expectedError != "" {
 				require.Error(t, err)
 				assert.Contains(t, err.Error(), tt.expectedError)
+				if tt.wantError != nil {
+					assert.ErrorIs(t, err, tt.wantError)
+				}
 			} else {
 				require.NoError(t, err)
 				require.NotNil(t, tt.assertSuccess)
@@ -151,3 +350,271 @@ type fakeWeatherPort struct {
 func (w fakeWeatherPort) FetchCurrent(ctx context.Context, req dto.WeatherAPIRequestDto) (dto.WeatherAPIResponseDto, error) {
 	return w.resp, w.err
 }
+
+func TestGetWeatherByCEPUseCase_Execute_Coalescing(t *testing.T) {
+	t.Run("identical ceps share one zipcode lookup", func(t *testing.T) {
+		zip := &blockingZipcodePort{
+			release: make(chan struct{}),
+			resp:    dto.ViaCEPResponseDto{Localidade: "São Paulo"},
+		}
+		weather := &blockingWeatherPort{}
+		weather.resp.Current.TempC = 20
+		uc := &usecase.GetWeatherByCEPUseCase{Zipcode: zip, Weather: weather}
+
+		const callers = 4
+		var wg sync.WaitGroup
+		for range callers {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				out, err := uc.Execute(context.Background(), dto.RequestInDto{CEP: entity.Cep("01001000")})
+				assert.NoError(t, err)
+				assert.InDelta(t, 20.0, out.TempC.Value(), 1e-3)
+			}()
+		}
+
+		require.Eventually(t, func() bool { return uc.ZipcodeWaiters("01001000") == callers }, time.Second, time.Millisecond)
+		close(zip.release)
+		wg.Wait()
+
+		assert.Equal(t, int32(1), zip.calls.Load())
+	})
+
+	t.Run("ceps resolving to the same city share one weather fetch", func(t *testing.T) {
+		zip := &blockingZipcodePort{resp: dto.ViaCEPResponseDto{Localidade: "São Paulo"}}
+		weather := &blockingWeatherPort{release: make(chan struct{})}
+		weather.resp.Current.TempC = 22
+		uc := &usecase.GetWeatherByCEPUseCase{Zipcode: zip, Weather: weather}
+
+		ceps := []entity.Cep{"01001000", "01310100", "04538133"}
+		var wg sync.WaitGroup
+		for _, cep := range ceps {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, err := uc.Execute(context.Background(), dto.RequestInDto{CEP: cep})
+				assert.NoError(t, err)
+			}()
+		}
+
+		require.Eventually(t, func() bool { return uc.WeatherWaiters("são paulo") == len(ceps) }, time.Second, time.Millisecond)
+		close(weather.release)
+		wg.Wait()
+
+		assert.Equal(t, int32(len(ceps)), zip.calls.Load())
+		assert.Equal(t, int32(1), weather.calls.Load())
+	})
+
+	t.Run("canceled caller does not affect other waiters", func(t *testing.T) {
+		zip := &blockingZipcodePort{
+			release: make(chan struct{}),
+			resp:    dto.ViaCEPResponseDto{Localidade: "Recife"},
+		}
+		weather := &blockingWeatherPort{}
+		uc := &usecase.GetWeatherByCEPUseCase{Zipcode: zip, Weather: weather}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		canceledErr := make(chan error, 1)
+		go func() {
+			_, err := uc.Execute(ctx, dto.RequestInDto{CEP: entity.Cep("50030230")})
+			canceledErr <- err
+		}()
+
+		okErr := make(chan error, 1)
+		go func() {
+			_, err := uc.Execute(context.Background(), dto.RequestInDto{CEP: entity.Cep("50030230")})
+			okErr <- err
+		}()
+
+		require.Eventually(t, func() bool { return uc.ZipcodeWaiters("50030230") == 2 }, time.Second, time.Millisecond)
+		cancel()
+		assert.ErrorIs(t, <-canceledErr, context.Canceled)
+
+		close(zip.release)
+		assert.NoError(t, <-okErr)
+		assert.Equal(t, int32(1), zip.calls.Load())
+	})
+}
+
+type blockingZipcodePort struct {
+	release chan struct{}
+	resp    dto.ViaCEPResponseDto
+	calls   atomic.Int32
+}
+
+func (z *blockingZipcodePort) Find(ctx context.Context, request dto.ViaCEPRequestDto) (dto.ViaCEPResponseDto, error) {
+	z.calls.Add(1)
+	if z.release != nil {
+		select {
+		case <-z.release:
+		case <-ctx.Done():
+			return dto.ViaCEPResponseDto{}, ctx.Err()
+		}
+	}
+
+	return z.resp, nil
+}
+
+type blockingWeatherPort struct {
+	release chan struct{}
+	resp    dto.WeatherAPIResponseDto
+	calls   atomic.Int32
+}
+
+func (w *blockingWeatherPort) FetchCurrent(ctx context.Context, req dto.WeatherAPIRequestDto) (dto.WeatherAPIResponseDto, error) {
+	w.calls.Add(1)
+	if w.release != nil {
+		select {
+		case <-w.release:
+		case <-ctx.Done():
+			return dto.WeatherAPIResponseDto{}, ctx.Err()
+		}
+	}
+
+	return w.resp, nil
+}
+
+type deadlineZipcodePort struct {
+	blockingZipcodePort
+	remaining atomic.Int64
+}
+
+func (z *deadlineZipcodePort) Find(ctx context.Context, request dto.ViaCEPRequestDto) (dto.ViaCEPResponseDto, error) {
+	if deadline, ok := ctx.Deadline(); ok {
+		z.remaining.Store(int64(time.Until(deadline)))
+	}
+
+	return z.blockingZipcodePort.Find(ctx, request)
+}
+
+type deadlineWeatherPort struct {
+	blockingWeatherPort
+	remaining atomic.Int64
+}
+
+func (w *deadlineWeatherPort) FetchCurrent(ctx context.Context, req dto.WeatherAPIRequestDto) (dto.WeatherAPIResponseDto, error) {
+	if deadline, ok := ctx.Deadline(); ok {
+		w.remaining.Store(int64(time.Until(deadline)))
+	}
+
+	return w.blockingWeatherPort.FetchCurrent(ctx, req)
+}
+
+func TestGetWeatherByCEPUseCase_Execute_Budget(t *testing.T) {
+	cep := dto.RequestInDto{CEP: entity.Cep("01001000")}
+
+	t.Run("budget split between phases", func(t *testing.T) {
+		zip := &deadlineZipcodePort{}
+		zip.resp = dto.ViaCEPResponseDto{Localidade: "São Paulo"}
+		weather := &deadlineWeatherPort{}
+		weather.resp.Current.TempC = 20
+		uc := usecase.NewGetWeatherByCEPUseCaseWithBudget(zip, weather, usecase.BudgetSettings{ZipcodeShare: 0.25})
+
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+
+		_, err := uc.Execute(ctx, cep)
+		require.NoError(t, err)
+
+		assert.LessOrEqual(t, time.Duration(zip.remaining.Load()), 500*time.Millisecond)
+		assert.Greater(t, time.Duration(zip.remaining.Load()), 400*time.Millisecond)
+		assert.Greater(t, time.Duration(weather.remaining.Load()), 1500*time.Millisecond)
+	})
+
+	t.Run("exhausted before zipcode lookup", func(t *testing.T) {
+		zip := &deadlineZipcodePort{}
+		uc := usecase.NewGetWeatherByCEPUseCaseWithBudget(zip, &deadlineWeatherPort{}, usecase.BudgetSettings{})
+
+		ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Millisecond))
+		defer cancel()
+
+		_, err := uc.Execute(ctx, cep)
+		require.ErrorIs(t, err, usecase.ErrDeadlineExceeded)
+		assert.Zero(t, zip.calls.Load())
+	})
+
+	t.Run("zipcode phase share expired", func(t *testing.T) {
+		zip := &deadlineZipcodePort{}
+		zip.release = make(chan struct{})
+		uc := usecase.NewGetWeatherByCEPUseCaseWithBudget(zip, &deadlineWeatherPort{}, usecase.BudgetSettings{ZipcodeShare: 0.1})
+
+		ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+		defer cancel()
+
+		_, err := uc.Execute(ctx, cep)
+		require.ErrorIs(t, err, outbound.ErrUpstreamTimeout)
+		assert.NotErrorIs(t, err, usecase.ErrDeadlineExceeded)
+	})
+
+	t.Run("weather fetch gives up before the request deadline", func(t *testing.T) {
+		zip := &deadlineZipcodePort{}
+		zip.resp = dto.ViaCEPResponseDto{Localidade: "São Paulo"}
+		weather := &deadlineWeatherPort{}
+		weather.release = make(chan struct{})
+		uc := usecase.NewGetWeatherByCEPUseCaseWithBudget(zip, weather, usecase.BudgetSettings{})
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		_, err := uc.Execute(ctx, cep)
+		require.ErrorIs(t, err, outbound.ErrUpstreamTimeout)
+		assert.NotErrorIs(t, err, usecase.ErrDeadlineExceeded)
+		assert.NoError(t, ctx.Err())
+		assert.Equal(t, int32(1), weather.calls.Load())
+	})
+}
+
+type hangingWeatherPort struct {
+	calls atomic.Int32
+}
+
+func (h *hangingWeatherPort) FetchCurrent(ctx context.Context, req dto.WeatherAPIRequestDto) (dto.WeatherAPIResponseDto, error) {
+	if h.calls.Add(1) == 1 {
+		var resp dto.WeatherAPIResponseDto
+		resp.Current.TempC = 21
+		return resp, nil
+	}
+
+	<-ctx.Done()
+	return dto.WeatherAPIResponseDto{}, fmt.Errorf("%w: %w", outbound.ErrUpstreamTimeout, ctx.Err())
+}
+
+func TestGetWeatherByCEPUseCase_Execute_HangingUpstreamBehindDecorators(t *testing.T) {
+	logger := slog.New(slog.NewJSONHandler(io.Discard, nil))
+	upstream := &hangingWeatherPort{}
+	breaker := resilience.NewBreaker(resilience.BreakerSettings{
+		Name:             "weatherapi",
+		FailureRatio:     0.5,
+		MinRequests:      1,
+		Window:           time.Minute,
+		CoolDown:         time.Minute,
+		HalfOpenRequests: 1,
+	}, resilience.IsUpstreamFailure, logger)
+	weather := cache.NewWeatherCache(resilience.NewWeatherBreaker(upstream, breaker), 10, time.Millisecond, time.Hour, logger)
+	zip := &fakeZipcodePort{resp: dto.ViaCEPResponseDto{Localidade: "São Paulo"}}
+	uc := usecase.NewGetWeatherByCEPUseCaseWithBudget(zip, weather, usecase.BudgetSettings{})
+	cep := dto.RequestInDto{CEP: entity.Cep("01001000")}
+
+	warm, err := uc.Execute(context.Background(), cep)
+	require.NoError(t, err)
+	require.False(t, warm.Stale)
+
+	time.Sleep(5 * time.Millisecond)
+
+	for range 3 {
+		ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+		out, err := uc.Execute(ctx, cep)
+		cancel()
+
+		require.NoError(t, err)
+		assert.True(t, out.Stale)
+		assert.InDelta(t, 21.0, out.TempC.Value(), 1e-9)
+	}
+
+	assert.Equal(t, resilience.StateOpen, breaker.State())
+	assert.Equal(t, int32(2), upstream.calls.Load())
+}
+
+func float64Ptr(v float64) *float64 {
+	return &v
+}
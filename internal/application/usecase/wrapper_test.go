@@ -0,0 +1,20 @@
+package usecase
+
+func (g *flightGroup[T]) InFlight(key string) int {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if c, ok := g.calls[key]; ok {
+		return c.waiters
+	}
+
+	return 0
+}
+
+func (g *GetWeatherByCEPUseCase) ZipcodeWaiters(key string) int {
+	return g.zipcodeFlight.InFlight(key)
+}
+
+func (g *GetWeatherByCEPUseCase) WeatherWaiters(key string) int {
+	return g.weatherFlight.InFlight(key)
+}
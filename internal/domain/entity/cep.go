@@ -6,6 +6,7 @@ import (
 	"regexp"
 	"slices"
 	"strings"
+	"unicode"
 )
 
 type Cep string
@@ -14,11 +15,28 @@ var (
 	ErrZipCodeEmpty         = errors.New("zip code: empty")
 	ErrZipCodeInvalidLength = errors.New("zip code: invalid length")
 	ErrZipCodeInvalidChars  = errors.New("zip code: invalid characters - only digits allowed")
+	ErrZipCodeInvalidFormat = errors.New("zip code: invalid format")
 	ErrZipCodeEqualChars    = errors.New("zip code: eight equal digits not allowed")
+	ErrZipCodeUnallocated   = errors.New("zip code: range not allocated to any state")
 )
 
 var zipRegex = regexp.MustCompile(`^[0-9]{8}$`)
 
+var zipFormats = []*regexp.Regexp{
+	zipRegex,
+	regexp.MustCompile(`^[0-9]{5}-[0-9]{3}$`),
+	regexp.MustCompile(`^[0-9]{2}\.[0-9]{3}-[0-9]{3}$`),
+}
+
+var dashReplacer = strings.NewReplacer(
+	"\u2010", "-",
+	"\u2011", "-",
+	"\u2012", "-",
+	"\u2013", "-",
+	"\u2014", "-",
+	"\u2212", "-",
+)
+
 var notAllowed = []string{
 	"00000000",
 	"11111111",
@@ -33,31 +51,78 @@ var notAllowed = []string{
 }
 
 func NewCep(cep string) (Cep, error) {
-	raw := strings.TrimSpace(cep)
+	raw := normalizeCep(cep)
 
 	if raw == "" {
 		return "", ErrZipCodeEmpty
 	}
 
-	if len(raw) != 8 {
-		return "", ErrZipCodeInvalidLength
+	if !slices.ContainsFunc(zipFormats, func(format *regexp.Regexp) bool { return format.MatchString(raw) }) {
+		return "", classifyCep(raw)
 	}
 
-	if !zipRegex.MatchString(raw) {
-		return "", ErrZipCodeInvalidChars
-	}
+	digits := strings.NewReplacer("-", "", ".", "").Replace(raw)
 
-	if slices.Contains(notAllowed, raw) {
+	if slices.Contains(notAllowed, digits) {
 		return "", ErrZipCodeEqualChars
 	}
 
-	return Cep(raw), nil
+	if ufFor(digits) == "" {
+		return "", ErrZipCodeUnallocated
+	}
+
+	return Cep(digits), nil
+}
+
+func normalizeCep(cep string) string {
+	raw := strings.TrimFunc(cep, isCepPadding)
+
+	if len(raw) >= 3 && strings.EqualFold(raw[:3], "cep") {
+		raw = strings.TrimFunc(raw[3:], isCepPadding)
+	}
+
+	return dashReplacer.Replace(raw)
+}
+
+func isCepPadding(r rune) bool {
+	return unicode.IsSpace(r) || unicode.IsPunct(r)
+}
+
+func classifyCep(raw string) error {
+	digits := 0
+	for _, r := range raw {
+		switch {
+		case r >= '0' && r <= '9':
+			digits++
+		case r == '-' || r == '.':
+		default:
+			return ErrZipCodeInvalidChars
+		}
+	}
+
+	if digits != 8 {
+		return ErrZipCodeInvalidLength
+	}
+
+	return ErrZipCodeInvalidFormat
 }
 
 func (c Cep) String() string {
 	return string(c)
 }
 
+func (c Cep) Formatted() string {
+	if len(c) != 8 {
+		return c.String()
+	}
+
+	return string(c[:5]) + "-" + string(c[5:])
+}
+
+func (c Cep) UF() string {
+	return ufFor(c.String())
+}
+
 func (c Cep) IsZero() bool {
 	return c.String() == ""
 }
@@ -0,0 +1,65 @@
+package entity
+
+import (
+	_ "embed"
+	"encoding/csv"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+//go:embed cep_ranges.csv
+var cepRangesCSV string
+
+type cepRange struct {
+	uf    string
+	start string
+	end   string
+}
+
+var cepRanges = mustParseCepRanges(cepRangesCSV)
+
+func mustParseCepRanges(raw string) []cepRange {
+	records, err := csv.NewReader(strings.NewReader(raw)).ReadAll()
+	if err != nil {
+		panic(fmt.Sprintf("cep ranges: %v", err))
+	}
+
+	ranges := make([]cepRange, 0, len(records))
+	for _, record := range records[1:] {
+		r := cepRange{uf: record[0], start: record[1], end: record[2]}
+		if !zipRegex.MatchString(r.start) || !zipRegex.MatchString(r.end) || r.start > r.end {
+			panic(fmt.Sprintf("cep ranges: invalid range %v", record))
+		}
+		ranges = append(ranges, r)
+	}
+
+	slices.SortFunc(ranges, func(a, b cepRange) int { return strings.Compare(a.start, b.start) })
+
+	for i := 1; i < len(ranges); i++ {
+		if ranges[i].start <= ranges[i-1].end {
+			panic(fmt.Sprintf("cep ranges: %s overlaps %s", ranges[i].start, ranges[i-1].end))
+		}
+	}
+
+	return ranges
+}
+
+func ufFor(digits string) string {
+	if !zipRegex.MatchString(digits) {
+		return ""
+	}
+
+	i, found := slices.BinarySearchFunc(cepRanges, digits, func(r cepRange, target string) int {
+		return strings.Compare(r.start, target)
+	})
+	if !found {
+		i--
+	}
+
+	if i < 0 || digits > cepRanges[i].end {
+		return ""
+	}
+
+	return cepRanges[i].uf
+}
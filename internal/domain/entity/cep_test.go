@@ -44,9 +44,15 @@ func TestNewCep(t *testing.T) {
 			expectedError: "zip code: invalid length",
 		},
 		{
-			name:          "invalid characters",
+			name:          "formatted with missing digit",
 			input:         "7190-050",
 			want:          "",
+			expectedError: "zip code: invalid length",
+		},
+		{
+			name:          "invalid characters",
+			input:         "0719005a",
+			want:          "",
 			expectedError: "zip code: invalid characters - only digits allowed",
 		},
 		{
@@ -55,6 +61,146 @@ func TestNewCep(t *testing.T) {
 			want:          "",
 			expectedError: "zip code: eight equal digits not allowed",
 		},
+		{
+			name:          "formatted repeated digits",
+			input:         "11111-111",
+			want:          "",
+			expectedError: "zip code: eight equal digits not allowed",
+		},
+		{
+			name:          "unallocated range below sao paulo",
+			input:         "00999-999",
+			want:          "",
+			expectedError: "zip code: range not allocated to any state",
+		},
+		{
+			name:          "unallocated range between mato grosso and mato grosso do sul",
+			input:         "78900-000",
+			want:          "",
+			expectedError: "zip code: range not allocated to any state",
+		},
+		{
+			name:  "hyphenated",
+			input: "01310-100",
+			want:  "01310100",
+		},
+		{
+			name:  "dotted and hyphenated",
+			input: "01.310-100",
+			want:  "01310100",
+		},
+		{
+			name:  "en dash",
+			input: "01310\u2013100",
+			want:  "01310100",
+		},
+		{
+			name:  "unicode minus",
+			input: "01.310\u2212100",
+			want:  "01310100",
+		},
+		{
+			name:  "surrounding parentheses",
+			input: "(01310-100)",
+			want:  "01310100",
+		},
+		{
+			name:  "surrounding quotes",
+			input: `"01310100"`,
+			want:  "01310100",
+		},
+		{
+			name:  "trailing punctuation",
+			input: "01310-100.",
+			want:  "01310100",
+		},
+		{
+			name:  "trailing comma and spaces",
+			input: " 01310-100, ",
+			want:  "01310100",
+		},
+		{
+			name:  "cep prefix",
+			input: "CEP: 01310-100",
+			want:  "01310100",
+		},
+		{
+			name:  "lowercase cep prefix without separator",
+			input: "cep 01310100",
+			want:  "01310100",
+		},
+		{
+			name:          "only punctuation",
+			input:         " (-.) ",
+			want:          "",
+			expectedError: "zip code: empty",
+		},
+		{
+			name:          "only prefix",
+			input:         "CEP:",
+			want:          "",
+			expectedError: "zip code: empty",
+		},
+		{
+			name:          "misplaced hyphen",
+			input:         "0131-0100",
+			want:          "",
+			expectedError: "zip code: invalid format",
+		},
+		{
+			name:          "dot without hyphen",
+			input:         "01.310100",
+			want:          "",
+			expectedError: "zip code: invalid format",
+		},
+		{
+			name:          "repeated separators",
+			input:         "01310--100",
+			want:          "",
+			expectedError: "zip code: invalid format",
+		},
+		{
+			name:          "inner whitespace",
+			input:         "01310 100",
+			want:          "",
+			expectedError: "zip code: invalid characters - only digits allowed",
+		},
+		{
+			name:          "slash separator",
+			input:         "01310/100",
+			want:          "",
+			expectedError: "zip code: invalid characters - only digits allowed",
+		},
+		{
+			name:          "mixed letters",
+			input:         "01310-1OO",
+			want:          "",
+			expectedError: "zip code: invalid characters - only digits allowed",
+		},
+		{
+			name:          "full address",
+			input:         "Av. Paulista, 1578 - 01310-200",
+			want:          "",
+			expectedError: "zip code: invalid characters - only digits allowed",
+		},
+		{
+			name:          "two ceps",
+			input:         "01310-100 01310-200",
+			want:          "",
+			expectedError: "zip code: invalid characters - only digits allowed",
+		},
+		{
+			name:          "fullwidth digits",
+			input:         "\uff10\uff11\uff13\uff11\uff10\uff11\uff10\uff10",
+			want:          "",
+			expectedError: "zip code: invalid characters - only digits allowed",
+		},
+		{
+			name:          "hyphenated with extra digit",
+			input:         "01310-1000",
+			want:          "",
+			expectedError: "zip code: invalid length",
+		},
 	}
 
 	for _, tt := range tests {
@@ -125,6 +271,91 @@ func TestCep_String(t *testing.T) {
 	}
 }
 
+func TestCep_Formatted(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{
+			name:  "digits only",
+			input: "01310100",
+			want:  "01310-100",
+		},
+		{
+			name:  "already formatted",
+			input: "01.310-100",
+			want:  "01310-100",
+		},
+		{
+			name:  "invalid zip code",
+			input: "1234567",
+			want:  "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := entity.NewCep(tt.input)
+			require.Equal(t, tt.want, c.Formatted())
+		})
+	}
+}
+
+func TestCep_UF(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{input: "01000000", want: "SP"},
+		{input: "19999999", want: "SP"},
+		{input: "20000000", want: "RJ"},
+		{input: "29100000", want: "ES"},
+		{input: "30130000", want: "MG"},
+		{input: "40010000", want: "BA"},
+		{input: "49000000", want: "SE"},
+		{input: "50030230", want: "PE"},
+		{input: "57020000", want: "AL"},
+		{input: "58010000", want: "PB"},
+		{input: "59010000", want: "RN"},
+		{input: "60010000", want: "CE"},
+		{input: "64000000", want: "PI"},
+		{input: "65010000", want: "MA"},
+		{input: "66010000", want: "PA"},
+		{input: "68899999", want: "PA"},
+		{input: "68900000", want: "AP"},
+		{input: "69010000", want: "AM"},
+		{input: "69300000", want: "RR"},
+		{input: "69400000", want: "AM"},
+		{input: "69900000", want: "AC"},
+		{input: "70040000", want: "DF"},
+		{input: "72800000", want: "GO"},
+		{input: "73000000", want: "DF"},
+		{input: "74000000", want: "GO"},
+		{input: "76800000", want: "RO"},
+		{input: "77000000", want: "TO"},
+		{input: "78000000", want: "MT"},
+		{input: "79000000", want: "MS"},
+		{input: "80010000", want: "PR"},
+		{input: "88010000", want: "SC"},
+		{input: "90010000", want: "RS"},
+		{input: "99999990", want: "RS"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			c, err := entity.NewCep(tt.input)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, c.UF())
+		})
+	}
+
+	t.Run("invalid zip code", func(t *testing.T) {
+		assert.Empty(t, entity.Cep("123").UF())
+		assert.Empty(t, entity.Cep("").UF())
+	})
+}
+
 func TestCep_IsZero(t *testing.T) {
 	tests := []struct {
 		name  string
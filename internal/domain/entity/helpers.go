@@ -1,9 +1,65 @@
 package entity
 
 import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"math"
+	"strconv"
+)
+
+var (
+	ErrValueNan = errors.New("value is NaN")
+	ErrValueInf = errors.New("value is infinite")
 )
 
 func almostEqual(a, b, epsilon float64) bool {
 	return math.Abs(a-b) <= epsilon
 }
+
+func checkFinite(kind string, v float64) error {
+	if math.IsNaN(v) {
+		return fmt.Errorf("%s: %w", kind, ErrValueNan)
+	}
+
+	if math.IsInf(v, 0) {
+		return fmt.Errorf("%s: %w", kind, ErrValueInf)
+	}
+
+	return nil
+}
+
+func convertLinear(v, fromBase, toBase float64) float64 {
+	if fromBase == toBase {
+		return v
+	}
+
+	r := exactRat(v)
+	r.Mul(r, exactRat(fromBase))
+	r.Quo(r, exactRat(toBase))
+
+	f, _ := r.Float64()
+	return f
+}
+
+func marshalDecimal(v float64, decimals int, valid bool) []byte {
+	if !valid {
+		return []byte("null")
+	}
+
+	return []byte(strconv.FormatFloat(roundDecimal(v, decimals, RoundHalfUp), 'f', decimals, 64))
+}
+
+func isNull(b []byte) bool {
+	return bytes.Equal(bytes.TrimSpace(b), []byte("null"))
+}
+
+func unmarshalNumber(b []byte) (float64, error) {
+	var f float64
+	if err := json.Unmarshal(b, &f); err != nil {
+		return 0, err
+	}
+
+	return f, nil
+}
@@ -0,0 +1,68 @@
+package entity
+
+import (
+	"errors"
+)
+
+type RelativeHumidity struct {
+	value float64
+	valid bool
+}
+
+var ErrHumidityOutOfRange = errors.New("relative humidity: must be between 0 and 100 percent")
+
+func NewRelativeHumidity(percent float64) (RelativeHumidity, error) {
+	if err := checkFinite("relative humidity", percent); err != nil {
+		return RelativeHumidity{}, err
+	}
+
+	if percent < 0 || percent > 100 {
+		return RelativeHumidity{}, ErrHumidityOutOfRange
+	}
+
+	return RelativeHumidity{percent, true}, nil
+}
+
+func NewRelativeHumidityFromFraction(fraction float64) (RelativeHumidity, error) {
+	if err := checkFinite("relative humidity", fraction); err != nil {
+		return RelativeHumidity{}, err
+	}
+
+	return NewRelativeHumidity(convertLinear(fraction, 100, 1))
+}
+
+func (h RelativeHumidity) IsValid() bool {
+	return h.valid
+}
+
+func (h RelativeHumidity) Percent() float64 {
+	return h.value
+}
+
+func (h RelativeHumidity) Fraction() float64 {
+	return convertLinear(h.value, 1, 100)
+}
+
+func (h RelativeHumidity) MarshalJSON() ([]byte, error) {
+	return marshalDecimal(h.value, 1, h.valid), nil
+}
+
+func (h *RelativeHumidity) UnmarshalJSON(b []byte) error {
+	if isNull(b) {
+		*h = RelativeHumidity{}
+		return nil
+	}
+
+	f, err := unmarshalNumber(b)
+	if err != nil {
+		return err
+	}
+
+	v, err := NewRelativeHumidity(f)
+	if err != nil {
+		return err
+	}
+
+	*h = v
+	return nil
+}
@@ -0,0 +1,83 @@
+package entity_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/biraneves/fc-labs-weather/internal/domain/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewRelativeHumidity(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     float64
+		wantError error
+		wantJSON  string
+	}{
+		{name: "typical humidity", input: 65, wantJSON: "65.0"},
+		{name: "dry air", input: 0, wantJSON: "0.0"},
+		{name: "saturated air", input: 100, wantJSON: "100.0"},
+		{name: "fractional humidity", input: 72.35, wantJSON: "72.4"},
+		{name: "negative humidity", input: -1, wantError: entity.ErrHumidityOutOfRange},
+		{name: "above saturation", input: 100.1, wantError: entity.ErrHumidityOutOfRange},
+		{name: "not a number", input: math.NaN(), wantError: entity.ErrValueNan},
+		{name: "infinite", input: math.Inf(1), wantError: entity.ErrValueInf},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := entity.NewRelativeHumidity(tt.input)
+
+			if tt.wantError != nil {
+				require.ErrorIs(t, err, tt.wantError)
+				assert.False(t, got.IsValid())
+
+				body, err := got.MarshalJSON()
+				require.NoError(t, err)
+				assert.Equal(t, "null", string(body))
+				return
+			}
+
+			require.NoError(t, err)
+			assert.True(t, got.IsValid())
+			assert.Equal(t, tt.input, got.Percent())
+
+			body, err := got.MarshalJSON()
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantJSON, string(body))
+		})
+	}
+}
+
+func TestRelativeHumidity_Fraction(t *testing.T) {
+	h, err := entity.NewRelativeHumidity(65)
+	require.NoError(t, err)
+	assert.Equal(t, 0.65, h.Fraction())
+
+	fromFraction, err := entity.NewRelativeHumidityFromFraction(0.375)
+	require.NoError(t, err)
+	assert.Equal(t, 37.5, fromFraction.Percent())
+
+	_, err = entity.NewRelativeHumidityFromFraction(1.2)
+	require.ErrorIs(t, err, entity.ErrHumidityOutOfRange)
+
+	_, err = entity.NewRelativeHumidityFromFraction(math.NaN())
+	require.ErrorIs(t, err, entity.ErrValueNan)
+}
+
+func TestRelativeHumidity_UnmarshalJSON(t *testing.T) {
+	var h entity.RelativeHumidity
+	require.NoError(t, h.UnmarshalJSON([]byte(`72.5`)))
+	assert.Equal(t, 72.5, h.Percent())
+
+	var invalid entity.RelativeHumidity
+	require.ErrorIs(t, invalid.UnmarshalJSON([]byte(`101`)), entity.ErrHumidityOutOfRange)
+	assert.False(t, invalid.IsValid())
+
+	require.Error(t, invalid.UnmarshalJSON([]byte(`"72"`)))
+
+	require.NoError(t, h.UnmarshalJSON([]byte(`null`)))
+	assert.False(t, h.IsValid())
+}
@@ -0,0 +1,109 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+type Locale struct {
+	Tag               string
+	DecimalSeparator  string
+	GroupSeparator    string
+	MinGroupingDigits int
+	AttachDegreeSign  bool
+}
+
+var ErrLocaleUnsupported = errors.New("locale: unsupported")
+
+var (
+	LocalePtBR = Locale{Tag: "pt-BR", DecimalSeparator: ",", GroupSeparator: ".", MinGroupingDigits: 1}
+	LocaleEnUS = Locale{Tag: "en-US", DecimalSeparator: ".", GroupSeparator: ",", MinGroupingDigits: 1, AttachDegreeSign: true}
+	LocaleEsES = Locale{Tag: "es-ES", DecimalSeparator: ",", GroupSeparator: ".", MinGroupingDigits: 2}
+)
+
+var DefaultLocale = LocalePtBR
+
+var locales = []Locale{LocalePtBR, LocaleEnUS, LocaleEsES}
+
+func Locales() []Locale {
+	out := make([]Locale, len(locales))
+	copy(out, locales)
+	return out
+}
+
+func ParseLocale(tag string) (Locale, error) {
+	normalized := strings.ToLower(strings.ReplaceAll(strings.TrimSpace(tag), "_", "-"))
+	if normalized == "" {
+		return Locale{}, fmt.Errorf("%w: %q", ErrLocaleUnsupported, tag)
+	}
+
+	for _, locale := range locales {
+		if strings.ToLower(locale.Tag) == normalized {
+			return locale, nil
+		}
+	}
+
+	language, _, _ := strings.Cut(normalized, "-")
+	for _, locale := range locales {
+		if locale.language() == language {
+			return locale, nil
+		}
+	}
+
+	return Locale{}, fmt.Errorf("%w: %q", ErrLocaleUnsupported, tag)
+}
+
+func (l Locale) String() string {
+	return l.Tag
+}
+
+func (l Locale) language() string {
+	language, _, _ := strings.Cut(strings.ToLower(l.Tag), "-")
+	return language
+}
+
+func (l Locale) formatNumber(number string) string {
+	sign := ""
+	if strings.HasPrefix(number, "-") {
+		sign, number = "-", number[1:]
+	}
+
+	integer, fraction, hasFraction := strings.Cut(number, ".")
+	integer = l.group(integer)
+
+	if !hasFraction {
+		return sign + integer
+	}
+
+	return sign + integer + l.DecimalSeparator + fraction
+}
+
+func (l Locale) group(integer string) string {
+	if l.GroupSeparator == "" || len(integer) < 4+max(l.MinGroupingDigits-1, 0) {
+		return integer
+	}
+
+	var b strings.Builder
+	head := len(integer) % 3
+	if head > 0 {
+		b.WriteString(integer[:head])
+	}
+
+	for i := head; i < len(integer); i += 3 {
+		if b.Len() > 0 {
+			b.WriteString(l.GroupSeparator)
+		}
+		b.WriteString(integer[i : i+3])
+	}
+
+	return b.String()
+}
+
+func (l Locale) withUnit(number string, unit TemperatureUnit) string {
+	if l.AttachDegreeSign && strings.HasPrefix(unit.Symbol, "°") {
+		return number + unit.Symbol
+	}
+
+	return number + " " + unit.Symbol
+}
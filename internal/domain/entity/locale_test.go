@@ -0,0 +1,86 @@
+package entity_test
+
+import (
+	"testing"
+
+	"github.com/biraneves/fc-labs-weather/internal/domain/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLocale(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		want      entity.Locale
+		wantError error
+	}{
+		{name: "brazilian portuguese", input: "pt-BR", want: entity.LocalePtBR},
+		{name: "lowercase tag", input: "en-us", want: entity.LocaleEnUS},
+		{name: "underscore separator", input: "es_ES", want: entity.LocaleEsES},
+		{name: "language only", input: "pt", want: entity.LocalePtBR},
+		{name: "other region falls back to language", input: "es-AR", want: entity.LocaleEsES},
+		{name: "english variant", input: "en-GB", want: entity.LocaleEnUS},
+		{name: "unsupported language", input: "fr-FR", wantError: entity.ErrLocaleUnsupported},
+		{name: "empty", input: " ", wantError: entity.ErrLocaleUnsupported},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := entity.ParseLocale(tt.input)
+
+			if tt.wantError != nil {
+				require.ErrorIs(t, err, tt.wantError)
+				assert.Equal(t, entity.Locale{}, got)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func TestLocales(t *testing.T) {
+	assert.Equal(t, []entity.Locale{entity.LocalePtBR, entity.LocaleEnUS, entity.LocaleEsES}, entity.Locales())
+}
+
+func TestTemperature_Format(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     float64
+		unit      entity.TemperatureUnit
+		precision int
+		locale    entity.Locale
+		want      string
+	}{
+		{name: "pt-BR celsius", input: 28.46, unit: entity.Celsius, precision: 1, locale: entity.LocalePtBR, want: "28,5 °C"},
+		{name: "en-US celsius", input: 28.46, unit: entity.Celsius, precision: 1, locale: entity.LocaleEnUS, want: "28.5°C"},
+		{name: "es-ES celsius", input: 28.46, unit: entity.Celsius, precision: 1, locale: entity.LocaleEsES, want: "28,5 °C"},
+		{name: "en-US fahrenheit", input: -4.25, unit: entity.Fahrenheit, precision: 2, locale: entity.LocaleEnUS, want: "-4.25°F"},
+		{name: "en-US kelvin keeps the space", input: 301.65, unit: entity.Kelvin, precision: 2, locale: entity.LocaleEnUS, want: "301.65 K"},
+		{name: "pt-BR kelvin", input: 301.65, unit: entity.Kelvin, precision: 2, locale: entity.LocalePtBR, want: "301,65 K"},
+		{name: "no decimals", input: 28.46, unit: entity.Celsius, precision: 0, locale: entity.LocalePtBR, want: "28 °C"},
+		{name: "pt-BR groups thousands", input: 1234.5, unit: entity.Kelvin, precision: 1, locale: entity.LocalePtBR, want: "1.234,5 K"},
+		{name: "en-US groups thousands", input: 1234.5, unit: entity.Rankine, precision: 1, locale: entity.LocaleEnUS, want: "1,234.5°R"},
+		{name: "es-ES skips four digit grouping", input: 1234.5, unit: entity.Kelvin, precision: 1, locale: entity.LocaleEsES, want: "1234,5 K"},
+		{name: "es-ES groups five digits", input: 12345.5, unit: entity.Kelvin, precision: 1, locale: entity.LocaleEsES, want: "12.345,5 K"},
+		{name: "groups millions", input: 1234567, unit: entity.Kelvin, precision: 0, locale: entity.LocalePtBR, want: "1.234.567 K"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v, err := entity.NewTemperature(tt.input, tt.unit)
+			require.NoError(t, err)
+
+			v, err = v.WithFormat(tt.precision, entity.RoundHalfUp)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.want, v.Format(tt.locale))
+		})
+	}
+}
+
+func TestTemperature_Format_Invalid(t *testing.T) {
+	var v entity.Temperature
+	assert.Empty(t, v.Format(entity.LocaleEnUS))
+}
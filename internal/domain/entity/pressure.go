@@ -0,0 +1,123 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+type PressureUnit struct {
+	Code     string
+	Symbol   string
+	hpa      float64
+	decimals int
+}
+
+type Pressure struct {
+	value float64
+	unit  PressureUnit
+	valid bool
+}
+
+const maxPressureHpa = 1100
+
+var (
+	Hectopascal   = PressureUnit{Code: "hpa", Symbol: "hPa", hpa: 1, decimals: 1}
+	Millibar      = PressureUnit{Code: "mb", Symbol: "mb", hpa: 1, decimals: 1}
+	InchOfMercury = PressureUnit{Code: "inhg", Symbol: "inHg", hpa: 33.8639, decimals: 2}
+)
+
+var (
+	ErrPressureOutOfRange  = fmt.Errorf("pressure: must be above 0 and at most %d hPa", maxPressureHpa)
+	ErrPressureUnknownUnit = errors.New("pressure: unknown unit")
+)
+
+var pressureUnits = []PressureUnit{Hectopascal, Millibar, InchOfMercury}
+
+func ParsePressureUnit(code string) (PressureUnit, error) {
+	normalized := strings.ToLower(strings.TrimSpace(code))
+	for _, unit := range pressureUnits {
+		if unit.Code == normalized {
+			return unit, nil
+		}
+	}
+
+	return PressureUnit{}, fmt.Errorf("%w: %q", ErrPressureUnknownUnit, code)
+}
+
+func (u PressureUnit) String() string {
+	return u.Symbol
+}
+
+func NewPressure(value float64, unit PressureUnit) (Pressure, error) {
+	if !slices.Contains(pressureUnits, unit) {
+		return Pressure{}, fmt.Errorf("%w: %q", ErrPressureUnknownUnit, unit.Code)
+	}
+
+	if err := checkFinite("pressure", value); err != nil {
+		return Pressure{}, err
+	}
+
+	if hpa := convertLinear(value, unit.hpa, Hectopascal.hpa); hpa <= 0 || hpa > maxPressureHpa {
+		return Pressure{}, ErrPressureOutOfRange
+	}
+
+	return Pressure{value, unit, true}, nil
+}
+
+func (p Pressure) IsValid() bool {
+	return p.valid
+}
+
+func (p Pressure) Value() float64 {
+	return p.value
+}
+
+func (p Pressure) Unit() PressureUnit {
+	return p.unit
+}
+
+func (p Pressure) In(unit PressureUnit) Pressure {
+	if !p.IsValid() || !slices.Contains(pressureUnits, unit) {
+		return Pressure{}
+	}
+
+	return Pressure{convertLinear(p.value, p.unit.hpa, unit.hpa), unit, true}
+}
+
+func (p Pressure) Hpa() float64 {
+	return p.In(Hectopascal).value
+}
+
+func (p Pressure) InHg() float64 {
+	return p.In(InchOfMercury).value
+}
+
+func (p Pressure) MarshalJSON() ([]byte, error) {
+	return marshalDecimal(p.Hpa(), Hectopascal.decimals, p.valid), nil
+}
+
+func (p *Pressure) UnmarshalJSON(b []byte) error {
+	if isNull(b) {
+		*p = Pressure{}
+		return nil
+	}
+
+	f, err := unmarshalNumber(b)
+	if err != nil {
+		return err
+	}
+
+	v, err := NewPressure(f, Hectopascal)
+	if err != nil {
+		return err
+	}
+
+	if slices.Contains(pressureUnits, p.unit) {
+		v = v.In(p.unit)
+	}
+
+	*p = v
+	return nil
+}
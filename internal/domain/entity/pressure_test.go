@@ -0,0 +1,140 @@
+package entity_test
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/biraneves/fc-labs-weather/internal/domain/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewPressure(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     float64
+		unit      entity.PressureUnit
+		wantError error
+		wantJSON  string
+	}{
+		{name: "sea level pressure", input: 1013.25, unit: entity.Hectopascal, wantJSON: "1013.3"},
+		{name: "millibar", input: 950, unit: entity.Millibar, wantJSON: "950.0"},
+		{name: "inches of mercury", input: 29.921, unit: entity.InchOfMercury, wantJSON: "1013.2"},
+		{name: "record high", input: 1100, unit: entity.Hectopascal, wantJSON: "1100.0"},
+		{name: "zero pressure", input: 0, unit: entity.Hectopascal, wantError: entity.ErrPressureOutOfRange},
+		{name: "negative pressure", input: -10, unit: entity.Millibar, wantError: entity.ErrPressureOutOfRange},
+		{name: "above plausible range", input: 1100.1, unit: entity.Hectopascal, wantError: entity.ErrPressureOutOfRange},
+		{name: "above plausible range in inches", input: 33, unit: entity.InchOfMercury, wantError: entity.ErrPressureOutOfRange},
+		{name: "unknown unit", input: 1000, wantError: entity.ErrPressureUnknownUnit},
+		{name: "not a number", input: math.NaN(), unit: entity.Hectopascal, wantError: entity.ErrValueNan},
+		{name: "infinite", input: math.Inf(-1), unit: entity.Hectopascal, wantError: entity.ErrValueInf},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := entity.NewPressure(tt.input, tt.unit)
+
+			if tt.wantError != nil {
+				require.ErrorIs(t, err, tt.wantError)
+				assert.Contains(t, err.Error(), "pressure:")
+				assert.False(t, got.IsValid())
+				return
+			}
+
+			require.NoError(t, err)
+			assert.True(t, got.IsValid())
+			assert.Equal(t, tt.input, got.Value())
+			assert.Equal(t, tt.unit, got.Unit())
+
+			body, err := got.MarshalJSON()
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantJSON, string(body))
+		})
+	}
+}
+
+func TestPressure_In(t *testing.T) {
+	p, err := entity.NewPressure(1015, entity.Hectopascal)
+	require.NoError(t, err)
+
+	assert.Equal(t, 1015.0, p.Hpa())
+	assert.Equal(t, 1015.0, p.In(entity.Millibar).Value())
+	assert.InDelta(t, 29.9729, p.InHg(), 1e-4)
+
+	inHg := p.In(entity.InchOfMercury)
+	assert.Equal(t, entity.InchOfMercury, inHg.Unit())
+	assert.InDelta(t, 1015.0, inHg.Hpa(), 1e-9)
+
+	body, err := inHg.MarshalJSON()
+	require.NoError(t, err)
+	assert.Equal(t, "1015.0", string(body))
+
+	assert.False(t, entity.Pressure{}.In(entity.Hectopascal).IsValid())
+	assert.False(t, p.In(entity.PressureUnit{}).IsValid())
+}
+
+func TestParsePressureUnit(t *testing.T) {
+	got, err := entity.ParsePressureUnit(" inHg ")
+	require.NoError(t, err)
+	assert.Equal(t, entity.InchOfMercury, got)
+
+	got, err = entity.ParsePressureUnit("MB")
+	require.NoError(t, err)
+	assert.Equal(t, entity.Millibar, got)
+
+	_, err = entity.ParsePressureUnit("psi")
+	require.ErrorIs(t, err, entity.ErrPressureUnknownUnit)
+}
+
+func TestPressure_UnmarshalJSON(t *testing.T) {
+	var p entity.Pressure
+	require.NoError(t, p.UnmarshalJSON([]byte(`1009.5`)))
+	assert.Equal(t, entity.Hectopascal, p.Unit())
+	assert.Equal(t, 1009.5, p.Value())
+
+	inHg := p.In(entity.InchOfMercury)
+	require.NoError(t, inHg.UnmarshalJSON([]byte(`1015`)))
+	assert.Equal(t, entity.InchOfMercury, inHg.Unit())
+	assert.InDelta(t, 29.9729, inHg.Value(), 1e-4)
+
+	var invalid entity.Pressure
+	require.ErrorIs(t, invalid.UnmarshalJSON([]byte(`-1`)), entity.ErrPressureOutOfRange)
+	assert.False(t, invalid.IsValid())
+
+	require.Error(t, invalid.UnmarshalJSON([]byte(`"1000"`)))
+
+	require.NoError(t, p.UnmarshalJSON([]byte(`null`)))
+	assert.False(t, p.IsValid())
+}
+
+func TestPressure_JSONRoundTrip(t *testing.T) {
+	tests := []struct {
+		name  string
+		input float64
+		unit  entity.PressureUnit
+	}{
+		{name: "millibar", input: 1009.5, unit: entity.Millibar},
+		{name: "inches of mercury", input: 29.92, unit: entity.InchOfMercury},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			original, err := entity.NewPressure(tt.input, tt.unit)
+			require.NoError(t, err)
+
+			body, err := json.Marshal(original)
+			require.NoError(t, err)
+
+			var decoded entity.Pressure
+			require.NoError(t, json.Unmarshal(body, &decoded))
+			assert.Equal(t, entity.Hectopascal, decoded.Unit())
+			assert.InDelta(t, original.Hpa(), decoded.Hpa(), 0.05)
+
+			same := decoded.In(tt.unit)
+			require.NoError(t, json.Unmarshal(body, &same))
+			assert.Equal(t, tt.unit, same.Unit())
+			assert.InDelta(t, tt.input, same.Value(), 0.005)
+		})
+	}
+}
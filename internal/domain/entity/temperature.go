@@ -0,0 +1,167 @@
+package entity
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+)
+
+type Temperature struct {
+	value      float64
+	unit       TemperatureUnit
+	origin     float64
+	originUnit TemperatureUnit
+	format     temperatureFormat
+	valid      bool
+}
+
+type temperatureFormat struct {
+	precision int
+	rounding  RoundingMode
+	set       bool
+}
+
+var (
+	ErrTempNan          = errors.New("temperature: value is NaN")
+	ErrTempInf          = errors.New("temperature: value is infinite")
+	ErrTempBelowAbsZero = errors.New("below absolute zero")
+)
+
+func NewTemperature(value float64, unit TemperatureUnit) (Temperature, error) {
+	if err := unit.validate(); err != nil {
+		return Temperature{}, err
+	}
+
+	if math.IsNaN(value) {
+		return Temperature{}, ErrTempNan
+	}
+
+	if math.IsInf(value, 0) {
+		return Temperature{}, ErrTempInf
+	}
+
+	if value < unit.AbsoluteZero {
+		return Temperature{}, fmt.Errorf("temperature %s: %w (%g %s)", unit.Name, ErrTempBelowAbsZero, unit.AbsoluteZero, unit.Symbol)
+	}
+
+	return Temperature{value: value, unit: unit, origin: value, originUnit: unit, valid: true}, nil
+}
+
+func (t Temperature) IsValid() bool {
+	return t.valid
+}
+
+func (t Temperature) Value() float64 {
+	return t.value
+}
+
+func (t Temperature) Unit() TemperatureUnit {
+	return t.unit
+}
+
+func (t Temperature) In(unit TemperatureUnit) Temperature {
+	if !t.IsValid() || unit.validate() != nil {
+		return Temperature{}
+	}
+
+	converted := convertTemperature(t.origin, t.originUnit, unit)
+
+	return Temperature{value: max(converted, unit.AbsoluteZero), unit: unit, origin: t.origin, originUnit: t.originUnit, format: t.format, valid: true}
+}
+
+func (t Temperature) WithFormat(precision int, rounding RoundingMode) (Temperature, error) {
+	if precision < 0 || precision > MaxTemperaturePrecision {
+		return Temperature{}, fmt.Errorf("%w: %d", ErrTempInvalidPrecision, precision)
+	}
+
+	if !rounding.valid() {
+		return Temperature{}, fmt.Errorf("%w: %q", ErrTempUnknownRounding, rounding)
+	}
+
+	t.format = temperatureFormat{precision: precision, rounding: rounding, set: true}
+	return t, nil
+}
+
+func (t Temperature) Precision() int {
+	if !t.format.set {
+		return DefaultTemperaturePrecision
+	}
+
+	return t.format.precision
+}
+
+func (t Temperature) Rounding() RoundingMode {
+	if !t.format.set {
+		return RoundHalfUp
+	}
+
+	return t.format.rounding
+}
+
+func (t Temperature) Rounded() float64 {
+	if !t.IsValid() {
+		return math.NaN()
+	}
+
+	return roundDecimal(t.value, t.Precision(), t.Rounding())
+}
+
+func (t Temperature) String() string {
+	if !t.IsValid() {
+		return ""
+	}
+
+	return t.Format(DefaultLocale)
+}
+
+func (t Temperature) Format(locale Locale) string {
+	if !t.IsValid() {
+		return ""
+	}
+
+	return locale.withUnit(locale.formatNumber(t.formatNumber()), t.unit)
+}
+
+func (t Temperature) Equal(o Temperature) bool {
+	if !t.IsValid() || !o.IsValid() {
+		return false
+	}
+
+	return t.value == o.In(t.unit).value
+}
+
+func (t Temperature) MarshalJSON() ([]byte, error) {
+	if !t.IsValid() {
+		return []byte("null"), nil
+	}
+
+	return []byte(t.formatNumber()), nil
+}
+
+func (t Temperature) formatNumber() string {
+	return strconv.FormatFloat(t.Rounded(), 'f', t.Precision(), 64)
+}
+
+func (t *Temperature) UnmarshalJSON(b []byte) error {
+	var f float64
+	err := json.Unmarshal(b, &f)
+	if err != nil {
+		return err
+	}
+
+	unit := t.unit
+	if unit.validate() != nil {
+		unit = Celsius
+	}
+
+	v, err := NewTemperature(f, unit)
+	if err != nil {
+		return err
+	}
+
+	v.format = t.format
+	*t = v
+	return nil
+}
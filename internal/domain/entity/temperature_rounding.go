@@ -0,0 +1,83 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+type RoundingMode string
+
+const (
+	RoundHalfUp   RoundingMode = "half_up"
+	RoundHalfEven RoundingMode = "half_even"
+	RoundFloor    RoundingMode = "floor"
+	RoundCeil     RoundingMode = "ceil"
+	RoundTrunc    RoundingMode = "trunc"
+)
+
+const (
+	DefaultTemperaturePrecision = 1
+	MaxTemperaturePrecision     = 3
+)
+
+var (
+	ErrTempInvalidPrecision = fmt.Errorf("temperature: precision must be between 0 and %d", MaxTemperaturePrecision)
+	ErrTempUnknownRounding  = errors.New("temperature: unknown rounding mode")
+)
+
+var roundingModes = []RoundingMode{RoundHalfUp, RoundHalfEven, RoundFloor, RoundCeil, RoundTrunc}
+
+func ParseRoundingMode(s string) (RoundingMode, error) {
+	mode := RoundingMode(strings.ToLower(strings.TrimSpace(s)))
+	if !mode.valid() {
+		return "", fmt.Errorf("%w: %q", ErrTempUnknownRounding, s)
+	}
+
+	return mode, nil
+}
+
+func RoundingModes() []RoundingMode {
+	out := make([]RoundingMode, len(roundingModes))
+	copy(out, roundingModes)
+	return out
+}
+
+func (m RoundingMode) valid() bool {
+	for _, mode := range roundingModes {
+		if m == mode {
+			return true
+		}
+	}
+
+	return false
+}
+
+func roundDecimal(v float64, precision int, mode RoundingMode) float64 {
+	scale := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(precision)), nil)
+	scaled := exactRat(v)
+	scaled.Mul(scaled, new(big.Rat).SetInt(scale))
+
+	den := scaled.Denom()
+	q, r := new(big.Int).QuoRem(scaled.Num(), den, new(big.Int))
+
+	away := false
+	switch mode {
+	case RoundFloor:
+		away = r.Sign() < 0
+	case RoundCeil:
+		away = r.Sign() > 0
+	case RoundHalfUp, RoundHalfEven:
+		twice := new(big.Int).Lsh(new(big.Int).Abs(r), 1)
+		cmp := twice.Cmp(den)
+		away = cmp > 0 || (cmp == 0 && (mode == RoundHalfUp || q.Bit(0) == 1))
+	}
+
+	if away {
+		q.Add(q, big.NewInt(int64(r.Sign())))
+	}
+
+	f, _ := new(big.Rat).SetFrac(q, scale).Float64()
+	return f
+}
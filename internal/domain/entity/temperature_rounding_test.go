@@ -0,0 +1,118 @@
+package entity_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/biraneves/fc-labs-weather/internal/domain/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRoundingMode(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		want      entity.RoundingMode
+		wantError error
+	}{
+		{name: "half up", input: "half_up", want: entity.RoundHalfUp},
+		{name: "half even", input: "HALF_EVEN", want: entity.RoundHalfEven},
+		{name: "floor", input: " floor ", want: entity.RoundFloor},
+		{name: "ceil", input: "ceil", want: entity.RoundCeil},
+		{name: "trunc", input: "trunc", want: entity.RoundTrunc},
+		{name: "unknown", input: "nearest", wantError: entity.ErrTempUnknownRounding},
+		{name: "empty", input: "", wantError: entity.ErrTempUnknownRounding},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := entity.ParseRoundingMode(tt.input)
+
+			if tt.wantError != nil {
+				require.ErrorIs(t, err, tt.wantError)
+				assert.Empty(t, got)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func TestTemperature_WithFormat(t *testing.T) {
+	v, err := entity.NewTemperature(21.456, entity.Celsius)
+	require.NoError(t, err)
+	assert.Equal(t, entity.DefaultTemperaturePrecision, v.Precision())
+	assert.Equal(t, entity.RoundHalfUp, v.Rounding())
+
+	formatted, err := v.WithFormat(2, entity.RoundFloor)
+	require.NoError(t, err)
+	assert.Equal(t, 2, formatted.Precision())
+	assert.Equal(t, entity.RoundFloor, formatted.Rounding())
+	assert.Equal(t, 21.456, formatted.Value())
+	assert.True(t, formatted.Equal(v))
+	assert.Equal(t, "21,45 °C", formatted.String())
+
+	converted := formatted.In(entity.Fahrenheit)
+	assert.Equal(t, 2, converted.Precision())
+	assert.Equal(t, entity.RoundFloor, converted.Rounding())
+
+	_, err = v.WithFormat(-1, entity.RoundHalfUp)
+	require.ErrorIs(t, err, entity.ErrTempInvalidPrecision)
+
+	_, err = v.WithFormat(entity.MaxTemperaturePrecision+1, entity.RoundHalfUp)
+	require.ErrorIs(t, err, entity.ErrTempInvalidPrecision)
+
+	_, err = v.WithFormat(1, entity.RoundingMode("nearest"))
+	require.ErrorIs(t, err, entity.ErrTempUnknownRounding)
+}
+
+func TestTemperature_Rounded(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     float64
+		unit      entity.TemperatureUnit
+		precision int
+		rounding  entity.RoundingMode
+		want      float64
+		wantJSON  string
+	}{
+		{name: "half up tie", input: 2.25, unit: entity.Celsius, precision: 1, rounding: entity.RoundHalfUp, want: 2.3, wantJSON: "2.3"},
+		{name: "half up negative tie", input: -2.25, unit: entity.Celsius, precision: 1, rounding: entity.RoundHalfUp, want: -2.3, wantJSON: "-2.3"},
+		{name: "half up decimal tie", input: 1.005, unit: entity.Celsius, precision: 2, rounding: entity.RoundHalfUp, want: 1.01, wantJSON: "1.01"},
+		{name: "half even tie down", input: 2.25, unit: entity.Celsius, precision: 1, rounding: entity.RoundHalfEven, want: 2.2, wantJSON: "2.2"},
+		{name: "half even tie up", input: 2.35, unit: entity.Celsius, precision: 1, rounding: entity.RoundHalfEven, want: 2.4, wantJSON: "2.4"},
+		{name: "half even not a tie", input: 2.251, unit: entity.Celsius, precision: 1, rounding: entity.RoundHalfEven, want: 2.3, wantJSON: "2.3"},
+		{name: "floor positive", input: 21.99, unit: entity.Celsius, precision: 0, rounding: entity.RoundFloor, want: 21, wantJSON: "21"},
+		{name: "floor negative", input: -0.01, unit: entity.Celsius, precision: 1, rounding: entity.RoundFloor, want: -0.1, wantJSON: "-0.1"},
+		{name: "ceil positive", input: 21.01, unit: entity.Celsius, precision: 0, rounding: entity.RoundCeil, want: 22, wantJSON: "22"},
+		{name: "ceil negative", input: -21.99, unit: entity.Celsius, precision: 0, rounding: entity.RoundCeil, want: -21, wantJSON: "-21"},
+		{name: "trunc negative", input: -3.789, unit: entity.Celsius, precision: 2, rounding: entity.RoundTrunc, want: -3.78, wantJSON: "-3.78"},
+		{name: "three decimals", input: 300.12345, unit: entity.Kelvin, precision: 3, rounding: entity.RoundHalfUp, want: 300.123, wantJSON: "300.123"},
+		{name: "pads decimals", input: 77, unit: entity.Fahrenheit, precision: 3, rounding: entity.RoundHalfUp, want: 77, wantJSON: "77.000"},
+		{name: "no negative zero", input: -0.04, unit: entity.Celsius, precision: 1, rounding: entity.RoundHalfUp, want: 0, wantJSON: "0.0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v, err := entity.NewTemperature(tt.input, tt.unit)
+			require.NoError(t, err)
+
+			v, err = v.WithFormat(tt.precision, tt.rounding)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.want, v.Rounded())
+			assert.False(t, math.Signbit(v.Rounded()) && v.Rounded() == 0)
+
+			got, err := v.MarshalJSON()
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantJSON, string(got))
+		})
+	}
+}
+
+func TestTemperature_Rounded_Invalid(t *testing.T) {
+	var v entity.Temperature
+	assert.True(t, math.IsNaN(v.Rounded()))
+}
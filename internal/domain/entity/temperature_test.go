@@ -0,0 +1,448 @@
+package entity_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/biraneves/fc-labs-weather/internal/domain/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewTemperature(t *testing.T) {
+	tests := []struct {
+		name          string
+		input         float64
+		unit          entity.TemperatureUnit
+		wantError     error
+		expectedError string
+	}{
+		{
+			name:  "valid celsius temperature",
+			input: 25.0,
+			unit:  entity.Celsius,
+		},
+		{
+			name:  "zero degree celsius",
+			input: 0.0,
+			unit:  entity.Celsius,
+		},
+		{
+			name:  "celsius absolute zero",
+			input: -273.15,
+			unit:  entity.Celsius,
+		},
+		{
+			name:          "celsius below absolute zero",
+			input:         -280.0,
+			unit:          entity.Celsius,
+			wantError:     entity.ErrTempBelowAbsZero,
+			expectedError: "temperature celsius: below absolute zero (-273.15 °C)",
+		},
+		{
+			name:  "valid fahrenheit temperature",
+			input: 77.0,
+			unit:  entity.Fahrenheit,
+		},
+		{
+			name:          "fahrenheit below absolute zero",
+			input:         -460.0,
+			unit:          entity.Fahrenheit,
+			wantError:     entity.ErrTempBelowAbsZero,
+			expectedError: "temperature fahrenheit: below absolute zero (-459.67 °F)",
+		},
+		{
+			name:  "kelvin absolute zero",
+			input: 0,
+			unit:  entity.Kelvin,
+		},
+		{
+			name:          "kelvin below absolute zero",
+			input:         -0.1,
+			unit:          entity.Kelvin,
+			wantError:     entity.ErrTempBelowAbsZero,
+			expectedError: "temperature kelvin: below absolute zero (0 K)",
+		},
+		{
+			name:  "valid rankine temperature",
+			input: 536.67,
+			unit:  entity.Rankine,
+		},
+		{
+			name:          "rankine below absolute zero",
+			input:         -1,
+			unit:          entity.Rankine,
+			wantError:     entity.ErrTempBelowAbsZero,
+			expectedError: "temperature rankine: below absolute zero (0 °R)",
+		},
+		{
+			name:  "valid reaumur temperature",
+			input: 20,
+			unit:  entity.Reaumur,
+		},
+		{
+			name:          "reaumur below absolute zero",
+			input:         -218.6,
+			unit:          entity.Reaumur,
+			wantError:     entity.ErrTempBelowAbsZero,
+			expectedError: "temperature reaumur: below absolute zero (-218.52 °Ré)",
+		},
+		{
+			name:          "not a number",
+			input:         math.NaN(),
+			unit:          entity.Celsius,
+			wantError:     entity.ErrTempNan,
+			expectedError: "temperature: value is NaN",
+		},
+		{
+			name:          "positive infinite",
+			input:         math.Inf(1),
+			unit:          entity.Fahrenheit,
+			wantError:     entity.ErrTempInf,
+			expectedError: "temperature: value is infinite",
+		},
+		{
+			name:          "negative infinite",
+			input:         math.Inf(-1),
+			unit:          entity.Kelvin,
+			wantError:     entity.ErrTempInf,
+			expectedError: "temperature: value is infinite",
+		},
+		{
+			name:          "missing unit",
+			input:         25.0,
+			wantError:     entity.ErrTempInvalidUnit,
+			expectedError: "temperature: invalid unit definition",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := entity.NewTemperature(tt.input, tt.unit)
+
+			if tt.expectedError != "" {
+				require.Error(t, err)
+				assert.ErrorIs(t, err, tt.wantError)
+				assert.Contains(t, err.Error(), tt.expectedError)
+				assert.Equal(t, entity.Temperature{}, got)
+				assert.False(t, got.IsValid())
+			} else {
+				require.NoError(t, err)
+				assert.True(t, got.IsValid())
+				assert.Equal(t, tt.input, got.Value())
+				assert.Equal(t, tt.unit, got.Unit())
+			}
+		})
+	}
+}
+
+func TestTemperature_In(t *testing.T) {
+	tests := []struct {
+		name  string
+		input float64
+		from  entity.TemperatureUnit
+		to    entity.TemperatureUnit
+		want  float64
+	}{
+		{name: "celsius to fahrenheit", input: 25.0, from: entity.Celsius, to: entity.Fahrenheit, want: 77.0},
+		{name: "water freezing to fahrenheit", input: 0.0, from: entity.Celsius, to: entity.Fahrenheit, want: 32.0},
+		{name: "water boiling to fahrenheit", input: 100.0, from: entity.Celsius, to: entity.Fahrenheit, want: 212.0},
+		{name: "celsius to kelvin", input: 19.0, from: entity.Celsius, to: entity.Kelvin, want: 292.15},
+		{name: "celsius to rankine", input: 0.0, from: entity.Celsius, to: entity.Rankine, want: 491.67},
+		{name: "celsius to reaumur", input: 100.0, from: entity.Celsius, to: entity.Reaumur, want: 80.0},
+		{name: "fahrenheit to celsius", input: 77.0, from: entity.Fahrenheit, to: entity.Celsius, want: 25.0},
+		{name: "fahrenheit to kelvin", input: 32.0, from: entity.Fahrenheit, to: entity.Kelvin, want: 273.15},
+		{name: "fahrenheit meets celsius", input: -40.0, from: entity.Fahrenheit, to: entity.Celsius, want: -40.0},
+		{name: "kelvin to celsius", input: 373.15, from: entity.Kelvin, to: entity.Celsius, want: 100.0},
+		{name: "kelvin to fahrenheit", input: 233.15, from: entity.Kelvin, to: entity.Fahrenheit, want: -40.0},
+		{name: "rankine to fahrenheit", input: 459.67, from: entity.Rankine, to: entity.Fahrenheit, want: 0.0},
+		{name: "reaumur to celsius", input: 20.0, from: entity.Reaumur, to: entity.Celsius, want: 25.0},
+		{name: "same unit", input: 18.4, from: entity.Celsius, to: entity.Celsius, want: 18.4},
+		{name: "celsius absolute zero to kelvin", input: -273.15, from: entity.Celsius, to: entity.Kelvin, want: 0},
+		{name: "fahrenheit absolute zero to celsius", input: -459.67, from: entity.Fahrenheit, to: entity.Celsius, want: -273.15},
+		{name: "reaumur absolute zero to rankine", input: -218.52, from: entity.Reaumur, to: entity.Rankine, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v, err := entity.NewTemperature(tt.input, tt.from)
+			require.NoError(t, err)
+
+			got := v.In(tt.to)
+
+			require.True(t, got.IsValid())
+			assert.Equal(t, tt.to, got.Unit())
+			assert.Equal(t, tt.want, got.Value())
+		})
+	}
+}
+
+func TestTemperature_In_Invalid(t *testing.T) {
+	v, _ := entity.NewTemperature(-300, entity.Celsius)
+	assert.False(t, v.In(entity.Fahrenheit).IsValid())
+
+	v, err := entity.NewTemperature(25, entity.Celsius)
+	require.NoError(t, err)
+	assert.False(t, v.In(entity.TemperatureUnit{}).IsValid())
+}
+
+func TestTemperature_In_RoundTrip(t *testing.T) {
+	deltas := []float64{0, 0.1, 1.0 / 3.0, 183.95, 233.15, 273.05, 273.15, 291.55, 298.273456789, 309.75, 329.85, 373.15, 1e6}
+
+	for _, from := range entity.TemperatureUnits() {
+		for _, to := range entity.TemperatureUnits() {
+			for _, delta := range deltas {
+				origin := from.AbsoluteZero + delta
+				v, err := entity.NewTemperature(origin, from)
+				require.NoError(t, err)
+
+				back := v.In(to).In(from)
+				assert.Equal(t, origin, back.Value(), "%v %s -> %s -> %s", origin, from, to, from)
+				assert.Equal(t, from, back.Unit())
+			}
+		}
+	}
+}
+
+func TestTemperature_String(t *testing.T) {
+	tests := []struct {
+		name  string
+		input float64
+		unit  entity.TemperatureUnit
+		want  string
+	}{
+		{
+			name:  "valid celsius temperature",
+			input: 25.0,
+			unit:  entity.Celsius,
+			want:  "25,0 °C",
+		},
+		{
+			name:  "zero degree celsius",
+			input: 0.0,
+			unit:  entity.Celsius,
+			want:  "0,0 °C",
+		},
+		{
+			name:  "fahrenheit temperature",
+			input: 77.04,
+			unit:  entity.Fahrenheit,
+			want:  "77,0 °F",
+		},
+		{
+			name:  "kelvin temperature",
+			input: 300,
+			unit:  entity.Kelvin,
+			want:  "300,0 K",
+		},
+		{
+			name:  "reaumur temperature",
+			input: 20,
+			unit:  entity.Reaumur,
+			want:  "20,0 °Ré",
+		},
+		{
+			name:  "invalid temperature",
+			input: -280.0,
+			unit:  entity.Celsius,
+			want:  "",
+		},
+		{
+			name:  "not a number",
+			input: math.NaN(),
+			unit:  entity.Celsius,
+			want:  "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v, _ := entity.NewTemperature(tt.input, tt.unit)
+			require.Equal(t, tt.want, v.String())
+		})
+	}
+}
+
+func TestTemperature_Equal(t *testing.T) {
+	tests := []struct {
+		name  string
+		a     float64
+		unitA entity.TemperatureUnit
+		b     float64
+		unitB entity.TemperatureUnit
+		want  bool
+	}{
+		{
+			name:  "equal valid temperatures",
+			a:     25.0,
+			unitA: entity.Celsius,
+			b:     25,
+			unitB: entity.Celsius,
+			want:  true,
+		},
+		{
+			name:  "different valid temperatures",
+			a:     18.4,
+			unitA: entity.Celsius,
+			b:     -24.3,
+			unitB: entity.Celsius,
+			want:  false,
+		},
+		{
+			name:  "same temperature in different units",
+			a:     25.0,
+			unitA: entity.Celsius,
+			b:     77.0,
+			unitB: entity.Fahrenheit,
+			want:  true,
+		},
+		{
+			name:  "same number in different units",
+			a:     25.0,
+			unitA: entity.Celsius,
+			b:     25.0,
+			unitB: entity.Kelvin,
+			want:  false,
+		},
+		{
+			name:  "first temperature invalid",
+			a:     -280,
+			unitA: entity.Celsius,
+			b:     340.5,
+			unitB: entity.Celsius,
+			want:  false,
+		},
+		{
+			name:  "second temperature invalid",
+			a:     98.3,
+			unitA: entity.Fahrenheit,
+			b:     math.NaN(),
+			unitB: entity.Fahrenheit,
+			want:  false,
+		},
+		{
+			name:  "two invalid temperatures",
+			a:     -340.0,
+			unitA: entity.Celsius,
+			b:     math.Inf(1),
+			unitB: entity.Kelvin,
+			want:  false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			va, _ := entity.NewTemperature(tt.a, tt.unitA)
+			vb, _ := entity.NewTemperature(tt.b, tt.unitB)
+
+			require.Equal(t, tt.want, va.Equal(vb))
+			require.Equal(t, tt.want, vb.Equal(va))
+		})
+	}
+}
+
+func TestTemperature_MarshalJSON(t *testing.T) {
+	tests := []struct {
+		name  string
+		input float64
+		unit  entity.TemperatureUnit
+		want  []byte
+	}{
+		{
+			name:  "valid celsius temperature",
+			input: 28.3,
+			unit:  entity.Celsius,
+			want:  []byte("28.3"),
+		},
+		{
+			name:  "rounds to one decimal",
+			input: 83.12,
+			unit:  entity.Fahrenheit,
+			want:  []byte("83.1"),
+		},
+		{
+			name:  "whole kelvin temperature",
+			input: 300,
+			unit:  entity.Kelvin,
+			want:  []byte("300.0"),
+		},
+		{
+			name:  "invalid temperature",
+			input: -540.8,
+			unit:  entity.Celsius,
+			want:  []byte("null"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v, _ := entity.NewTemperature(tt.input, tt.unit)
+			got, err := v.MarshalJSON()
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestTemperature_UnmarshalJSON(t *testing.T) {
+	kelvin, err := entity.NewTemperature(0, entity.Kelvin)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name          string
+		into          entity.Temperature
+		input         []byte
+		want          float64
+		wantUnit      entity.TemperatureUnit
+		expectedError string
+	}{
+		{
+			name:     "defaults to celsius",
+			input:    []byte(`18.5`),
+			want:     18.5,
+			wantUnit: entity.Celsius,
+		},
+		{
+			name:     "keeps the receiver unit",
+			into:     kelvin,
+			input:    []byte(`291.65`),
+			want:     291.65,
+			wantUnit: entity.Kelvin,
+		},
+		{
+			name:          "temperature as string",
+			input:         []byte(`"23.4"`),
+			expectedError: "json: cannot unmarshal string",
+		},
+		{
+			name:          "invalid temperature",
+			input:         []byte(`-280`),
+			expectedError: "temperature celsius: below absolute zero",
+		},
+		{
+			name:          "invalid temperature in receiver unit",
+			into:          kelvin,
+			input:         []byte(`-1`),
+			expectedError: "temperature kelvin: below absolute zero",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := tt.into
+			err := v.UnmarshalJSON(tt.input)
+
+			if tt.expectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError)
+				assert.Equal(t, tt.into, v)
+			} else {
+				require.NoError(t, err)
+				assert.True(t, v.IsValid())
+				assert.Equal(t, tt.want, v.Value())
+				assert.Equal(t, tt.wantUnit, v.Unit())
+			}
+		})
+	}
+}
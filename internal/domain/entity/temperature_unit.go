@@ -0,0 +1,145 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+type TemperatureUnit struct {
+	Code             string
+	Name             string
+	Symbol           string
+	AbsoluteZero     float64
+	DegreesPerKelvin float64
+}
+
+var (
+	ErrTempUnknownUnit   = errors.New("temperature: unknown unit")
+	ErrTempInvalidUnit   = errors.New("temperature: invalid unit definition")
+	ErrTempDuplicateUnit = errors.New("temperature: unit already registered")
+)
+
+var (
+	Celsius    = TemperatureUnit{Code: "C", Name: "celsius", Symbol: "°C", AbsoluteZero: -273.15, DegreesPerKelvin: 1}
+	Fahrenheit = TemperatureUnit{Code: "F", Name: "fahrenheit", Symbol: "°F", AbsoluteZero: -459.67, DegreesPerKelvin: 1.8}
+	Kelvin     = TemperatureUnit{Code: "K", Name: "kelvin", Symbol: "K", AbsoluteZero: 0, DegreesPerKelvin: 1}
+	Rankine    = TemperatureUnit{Code: "R", Name: "rankine", Symbol: "°R", AbsoluteZero: 0, DegreesPerKelvin: 1.8}
+	Reaumur    = TemperatureUnit{Code: "Re", Name: "reaumur", Symbol: "°Ré", AbsoluteZero: -218.52, DegreesPerKelvin: 0.8}
+)
+
+var temperatureUnits = newTemperatureUnitRegistry(Celsius, Fahrenheit, Kelvin, Rankine, Reaumur)
+
+type temperatureUnitRegistry struct {
+	mu    sync.RWMutex
+	byKey map[string]TemperatureUnit
+	units []TemperatureUnit
+}
+
+func newTemperatureUnitRegistry(units ...TemperatureUnit) *temperatureUnitRegistry {
+	r := &temperatureUnitRegistry{byKey: make(map[string]TemperatureUnit)}
+	for _, unit := range units {
+		if err := r.register(unit); err != nil {
+			panic(err)
+		}
+	}
+
+	return r
+}
+
+func (r *temperatureUnitRegistry) register(unit TemperatureUnit) error {
+	if err := unit.validate(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	keys := []string{strings.ToLower(unit.Code), strings.ToLower(unit.Name)}
+	for _, key := range keys {
+		if _, ok := r.byKey[key]; ok {
+			return fmt.Errorf("%w: %q", ErrTempDuplicateUnit, key)
+		}
+	}
+
+	for _, key := range keys {
+		r.byKey[key] = unit
+	}
+	r.units = append(r.units, unit)
+
+	return nil
+}
+
+func (r *temperatureUnitRegistry) lookup(key string) (TemperatureUnit, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	unit, ok := r.byKey[strings.ToLower(strings.TrimSpace(key))]
+	return unit, ok
+}
+
+func (r *temperatureUnitRegistry) all() []TemperatureUnit {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	out := make([]TemperatureUnit, len(r.units))
+	copy(out, r.units)
+	return out
+}
+
+func RegisterTemperatureUnit(unit TemperatureUnit) error {
+	return temperatureUnits.register(unit)
+}
+
+func ParseTemperatureUnit(s string) (TemperatureUnit, error) {
+	unit, ok := temperatureUnits.lookup(s)
+	if !ok {
+		return TemperatureUnit{}, fmt.Errorf("%w: %q", ErrTempUnknownUnit, s)
+	}
+
+	return unit, nil
+}
+
+func TemperatureUnits() []TemperatureUnit {
+	return temperatureUnits.all()
+}
+
+func (u TemperatureUnit) String() string {
+	return u.Symbol
+}
+
+func (u TemperatureUnit) validate() error {
+	switch {
+	case strings.TrimSpace(u.Code) == "" || strings.TrimSpace(u.Name) == "":
+		return fmt.Errorf("%w: code and name are required", ErrTempInvalidUnit)
+	case math.IsNaN(u.AbsoluteZero) || math.IsInf(u.AbsoluteZero, 0):
+		return fmt.Errorf("%w: absolute zero must be finite", ErrTempInvalidUnit)
+	case math.IsNaN(u.DegreesPerKelvin) || math.IsInf(u.DegreesPerKelvin, 0) || u.DegreesPerKelvin <= 0:
+		return fmt.Errorf("%w: degrees per kelvin must be positive", ErrTempInvalidUnit)
+	}
+
+	return nil
+}
+
+func convertTemperature(value float64, from, to TemperatureUnit) float64 {
+	if from == to {
+		return value
+	}
+
+	aboveZero := new(big.Rat).Sub(exactRat(value), exactRat(from.AbsoluteZero))
+	kelvin := aboveZero.Quo(aboveZero, exactRat(from.DegreesPerKelvin))
+	target := kelvin.Mul(kelvin, exactRat(to.DegreesPerKelvin))
+	target.Add(target, exactRat(to.AbsoluteZero))
+
+	f, _ := target.Float64()
+	return f
+}
+
+func exactRat(f float64) *big.Rat {
+	r, _ := new(big.Rat).SetString(strconv.FormatFloat(f, 'g', -1, 64))
+	return r
+}
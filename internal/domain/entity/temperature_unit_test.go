@@ -0,0 +1,113 @@
+package entity_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/biraneves/fc-labs-weather/internal/domain/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTemperatureUnit(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		want      entity.TemperatureUnit
+		wantError error
+	}{
+		{name: "celsius code", input: "C", want: entity.Celsius},
+		{name: "lowercase code", input: "f", want: entity.Fahrenheit},
+		{name: "kelvin name", input: "kelvin", want: entity.Kelvin},
+		{name: "mixed case name", input: " Rankine ", want: entity.Rankine},
+		{name: "reaumur code", input: "re", want: entity.Reaumur},
+		{name: "unknown unit", input: "delisle", wantError: entity.ErrTempUnknownUnit},
+		{name: "empty", input: "", wantError: entity.ErrTempUnknownUnit},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := entity.ParseTemperatureUnit(tt.input)
+
+			if tt.wantError != nil {
+				require.ErrorIs(t, err, tt.wantError)
+				assert.Equal(t, entity.TemperatureUnit{}, got)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func TestTemperatureUnits(t *testing.T) {
+	units := entity.TemperatureUnits()
+	require.GreaterOrEqual(t, len(units), 5)
+	assert.Equal(t, []entity.TemperatureUnit{entity.Celsius, entity.Fahrenheit, entity.Kelvin, entity.Rankine, entity.Reaumur}, units[:5])
+
+	units[0] = entity.TemperatureUnit{}
+	assert.Equal(t, entity.Celsius, entity.TemperatureUnits()[0])
+}
+
+func TestRegisterTemperatureUnit(t *testing.T) {
+	t.Cleanup(entity.IsolateTemperatureUnits())
+
+	newton := entity.TemperatureUnit{Code: "N", Name: "newton", Symbol: "°N", AbsoluteZero: -90.1395, DegreesPerKelvin: 0.33}
+	require.NoError(t, entity.RegisterTemperatureUnit(newton))
+
+	got, err := entity.ParseTemperatureUnit("newton")
+	require.NoError(t, err)
+	assert.Equal(t, newton, got)
+	assert.Contains(t, entity.TemperatureUnits(), newton)
+
+	boiling, err := entity.NewTemperature(100, entity.Celsius)
+	require.NoError(t, err)
+	assert.Equal(t, 33.0, boiling.In(newton).Value())
+
+	_, err = entity.NewTemperature(-91, newton)
+	require.ErrorIs(t, err, entity.ErrTempBelowAbsZero)
+
+	tests := []struct {
+		name      string
+		unit      entity.TemperatureUnit
+		wantError error
+	}{
+		{
+			name:      "duplicate code",
+			unit:      entity.TemperatureUnit{Code: "c", Name: "centigrade", Symbol: "°C", AbsoluteZero: -273.15, DegreesPerKelvin: 1},
+			wantError: entity.ErrTempDuplicateUnit,
+		},
+		{
+			name:      "duplicate name",
+			unit:      entity.TemperatureUnit{Code: "K2", Name: "Kelvin", Symbol: "K", DegreesPerKelvin: 1},
+			wantError: entity.ErrTempDuplicateUnit,
+		},
+		{
+			name:      "missing code",
+			unit:      entity.TemperatureUnit{Name: "delisle", Symbol: "°De", AbsoluteZero: 559.725, DegreesPerKelvin: 1.5},
+			wantError: entity.ErrTempInvalidUnit,
+		},
+		{
+			name:      "non positive degree size",
+			unit:      entity.TemperatureUnit{Code: "X", Name: "broken", Symbol: "°X", DegreesPerKelvin: 0},
+			wantError: entity.ErrTempInvalidUnit,
+		},
+		{
+			name:      "infinite absolute zero",
+			unit:      entity.TemperatureUnit{Code: "Y", Name: "unbounded", Symbol: "°Y", AbsoluteZero: math.Inf(-1), DegreesPerKelvin: 1},
+			wantError: entity.ErrTempInvalidUnit,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := entity.RegisterTemperatureUnit(tt.unit)
+			require.ErrorIs(t, err, tt.wantError)
+
+			_, err = entity.ParseTemperatureUnit(tt.unit.Code)
+			if tt.wantError == entity.ErrTempInvalidUnit {
+				assert.ErrorIs(t, err, entity.ErrTempUnknownUnit)
+			}
+		})
+	}
+}
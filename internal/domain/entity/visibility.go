@@ -0,0 +1,120 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+type DistanceUnit struct {
+	Code     string
+	Symbol   string
+	km       float64
+	decimals int
+}
+
+type Visibility struct {
+	value float64
+	unit  DistanceUnit
+	valid bool
+}
+
+var (
+	Kilometers = DistanceUnit{Code: "km", Symbol: "km", km: 1, decimals: 1}
+	Miles      = DistanceUnit{Code: "mi", Symbol: "mi", km: 1.609344, decimals: 1}
+)
+
+var (
+	ErrVisibilityNegative    = errors.New("visibility: must not be negative")
+	ErrVisibilityUnknownUnit = errors.New("visibility: unknown unit")
+)
+
+var distanceUnits = []DistanceUnit{Kilometers, Miles}
+
+func ParseDistanceUnit(code string) (DistanceUnit, error) {
+	normalized := strings.ToLower(strings.TrimSpace(code))
+	for _, unit := range distanceUnits {
+		if unit.Code == normalized {
+			return unit, nil
+		}
+	}
+
+	return DistanceUnit{}, fmt.Errorf("%w: %q", ErrVisibilityUnknownUnit, code)
+}
+
+func (u DistanceUnit) String() string {
+	return u.Symbol
+}
+
+func NewVisibility(value float64, unit DistanceUnit) (Visibility, error) {
+	if !slices.Contains(distanceUnits, unit) {
+		return Visibility{}, fmt.Errorf("%w: %q", ErrVisibilityUnknownUnit, unit.Code)
+	}
+
+	if err := checkFinite("visibility", value); err != nil {
+		return Visibility{}, err
+	}
+
+	if value < 0 {
+		return Visibility{}, ErrVisibilityNegative
+	}
+
+	return Visibility{value, unit, true}, nil
+}
+
+func (v Visibility) IsValid() bool {
+	return v.valid
+}
+
+func (v Visibility) Value() float64 {
+	return v.value
+}
+
+func (v Visibility) Unit() DistanceUnit {
+	return v.unit
+}
+
+func (v Visibility) In(unit DistanceUnit) Visibility {
+	if !v.IsValid() || !slices.Contains(distanceUnits, unit) {
+		return Visibility{}
+	}
+
+	return Visibility{convertLinear(v.value, v.unit.km, unit.km), unit, true}
+}
+
+func (v Visibility) Kilometers() float64 {
+	return v.In(Kilometers).value
+}
+
+func (v Visibility) Miles() float64 {
+	return v.In(Miles).value
+}
+
+func (v Visibility) MarshalJSON() ([]byte, error) {
+	return marshalDecimal(v.Kilometers(), Kilometers.decimals, v.valid), nil
+}
+
+func (v *Visibility) UnmarshalJSON(b []byte) error {
+	if isNull(b) {
+		*v = Visibility{}
+		return nil
+	}
+
+	f, err := unmarshalNumber(b)
+	if err != nil {
+		return err
+	}
+
+	parsed, err := NewVisibility(f, Kilometers)
+	if err != nil {
+		return err
+	}
+
+	if slices.Contains(distanceUnits, v.unit) {
+		parsed = parsed.In(v.unit)
+	}
+
+	*v = parsed
+	return nil
+}
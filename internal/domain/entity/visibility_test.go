@@ -0,0 +1,115 @@
+package entity_test
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/biraneves/fc-labs-weather/internal/domain/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewVisibility(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     float64
+		unit      entity.DistanceUnit
+		wantError error
+		wantJSON  string
+	}{
+		{name: "clear day", input: 10, unit: entity.Kilometers, wantJSON: "10.0"},
+		{name: "fog", input: 0.04, unit: entity.Kilometers, wantJSON: "0.0"},
+		{name: "miles", input: 6.2, unit: entity.Miles, wantJSON: "10.0"},
+		{name: "negative", input: -1, unit: entity.Kilometers, wantError: entity.ErrVisibilityNegative},
+		{name: "unknown unit", input: 10, wantError: entity.ErrVisibilityUnknownUnit},
+		{name: "not a number", input: math.NaN(), unit: entity.Miles, wantError: entity.ErrValueNan},
+		{name: "infinite", input: math.Inf(1), unit: entity.Kilometers, wantError: entity.ErrValueInf},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := entity.NewVisibility(tt.input, tt.unit)
+
+			if tt.wantError != nil {
+				require.ErrorIs(t, err, tt.wantError)
+				assert.Contains(t, err.Error(), "visibility:")
+				assert.False(t, got.IsValid())
+				return
+			}
+
+			require.NoError(t, err)
+			assert.True(t, got.IsValid())
+			assert.Equal(t, tt.input, got.Value())
+			assert.Equal(t, tt.unit, got.Unit())
+
+			body, err := got.MarshalJSON()
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantJSON, string(body))
+		})
+	}
+}
+
+func TestVisibility_In(t *testing.T) {
+	v, err := entity.NewVisibility(10, entity.Kilometers)
+	require.NoError(t, err)
+
+	assert.Equal(t, 10.0, v.Kilometers())
+	assert.InDelta(t, 6.2137, v.Miles(), 1e-4)
+
+	miles := v.In(entity.Miles)
+	assert.Equal(t, entity.Miles, miles.Unit())
+	assert.Equal(t, 10.0, miles.Kilometers())
+
+	oneMile, err := entity.NewVisibility(1, entity.Miles)
+	require.NoError(t, err)
+	assert.Equal(t, 1.609344, oneMile.Kilometers())
+
+	assert.False(t, entity.Visibility{}.In(entity.Miles).IsValid())
+}
+
+func TestParseDistanceUnit(t *testing.T) {
+	got, err := entity.ParseDistanceUnit("MI")
+	require.NoError(t, err)
+	assert.Equal(t, entity.Miles, got)
+
+	_, err = entity.ParseDistanceUnit("nmi")
+	require.ErrorIs(t, err, entity.ErrVisibilityUnknownUnit)
+}
+
+func TestVisibility_UnmarshalJSON(t *testing.T) {
+	var v entity.Visibility
+	require.NoError(t, v.UnmarshalJSON([]byte(`8.5`)))
+	assert.Equal(t, entity.Kilometers, v.Unit())
+	assert.Equal(t, 8.5, v.Value())
+
+	var invalid entity.Visibility
+	require.ErrorIs(t, invalid.UnmarshalJSON([]byte(`-2`)), entity.ErrVisibilityNegative)
+	assert.False(t, invalid.IsValid())
+
+	miles := v.In(entity.Miles)
+	require.NoError(t, miles.UnmarshalJSON([]byte(`1.609344`)))
+	assert.Equal(t, entity.Miles, miles.Unit())
+	assert.InDelta(t, 1.0, miles.Value(), 1e-9)
+
+	require.NoError(t, v.UnmarshalJSON([]byte(`null`)))
+	assert.False(t, v.IsValid())
+}
+
+func TestVisibility_JSONRoundTrip(t *testing.T) {
+	original, err := entity.NewVisibility(6.2, entity.Miles)
+	require.NoError(t, err)
+
+	body, err := json.Marshal(original)
+	require.NoError(t, err)
+
+	var decoded entity.Visibility
+	require.NoError(t, json.Unmarshal(body, &decoded))
+	assert.Equal(t, entity.Kilometers, decoded.Unit())
+	assert.InDelta(t, original.Kilometers(), decoded.Kilometers(), 0.05)
+
+	miles := decoded.In(entity.Miles)
+	require.NoError(t, json.Unmarshal(body, &miles))
+	assert.Equal(t, entity.Miles, miles.Unit())
+	assert.InDelta(t, 6.2, miles.Value(), 0.05)
+}
@@ -0,0 +1,206 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+)
+
+type SpeedUnit struct {
+	Code     string
+	Symbol   string
+	kph      float64
+	decimals int
+}
+
+type WindSpeed struct {
+	value float64
+	unit  SpeedUnit
+	valid bool
+}
+
+type WindDirection struct {
+	degrees float64
+	valid   bool
+}
+
+const maxWindSpeedKph = 500
+
+var (
+	KilometersPerHour = SpeedUnit{Code: "kph", Symbol: "km/h", kph: 1, decimals: 1}
+	MilesPerHour      = SpeedUnit{Code: "mph", Symbol: "mph", kph: 1.609344, decimals: 1}
+	MetersPerSecond   = SpeedUnit{Code: "ms", Symbol: "m/s", kph: 3.6, decimals: 1}
+	Knots             = SpeedUnit{Code: "kn", Symbol: "kn", kph: 1.852, decimals: 1}
+)
+
+var (
+	ErrWindSpeedOutOfRange     = fmt.Errorf("wind speed: must be between 0 and %d km/h", maxWindSpeedKph)
+	ErrWindSpeedUnknownUnit    = errors.New("wind speed: unknown unit")
+	ErrWindDirectionOutOfRange = errors.New("wind direction: must be between 0 and 360 degrees")
+	ErrWindDirectionCompass    = errors.New("wind direction: unknown compass point")
+)
+
+var speedUnits = []SpeedUnit{KilometersPerHour, MilesPerHour, MetersPerSecond, Knots}
+
+var compassPoints = []string{
+	"N", "NNE", "NE", "ENE", "E", "ESE", "SE", "SSE",
+	"S", "SSW", "SW", "WSW", "W", "WNW", "NW", "NNW",
+}
+
+func ParseSpeedUnit(code string) (SpeedUnit, error) {
+	normalized := strings.ToLower(strings.TrimSpace(code))
+	for _, unit := range speedUnits {
+		if unit.Code == normalized || strings.ToLower(unit.Symbol) == normalized {
+			return unit, nil
+		}
+	}
+
+	return SpeedUnit{}, fmt.Errorf("%w: %q", ErrWindSpeedUnknownUnit, code)
+}
+
+func (u SpeedUnit) String() string {
+	return u.Symbol
+}
+
+func NewWindSpeed(value float64, unit SpeedUnit) (WindSpeed, error) {
+	if !slices.Contains(speedUnits, unit) {
+		return WindSpeed{}, fmt.Errorf("%w: %q", ErrWindSpeedUnknownUnit, unit.Code)
+	}
+
+	if err := checkFinite("wind speed", value); err != nil {
+		return WindSpeed{}, err
+	}
+
+	if kph := convertLinear(value, unit.kph, KilometersPerHour.kph); kph < 0 || kph > maxWindSpeedKph {
+		return WindSpeed{}, ErrWindSpeedOutOfRange
+	}
+
+	return WindSpeed{value, unit, true}, nil
+}
+
+func (s WindSpeed) IsValid() bool {
+	return s.valid
+}
+
+func (s WindSpeed) Value() float64 {
+	return s.value
+}
+
+func (s WindSpeed) Unit() SpeedUnit {
+	return s.unit
+}
+
+func (s WindSpeed) In(unit SpeedUnit) WindSpeed {
+	if !s.IsValid() || !slices.Contains(speedUnits, unit) {
+		return WindSpeed{}
+	}
+
+	return WindSpeed{convertLinear(s.value, s.unit.kph, unit.kph), unit, true}
+}
+
+func (s WindSpeed) Kph() float64 {
+	return s.In(KilometersPerHour).value
+}
+
+func (s WindSpeed) Mph() float64 {
+	return s.In(MilesPerHour).value
+}
+
+func (s WindSpeed) MetersPerSecond() float64 {
+	return s.In(MetersPerSecond).value
+}
+
+func (s WindSpeed) Knots() float64 {
+	return s.In(Knots).value
+}
+
+func (s WindSpeed) MarshalJSON() ([]byte, error) {
+	return marshalDecimal(s.Kph(), KilometersPerHour.decimals, s.valid), nil
+}
+
+func (s *WindSpeed) UnmarshalJSON(b []byte) error {
+	if isNull(b) {
+		*s = WindSpeed{}
+		return nil
+	}
+
+	f, err := unmarshalNumber(b)
+	if err != nil {
+		return err
+	}
+
+	v, err := NewWindSpeed(f, KilometersPerHour)
+	if err != nil {
+		return err
+	}
+
+	if slices.Contains(speedUnits, s.unit) {
+		v = v.In(s.unit)
+	}
+
+	*s = v
+	return nil
+}
+
+func NewWindDirection(degrees float64) (WindDirection, error) {
+	if err := checkFinite("wind direction", degrees); err != nil {
+		return WindDirection{}, err
+	}
+
+	if degrees < 0 || degrees > 360 {
+		return WindDirection{}, ErrWindDirectionOutOfRange
+	}
+
+	return WindDirection{math.Mod(degrees, 360), true}, nil
+}
+
+func NewWindDirectionFromCompass(point string) (WindDirection, error) {
+	i := slices.Index(compassPoints, strings.ToUpper(strings.TrimSpace(point)))
+	if i < 0 {
+		return WindDirection{}, fmt.Errorf("%w: %q", ErrWindDirectionCompass, point)
+	}
+
+	return WindDirection{float64(i) * 22.5, true}, nil
+}
+
+func (d WindDirection) IsValid() bool {
+	return d.valid
+}
+
+func (d WindDirection) Degrees() float64 {
+	return d.degrees
+}
+
+func (d WindDirection) Compass() string {
+	if !d.IsValid() {
+		return ""
+	}
+
+	return compassPoints[int(math.Round(d.degrees/22.5))%len(compassPoints)]
+}
+
+func (d WindDirection) MarshalJSON() ([]byte, error) {
+	return marshalDecimal(d.degrees, 1, d.valid), nil
+}
+
+func (d *WindDirection) UnmarshalJSON(b []byte) error {
+	if isNull(b) {
+		*d = WindDirection{}
+		return nil
+	}
+
+	f, err := unmarshalNumber(b)
+	if err != nil {
+		return err
+	}
+
+	v, err := NewWindDirection(f)
+	if err != nil {
+		return err
+	}
+
+	*d = v
+	return nil
+}
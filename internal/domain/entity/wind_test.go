@@ -0,0 +1,259 @@
+package entity_test
+
+import (
+	"encoding/json"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/biraneves/fc-labs-weather/internal/domain/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewWindSpeed(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     float64
+		unit      entity.SpeedUnit
+		wantError error
+		wantJSON  string
+	}{
+		{name: "breeze", input: 11.16, unit: entity.KilometersPerHour, wantJSON: "11.2"},
+		{name: "calm", input: 0, unit: entity.KilometersPerHour, wantJSON: "0.0"},
+		{name: "miles per hour", input: 15, unit: entity.MilesPerHour, wantJSON: "24.1"},
+		{name: "meters per second", input: 3.14, unit: entity.MetersPerSecond, wantJSON: "11.3"},
+		{name: "knots", input: 12.5, unit: entity.Knots, wantJSON: "23.2"},
+		{name: "upper bound", input: 500, unit: entity.KilometersPerHour, wantJSON: "500.0"},
+		{name: "negative speed", input: -0.5, unit: entity.KilometersPerHour, wantError: entity.ErrWindSpeedOutOfRange},
+		{name: "above upper bound", input: 501, unit: entity.KilometersPerHour, wantError: entity.ErrWindSpeedOutOfRange},
+		{name: "above upper bound in meters per second", input: 140, unit: entity.MetersPerSecond, wantError: entity.ErrWindSpeedOutOfRange},
+		{name: "unknown unit", input: 10, wantError: entity.ErrWindSpeedUnknownUnit},
+		{name: "not a number", input: math.NaN(), unit: entity.Knots, wantError: entity.ErrValueNan},
+		{name: "infinite", input: math.Inf(1), unit: entity.MilesPerHour, wantError: entity.ErrValueInf},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := entity.NewWindSpeed(tt.input, tt.unit)
+
+			if tt.wantError != nil {
+				require.ErrorIs(t, err, tt.wantError)
+				assert.Contains(t, err.Error(), "wind speed:")
+				assert.False(t, got.IsValid())
+				return
+			}
+
+			require.NoError(t, err)
+			assert.True(t, got.IsValid())
+			assert.Equal(t, tt.input, got.Value())
+			assert.Equal(t, tt.unit, got.Unit())
+
+			body, err := got.MarshalJSON()
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantJSON, string(body))
+		})
+	}
+}
+
+func TestWindSpeed_In(t *testing.T) {
+	tests := []struct {
+		name  string
+		input float64
+		from  entity.SpeedUnit
+		to    entity.SpeedUnit
+		want  float64
+	}{
+		{name: "kph to meters per second", input: 36, from: entity.KilometersPerHour, to: entity.MetersPerSecond, want: 10},
+		{name: "meters per second to kph", input: 10, from: entity.MetersPerSecond, to: entity.KilometersPerHour, want: 36},
+		{name: "mph to kph", input: 10, from: entity.MilesPerHour, to: entity.KilometersPerHour, want: 16.09344},
+		{name: "knots to kph", input: 10, from: entity.Knots, to: entity.KilometersPerHour, want: 18.52},
+		{name: "knots to meters per second", input: 3.6, from: entity.Knots, to: entity.MetersPerSecond, want: 1.852},
+		{name: "same unit", input: 11.2, from: entity.KilometersPerHour, to: entity.KilometersPerHour, want: 11.2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v, err := entity.NewWindSpeed(tt.input, tt.from)
+			require.NoError(t, err)
+
+			got := v.In(tt.to)
+			require.True(t, got.IsValid())
+			assert.Equal(t, tt.to, got.Unit())
+			assert.Equal(t, tt.want, got.Value())
+		})
+	}
+
+	v, err := entity.NewWindSpeed(18.52, entity.KilometersPerHour)
+	require.NoError(t, err)
+	assert.Equal(t, 18.52, v.Kph())
+	assert.Equal(t, 10.0, v.Knots())
+	assert.InDelta(t, 5.1444, v.MetersPerSecond(), 1e-4)
+	assert.InDelta(t, 11.5078, v.Mph(), 1e-4)
+
+	assert.False(t, entity.WindSpeed{}.In(entity.Knots).IsValid())
+	assert.False(t, v.In(entity.SpeedUnit{}).IsValid())
+}
+
+func TestParseSpeedUnit(t *testing.T) {
+	tests := []struct {
+		input     string
+		want      entity.SpeedUnit
+		wantError error
+	}{
+		{input: "kph", want: entity.KilometersPerHour},
+		{input: "km/h", want: entity.KilometersPerHour},
+		{input: "MPH", want: entity.MilesPerHour},
+		{input: "m/s", want: entity.MetersPerSecond},
+		{input: " kn ", want: entity.Knots},
+		{input: "beaufort", wantError: entity.ErrWindSpeedUnknownUnit},
+	}
+
+	for _, tt := range tests {
+		got, err := entity.ParseSpeedUnit(tt.input)
+		if tt.wantError != nil {
+			assert.ErrorIs(t, err, tt.wantError, tt.input)
+			continue
+		}
+
+		require.NoError(t, err, tt.input)
+		assert.Equal(t, tt.want, got, tt.input)
+	}
+}
+
+func TestWindSpeed_UnmarshalJSON(t *testing.T) {
+	var s entity.WindSpeed
+	require.NoError(t, s.UnmarshalJSON([]byte(`12.5`)))
+	assert.Equal(t, entity.KilometersPerHour, s.Unit())
+	assert.Equal(t, 12.5, s.Value())
+
+	knots := s.In(entity.Knots)
+	require.NoError(t, knots.UnmarshalJSON([]byte(`18.52`)))
+	assert.Equal(t, entity.Knots, knots.Unit())
+	assert.InDelta(t, 10.0, knots.Value(), 1e-9)
+
+	var invalid entity.WindSpeed
+	require.ErrorIs(t, invalid.UnmarshalJSON([]byte(`-3`)), entity.ErrWindSpeedOutOfRange)
+	assert.False(t, invalid.IsValid())
+
+	require.NoError(t, s.UnmarshalJSON([]byte(`null`)))
+	assert.False(t, s.IsValid())
+}
+
+func TestWindSpeed_JSONRoundTrip(t *testing.T) {
+	tests := []struct {
+		name  string
+		input float64
+		unit  entity.SpeedUnit
+	}{
+		{name: "miles per hour", input: 15, unit: entity.MilesPerHour},
+		{name: "meters per second", input: 3.5, unit: entity.MetersPerSecond},
+		{name: "knots", input: 12, unit: entity.Knots},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			original, err := entity.NewWindSpeed(tt.input, tt.unit)
+			require.NoError(t, err)
+
+			body, err := json.Marshal(original)
+			require.NoError(t, err)
+
+			var decoded entity.WindSpeed
+			require.NoError(t, json.Unmarshal(body, &decoded))
+			assert.Equal(t, entity.KilometersPerHour, decoded.Unit())
+			assert.InDelta(t, original.Kph(), decoded.Kph(), 0.05)
+
+			same := decoded.In(tt.unit)
+			require.NoError(t, json.Unmarshal(body, &same))
+			assert.Equal(t, tt.unit, same.Unit())
+			assert.InDelta(t, tt.input, same.Value(), 0.05)
+		})
+	}
+}
+
+func TestNewWindDirection(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       float64
+		want        float64
+		wantCompass string
+		wantError   error
+	}{
+		{name: "north", input: 0, want: 0, wantCompass: "N"},
+		{name: "north north east", input: 12, want: 12, wantCompass: "NNE"},
+		{name: "east", input: 90, want: 90, wantCompass: "E"},
+		{name: "south west", input: 225, want: 225, wantCompass: "SW"},
+		{name: "almost north", input: 350, want: 350, wantCompass: "N"},
+		{name: "full turn", input: 360, want: 0, wantCompass: "N"},
+		{name: "negative bearing", input: -90, wantError: entity.ErrWindDirectionOutOfRange},
+		{name: "beyond a full turn", input: 450, wantError: entity.ErrWindDirectionOutOfRange},
+		{name: "not a number", input: math.NaN(), wantError: entity.ErrValueNan},
+		{name: "infinite", input: math.Inf(-1), wantError: entity.ErrValueInf},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := entity.NewWindDirection(tt.input)
+
+			if tt.wantError != nil {
+				require.ErrorIs(t, err, tt.wantError)
+				assert.Contains(t, err.Error(), "wind direction:")
+				assert.False(t, got.IsValid())
+				assert.Empty(t, got.Compass())
+				return
+			}
+
+			require.NoError(t, err)
+			assert.True(t, got.IsValid())
+			assert.Equal(t, tt.want, got.Degrees())
+			assert.Equal(t, tt.wantCompass, got.Compass())
+		})
+	}
+}
+
+func TestNewWindDirectionFromCompass(t *testing.T) {
+	tests := []struct {
+		input     string
+		want      float64
+		wantError error
+	}{
+		{input: "N", want: 0},
+		{input: "ene", want: 67.5},
+		{input: " SW ", want: 225},
+		{input: "NNW", want: 337.5},
+		{input: "NORTH", wantError: entity.ErrWindDirectionCompass},
+		{input: "", wantError: entity.ErrWindDirectionCompass},
+	}
+
+	for _, tt := range tests {
+		got, err := entity.NewWindDirectionFromCompass(tt.input)
+		if tt.wantError != nil {
+			assert.ErrorIs(t, err, tt.wantError, tt.input)
+			continue
+		}
+
+		require.NoError(t, err, tt.input)
+		assert.Equal(t, tt.want, got.Degrees(), tt.input)
+		assert.Equal(t, strings.ToUpper(strings.TrimSpace(tt.input)), got.Compass(), tt.input)
+	}
+}
+
+func TestWindDirection_JSON(t *testing.T) {
+	d, err := entity.NewWindDirection(220)
+	require.NoError(t, err)
+
+	body, err := d.MarshalJSON()
+	require.NoError(t, err)
+	assert.Equal(t, "220.0", string(body))
+
+	var decoded entity.WindDirection
+	require.NoError(t, decoded.UnmarshalJSON(body))
+	assert.Equal(t, 220.0, decoded.Degrees())
+
+	require.ErrorIs(t, decoded.UnmarshalJSON([]byte(`361`)), entity.ErrWindDirectionOutOfRange)
+
+	require.NoError(t, decoded.UnmarshalJSON([]byte(`null`)))
+	assert.False(t, decoded.IsValid())
+	assert.Empty(t, decoded.Compass())
+}
@@ -3,3 +3,9 @@ package entity
 func AlmostEqual(a, b, epsilon float64) bool {
 	return almostEqual(a, b, epsilon)
 }
+
+func IsolateTemperatureUnits() (restore func()) {
+	saved := temperatureUnits
+	temperatureUnits = newTemperatureUnitRegistry(saved.all()...)
+	return func() { temperatureUnits = saved }
+}
@@ -0,0 +1,95 @@
+package cache
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+type lruEntry[V any] struct {
+	key       string
+	value     V
+	expiresAt time.Time
+}
+
+type lru[V any] struct {
+	mu        sync.Mutex
+	capacity  int
+	items     map[string]*list.Element
+	order     *list.List
+	evictions uint64
+}
+
+func newLRU[V any](capacity int) *lru[V] {
+	if capacity <= 0 {
+		capacity = 1
+	}
+
+	return &lru[V]{
+		capacity: capacity,
+		items:    make(map[string]*list.Element, capacity),
+		order:    list.New(),
+	}
+}
+
+func (c *lru[V]) get(key string, now time.Time) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var zero V
+
+	elem, ok := c.items[key]
+	if !ok {
+		return zero, false
+	}
+
+	entry := elem.Value.(*lruEntry[V])
+	if !now.Before(entry.expiresAt) {
+		c.removeElement(elem)
+		return zero, false
+	}
+
+	c.order.MoveToFront(elem)
+	return entry.value, true
+}
+
+func (c *lru[V]) set(key string, value V, expiresAt time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.items[key]; ok {
+		entry := elem.Value.(*lruEntry[V])
+		entry.value = value
+		entry.expiresAt = expiresAt
+		c.order.MoveToFront(elem)
+		return
+	}
+
+	elem := c.order.PushFront(&lruEntry[V]{key: key, value: value, expiresAt: expiresAt})
+	c.items[key] = elem
+
+	for c.order.Len() > c.capacity {
+		c.removeElement(c.order.Back())
+		c.evictions++
+	}
+}
+
+func (c *lru[V]) len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.order.Len()
+}
+
+func (c *lru[V]) evicted() uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.evictions
+}
+
+func (c *lru[V]) removeElement(elem *list.Element) {
+	entry := elem.Value.(*lruEntry[V])
+	delete(c.items, entry.key)
+	c.order.Remove(elem)
+}
@@ -0,0 +1,99 @@
+package cache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLRU(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name          string
+		capacity      int
+		action        func(c *lru[string])
+		wantKeys      map[string]string
+		wantMissing   []string
+		wantLen       int
+		wantEvictions uint64
+	}{
+		{
+			name:     "get after set",
+			capacity: 2,
+			action: func(c *lru[string]) {
+				c.set("a", "1", now.Add(time.Minute))
+			},
+			wantKeys: map[string]string{"a": "1"},
+			wantLen:  1,
+		},
+		{
+			name:     "expired entries are dropped",
+			capacity: 2,
+			action: func(c *lru[string]) {
+				c.set("a", "1", now)
+			},
+			wantMissing: []string{"a"},
+			wantLen:     0,
+		},
+		{
+			name:     "least recently used entry is evicted",
+			capacity: 2,
+			action: func(c *lru[string]) {
+				c.set("a", "1", now.Add(time.Minute))
+				c.set("b", "2", now.Add(time.Minute))
+				_, _ = c.get("a", now)
+				c.set("c", "3", now.Add(time.Minute))
+			},
+			wantKeys:      map[string]string{"a": "1", "c": "3"},
+			wantMissing:   []string{"b"},
+			wantLen:       2,
+			wantEvictions: 1,
+		},
+		{
+			name:     "overwrite keeps a single entry",
+			capacity: 2,
+			action: func(c *lru[string]) {
+				c.set("a", "1", now.Add(time.Minute))
+				c.set("a", "2", now.Add(time.Minute))
+			},
+			wantKeys: map[string]string{"a": "2"},
+			wantLen:  1,
+		},
+		{
+			name:     "non positive capacity holds one entry",
+			capacity: 0,
+			action: func(c *lru[string]) {
+				c.set("a", "1", now.Add(time.Minute))
+				c.set("b", "2", now.Add(time.Minute))
+			},
+			wantKeys:      map[string]string{"b": "2"},
+			wantMissing:   []string{"a"},
+			wantLen:       1,
+			wantEvictions: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newLRU[string](tt.capacity)
+			tt.action(c)
+
+			for k, v := range tt.wantKeys {
+				got, ok := c.get(k, now)
+				require.True(t, ok, "expected key %s", k)
+				assert.Equal(t, v, got)
+			}
+
+			for _, k := range tt.wantMissing {
+				_, ok := c.get(k, now)
+				assert.False(t, ok, "unexpected key %s", k)
+			}
+
+			assert.Equal(t, tt.wantLen, c.len())
+			assert.Equal(t, tt.wantEvictions, c.evicted())
+		})
+	}
+}
@@ -0,0 +1,142 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/biraneves/fc-labs-weather/internal/application/dto"
+	"github.com/biraneves/fc-labs-weather/internal/application/ports/outbound"
+	"github.com/biraneves/fc-labs-weather/internal/infrastructure/http/server"
+)
+
+const (
+	defaultWeatherCacheSize     = 1000
+	defaultWeatherCacheTTL      = 10 * time.Minute
+	defaultWeatherCacheStaleTTL = 6 * time.Hour
+	staleReserve                = 0.1
+)
+
+type weatherEntry struct {
+	resp      dto.WeatherAPIResponseDto
+	fetchedAt time.Time
+}
+
+type WeatherCache struct {
+	next     outbound.WeatherProviderPort
+	entries  *lru[weatherEntry]
+	ttl      time.Duration
+	staleTTL time.Duration
+	logger   *slog.Logger
+	now      func() time.Time
+
+	hits   atomic.Uint64
+	misses atomic.Uint64
+	stale  atomic.Uint64
+}
+
+func NewWeatherCache(next outbound.WeatherProviderPort, size int, ttl, staleTTL time.Duration, logger *slog.Logger) *WeatherCache {
+	if size <= 0 {
+		size = defaultWeatherCacheSize
+	}
+
+	if ttl <= 0 {
+		ttl = defaultWeatherCacheTTL
+	}
+
+	if staleTTL <= 0 {
+		staleTTL = defaultWeatherCacheStaleTTL
+	}
+
+	if staleTTL < ttl {
+		staleTTL = ttl
+	}
+
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	return &WeatherCache{
+		next:     next,
+		entries:  newLRU[weatherEntry](size),
+		ttl:      ttl,
+		staleTTL: staleTTL,
+		logger:   logger,
+		now:      time.Now,
+	}
+}
+
+func (c *WeatherCache) FetchCurrent(ctx context.Context, request dto.WeatherAPIRequestDto) (dto.WeatherAPIResponseDto, error) {
+	logger := server.LoggerFromContext(ctx, c.logger)
+	key := normalizeQuery(request.Q)
+
+	entry, cached := c.entries.get(key, c.now())
+	if cached && c.now().Sub(entry.fetchedAt) < c.ttl {
+		c.hits.Add(1)
+		logger.Info("weather cache: hit",
+			slog.String("type", "cache_hit"),
+			slog.String("query", key),
+		)
+		return entry.resp, nil
+	}
+
+	c.misses.Add(1)
+
+	fetchCtx, cancel := ctx, context.CancelFunc(func() {})
+	if cached {
+		fetchCtx, cancel = upstreamContext(ctx)
+	}
+	resp, err := c.next.FetchCurrent(fetchCtx, request)
+	cancel()
+	if err != nil {
+		if !cached || errors.Is(ctx.Err(), context.Canceled) {
+			return dto.WeatherAPIResponseDto{}, err
+		}
+
+		c.stale.Add(1)
+		logger.Warn("weather cache: serving stale response after upstream failure",
+			slog.String("type", "cache_stale"),
+			slog.String("query", key),
+			slog.Duration("age", c.now().Sub(entry.fetchedAt)),
+			slog.String("error", err.Error()),
+		)
+
+		stale := entry.resp
+		stale.Stale = true
+		return stale, nil
+	}
+
+	now := c.now()
+	c.entries.set(key, weatherEntry{resp: resp, fetchedAt: now}, now.Add(c.staleTTL))
+
+	return resp, nil
+}
+
+func (c *WeatherCache) Stats() Stats {
+	return Stats{
+		Hits:      c.hits.Load(),
+		Misses:    c.misses.Load(),
+		Stale:     c.stale.Load(),
+		Evictions: c.entries.evicted(),
+		Size:      c.entries.len(),
+	}
+}
+
+func upstreamContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return context.WithCancel(ctx)
+	}
+
+	reserve := time.Duration(float64(time.Until(deadline)) * staleReserve)
+	return context.WithDeadline(ctx, deadline.Add(-reserve))
+}
+
+func normalizeQuery(q string) string {
+	return strings.ToLower(strings.Join(strings.Fields(q), " "))
+}
+
+var _ outbound.WeatherProviderPort = (*WeatherCache)(nil)
@@ -0,0 +1,235 @@
+package cache_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/biraneves/fc-labs-weather/internal/application/dto"
+	"github.com/biraneves/fc-labs-weather/internal/application/ports/outbound"
+	"github.com/biraneves/fc-labs-weather/internal/infrastructure/cache"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeWeatherPort struct {
+	mu        sync.Mutex
+	responses []dto.WeatherAPIResponseDto
+	errs      []error
+	calls     int
+	queries   []string
+}
+
+func (f *fakeWeatherPort) FetchCurrent(ctx context.Context, req dto.WeatherAPIRequestDto) (dto.WeatherAPIResponseDto, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	i := f.calls
+	f.calls++
+	f.queries = append(f.queries, req.Q)
+
+	var err error
+	if i < len(f.errs) {
+		err = f.errs[i]
+	}
+	if err != nil {
+		return dto.WeatherAPIResponseDto{}, err
+	}
+
+	if i < len(f.responses) {
+		return f.responses[i], nil
+	}
+
+	return dto.WeatherAPIResponseDto{}, nil
+}
+
+func (f *fakeWeatherPort) Calls() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.calls
+}
+
+func weatherResp(tempC float64) dto.WeatherAPIResponseDto {
+	var resp dto.WeatherAPIResponseDto
+	resp.Current.TempC = tempC
+	return resp
+}
+
+func TestWeatherCache_FetchCurrent(t *testing.T) {
+	upstreamErr := errors.New("weather api unavailable")
+
+	type step struct {
+		query     string
+		advance   time.Duration
+		wantTempC float64
+		wantStale bool
+		wantErr   error
+	}
+
+	tests := []struct {
+		name      string
+		upstream  *fakeWeatherPort
+		steps     []step
+		wantCalls int
+		wantStats cache.Stats
+	}{
+		{
+			name:     "fresh entry is served from cache",
+			upstream: &fakeWeatherPort{responses: []dto.WeatherAPIResponseDto{weatherResp(25)}},
+			steps: []step{
+				{query: "São Paulo", wantTempC: 25},
+				{query: "  são   PAULO ", advance: 5 * time.Minute, wantTempC: 25},
+			},
+			wantCalls: 1,
+			wantStats: cache.Stats{Hits: 1, Misses: 1, Size: 1},
+		},
+		{
+			name:     "expired entry is refreshed",
+			upstream: &fakeWeatherPort{responses: []dto.WeatherAPIResponseDto{weatherResp(25), weatherResp(27)}},
+			steps: []step{
+				{query: "São Paulo", wantTempC: 25},
+				{query: "São Paulo", advance: 11 * time.Minute, wantTempC: 27},
+			},
+			wantCalls: 2,
+			wantStats: cache.Stats{Misses: 2, Size: 1},
+		},
+		{
+			name: "stale entry is served when upstream fails",
+			upstream: &fakeWeatherPort{
+				responses: []dto.WeatherAPIResponseDto{weatherResp(25)},
+				errs:      []error{nil, upstreamErr},
+			},
+			steps: []step{
+				{query: "São Paulo", wantTempC: 25},
+				{query: "São Paulo", advance: 30 * time.Minute, wantTempC: 25, wantStale: true},
+			},
+			wantCalls: 2,
+			wantStats: cache.Stats{Misses: 2, Stale: 1, Size: 1},
+		},
+		{
+			name: "entries older than the stale ttl are not served",
+			upstream: &fakeWeatherPort{
+				responses: []dto.WeatherAPIResponseDto{weatherResp(25)},
+				errs:      []error{nil, upstreamErr},
+			},
+			steps: []step{
+				{query: "São Paulo", wantTempC: 25},
+				{query: "São Paulo", advance: 2 * time.Hour, wantErr: upstreamErr},
+			},
+			wantCalls: 2,
+			wantStats: cache.Stats{Misses: 2},
+		},
+		{
+			name:     "upstream failure without cached entry",
+			upstream: &fakeWeatherPort{errs: []error{upstreamErr}},
+			steps: []step{
+				{query: "São Paulo", wantErr: upstreamErr},
+			},
+			wantCalls: 1,
+			wantStats: cache.Stats{Misses: 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clock := &fakeClock{now: time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)}
+			c := cache.NewWeatherCache(tt.upstream, 10, 10*time.Minute, time.Hour, noopLogger)
+			c.SetClock(clock.Now)
+
+			for _, s := range tt.steps {
+				clock.Advance(s.advance)
+
+				got, err := c.FetchCurrent(context.Background(), dto.WeatherAPIRequestDto{Q: s.query})
+				if s.wantErr != nil {
+					require.ErrorIs(t, err, s.wantErr)
+					continue
+				}
+
+				require.NoError(t, err)
+				assert.Equal(t, s.wantTempC, got.Current.TempC)
+				assert.Equal(t, s.wantStale, got.Stale)
+			}
+
+			assert.Equal(t, tt.wantCalls, tt.upstream.Calls())
+			assert.Equal(t, tt.wantStats, c.Stats())
+		})
+	}
+}
+
+func TestWeatherCache_CanceledCallerGetsError(t *testing.T) {
+	upstream := &fakeWeatherPort{
+		responses: []dto.WeatherAPIResponseDto{weatherResp(25)},
+		errs:      []error{nil, context.Canceled},
+	}
+	clock := &fakeClock{now: time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)}
+	c := cache.NewWeatherCache(upstream, 10, time.Minute, time.Hour, noopLogger)
+	c.SetClock(clock.Now)
+
+	_, err := c.FetchCurrent(context.Background(), dto.WeatherAPIRequestDto{Q: "Recife"})
+	require.NoError(t, err)
+
+	clock.Advance(5 * time.Minute)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err = c.FetchCurrent(ctx, dto.WeatherAPIRequestDto{Q: "Recife"})
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+type hangingWeatherPort struct {
+	calls atomic.Int32
+}
+
+func (h *hangingWeatherPort) FetchCurrent(ctx context.Context, req dto.WeatherAPIRequestDto) (dto.WeatherAPIResponseDto, error) {
+	if h.calls.Add(1) == 1 {
+		return weatherResp(25), nil
+	}
+
+	<-ctx.Done()
+	return dto.WeatherAPIResponseDto{}, fmt.Errorf("%w: %w", outbound.ErrUpstreamTimeout, ctx.Err())
+}
+
+func TestWeatherCache_HangingUpstreamServesStaleBeforeDeadline(t *testing.T) {
+	upstream := &hangingWeatherPort{}
+	clock := &fakeClock{now: time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)}
+	c := cache.NewWeatherCache(upstream, 10, time.Minute, time.Hour, noopLogger)
+	c.SetClock(clock.Now)
+
+	_, err := c.FetchCurrent(context.Background(), dto.WeatherAPIRequestDto{Q: "Recife"})
+	require.NoError(t, err)
+
+	clock.Advance(5 * time.Minute)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	got, err := c.FetchCurrent(ctx, dto.WeatherAPIRequestDto{Q: "Recife"})
+	require.NoError(t, err)
+	assert.True(t, got.Stale)
+	assert.Equal(t, 25.0, got.Current.TempC)
+	assert.NoError(t, ctx.Err(), "stale response must arrive before the caller deadline")
+	assert.Equal(t, uint64(1), c.Stats().Stale)
+}
+
+func TestNormalizeQuery(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{input: "São Paulo", want: "são paulo"},
+		{input: "  RIO   de\tJaneiro ", want: "rio de janeiro"},
+		{input: "", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			assert.Equal(t, tt.want, cache.NormalizeQuery(tt.input))
+		})
+	}
+}
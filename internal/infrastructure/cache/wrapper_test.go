@@ -0,0 +1,15 @@
+package cache
+
+import "time"
+
+func (c *ZipcodeCache) SetClock(now func() time.Time) {
+	c.now = now
+}
+
+func (c *WeatherCache) SetClock(now func() time.Time) {
+	c.now = now
+}
+
+func NormalizeQuery(q string) string {
+	return normalizeQuery(q)
+}
@@ -0,0 +1,122 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync/atomic"
+	"time"
+
+	"github.com/biraneves/fc-labs-weather/internal/application/dto"
+	"github.com/biraneves/fc-labs-weather/internal/application/ports/outbound"
+	"github.com/biraneves/fc-labs-weather/internal/infrastructure/http/server"
+)
+
+const (
+	defaultZipcodeCacheSize        = 10000
+	defaultZipcodeCacheTTL         = 30 * 24 * time.Hour
+	defaultZipcodeCacheNegativeTTL = time.Hour
+)
+
+type zipcodeEntry struct {
+	resp     dto.ViaCEPResponseDto
+	notFound bool
+}
+
+type Stats struct {
+	Hits         uint64 `json:"hits"`
+	NegativeHits uint64 `json:"negative_hits"`
+	Misses       uint64 `json:"misses"`
+	Stale        uint64 `json:"stale"`
+	Evictions    uint64 `json:"evictions"`
+	Size         int    `json:"size"`
+}
+
+type ZipcodeCache struct {
+	next        outbound.ZipcodeLookupPort
+	entries     *lru[zipcodeEntry]
+	ttl         time.Duration
+	negativeTTL time.Duration
+	logger      *slog.Logger
+	now         func() time.Time
+
+	hits         atomic.Uint64
+	negativeHits atomic.Uint64
+	misses       atomic.Uint64
+}
+
+func NewZipcodeCache(next outbound.ZipcodeLookupPort, size int, ttl, negativeTTL time.Duration, logger *slog.Logger) *ZipcodeCache {
+	if size <= 0 {
+		size = defaultZipcodeCacheSize
+	}
+
+	if ttl <= 0 {
+		ttl = defaultZipcodeCacheTTL
+	}
+
+	if negativeTTL <= 0 {
+		negativeTTL = defaultZipcodeCacheNegativeTTL
+	}
+
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	return &ZipcodeCache{
+		next:        next,
+		entries:     newLRU[zipcodeEntry](size),
+		ttl:         ttl,
+		negativeTTL: negativeTTL,
+		logger:      logger,
+		now:         time.Now,
+	}
+}
+
+func (c *ZipcodeCache) Find(ctx context.Context, request dto.ViaCEPRequestDto) (dto.ViaCEPResponseDto, error) {
+	logger := server.LoggerFromContext(ctx, c.logger)
+	key := request.CEP.String()
+
+	if entry, ok := c.entries.get(key, c.now()); ok {
+		if entry.notFound {
+			c.negativeHits.Add(1)
+			logger.Info("zipcode cache: negative hit",
+				slog.String("type", "cache_hit"),
+				slog.String("cep", key),
+			)
+			return dto.ViaCEPResponseDto{}, outbound.ErrZipcodeNotFound
+		}
+
+		c.hits.Add(1)
+		logger.Info("zipcode cache: hit",
+			slog.String("type", "cache_hit"),
+			slog.String("cep", key),
+		)
+		return entry.resp, nil
+	}
+
+	c.misses.Add(1)
+
+	resp, err := c.next.Find(ctx, request)
+	if err != nil {
+		if errors.Is(err, outbound.ErrZipcodeNotFound) {
+			c.entries.set(key, zipcodeEntry{notFound: true}, c.now().Add(c.negativeTTL))
+		}
+		return dto.ViaCEPResponseDto{}, err
+	}
+
+	c.entries.set(key, zipcodeEntry{resp: resp}, c.now().Add(c.ttl))
+
+	return resp, nil
+}
+
+func (c *ZipcodeCache) Stats() Stats {
+	return Stats{
+		Hits:         c.hits.Load(),
+		NegativeHits: c.negativeHits.Load(),
+		Misses:       c.misses.Load(),
+		Evictions:    c.entries.evicted(),
+		Size:         c.entries.len(),
+	}
+}
+
+var _ outbound.ZipcodeLookupPort = (*ZipcodeCache)(nil)
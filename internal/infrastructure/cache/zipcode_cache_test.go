@@ -0,0 +1,148 @@
+package cache_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/biraneves/fc-labs-weather/internal/application/dto"
+	"github.com/biraneves/fc-labs-weather/internal/application/ports/outbound"
+	"github.com/biraneves/fc-labs-weather/internal/domain/entity"
+	"github.com/biraneves/fc-labs-weather/internal/infrastructure/cache"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var noopLogger = slog.New(slog.NewJSONHandler(io.Discard, nil))
+
+type fakeZipcodePort struct {
+	mu    sync.Mutex
+	resp  dto.ViaCEPResponseDto
+	err   error
+	calls int
+}
+
+func (f *fakeZipcodePort) Find(ctx context.Context, request dto.ViaCEPRequestDto) (dto.ViaCEPResponseDto, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.calls++
+	return f.resp, f.err
+}
+
+func (f *fakeZipcodePort) Calls() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.calls
+}
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.now = c.now.Add(d)
+}
+
+func TestZipcodeCache_Find(t *testing.T) {
+	cep := entity.Cep("01001000")
+	found := dto.ViaCEPResponseDto{CEP: "01001-000", Localidade: "São Paulo", UF: "SP"}
+
+	tests := []struct {
+		name      string
+		upstream  *fakeZipcodePort
+		advance   time.Duration
+		wantErr   error
+		wantResp  dto.ViaCEPResponseDto
+		wantCalls int
+		wantStats cache.Stats
+	}{
+		{
+			name:      "positive result is served from cache",
+			upstream:  &fakeZipcodePort{resp: found},
+			wantResp:  found,
+			wantCalls: 1,
+			wantStats: cache.Stats{Hits: 1, Misses: 1, Size: 1},
+		},
+		{
+			name:      "positive result expires after ttl",
+			upstream:  &fakeZipcodePort{resp: found},
+			advance:   time.Hour + time.Second,
+			wantResp:  found,
+			wantCalls: 2,
+			wantStats: cache.Stats{Misses: 2, Size: 1},
+		},
+		{
+			name:      "not found is negatively cached",
+			upstream:  &fakeZipcodePort{err: outbound.ErrZipcodeNotFound},
+			wantErr:   outbound.ErrZipcodeNotFound,
+			wantCalls: 1,
+			wantStats: cache.Stats{NegativeHits: 1, Misses: 1, Size: 1},
+		},
+		{
+			name:      "negative entry expires after negative ttl",
+			upstream:  &fakeZipcodePort{err: outbound.ErrZipcodeNotFound},
+			advance:   time.Minute + time.Second,
+			wantErr:   outbound.ErrZipcodeNotFound,
+			wantCalls: 2,
+			wantStats: cache.Stats{Misses: 2, Size: 1},
+		},
+		{
+			name:      "upstream failures are not cached",
+			upstream:  &fakeZipcodePort{err: errors.New("boom")},
+			wantCalls: 2,
+			wantStats: cache.Stats{Misses: 2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clock := &fakeClock{now: time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)}
+			c := cache.NewZipcodeCache(tt.upstream, 10, time.Hour, time.Minute, noopLogger)
+			c.SetClock(clock.Now)
+
+			for i := 0; i < 2; i++ {
+				got, err := c.Find(context.Background(), dto.ViaCEPRequestDto{CEP: cep})
+
+				switch {
+				case tt.wantErr != nil:
+					require.ErrorIs(t, err, tt.wantErr)
+				case tt.upstream.err != nil:
+					require.Error(t, err)
+				default:
+					require.NoError(t, err)
+					assert.Equal(t, tt.wantResp, got)
+				}
+
+				clock.Advance(tt.advance)
+			}
+
+			assert.Equal(t, tt.wantCalls, tt.upstream.Calls())
+			assert.Equal(t, tt.wantStats, c.Stats())
+		})
+	}
+}
+
+func TestZipcodeCache_Eviction(t *testing.T) {
+	upstream := &fakeZipcodePort{resp: dto.ViaCEPResponseDto{Localidade: "São Paulo"}}
+	c := cache.NewZipcodeCache(upstream, 2, 0, 0, nil)
+
+	for _, raw := range []string{"01001000", "01310100", "07190050", "01001000"} {
+		_, err := c.Find(context.Background(), dto.ViaCEPRequestDto{CEP: entity.Cep(raw)})
+		require.NoError(t, err)
+	}
+
+	stats := c.Stats()
+	assert.Equal(t, 4, upstream.Calls())
+	assert.Equal(t, uint64(2), stats.Evictions)
+	assert.Equal(t, 2, stats.Size)
+}
@@ -4,7 +4,10 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"net"
+	"net/netip"
 	"os"
+	"strconv"
 	"strings"
 	"time"
 
@@ -13,32 +16,255 @@ import (
 
 const (
 	defaultPort          = "8080"
+	defaultAdminHost     = "127.0.0.1"
 	defaultTimeout       = 5 * time.Second
+	defaultTimeoutMin    = 100 * time.Millisecond
+	defaultTimeoutMax    = 30 * time.Second
+	defaultZipcodeShare  = 0.4
 	defaultAPIReturnType = "json"
 	defaultWeatherAPIKey = "default_key"
+
+	defaultMaxIdleConns        = 100
+	defaultMaxIdleConnsPerHost = 10
+	defaultIdleConnTimeout     = 90 * time.Second
+	defaultKeepAlive           = 30 * time.Second
+	defaultDialTimeout         = 5 * time.Second
+	defaultTLSHandshakeTimeout = 5 * time.Second
+	defaultDNSCacheTTL         = time.Minute
+
+	ZipcodeProviderViaCEP    = "viacep"
+	ZipcodeProviderBrasilAPI = "brasilapi"
+	ZipcodeProviderOpenCEP   = "opencep"
+
+	defaultZipcodeProvider = ZipcodeProviderViaCEP
+
+	WeatherProviderWeatherAPI = "weatherapi"
+	WeatherProviderOpenMeteo  = "openmeteo"
+
+	defaultWeatherProvider = WeatherProviderWeatherAPI
+
+	defaultZipcodeNotFoundAuthoritative = true
+	defaultZipcodeFailoverCoolDown      = 30 * time.Second
+
+	defaultWeatherHedgePercentile  = 0.95
+	defaultWeatherHedgeMinDelay    = 100 * time.Millisecond
+	defaultWeatherFailoverCoolDown = 30 * time.Second
+
+	defaultWeatherEnsembleStrategy   = "median"
+	defaultWeatherEnsembleThresholdC = 2.0
+
+	defaultShadowSampleRate     = 0.1
+	defaultShadowMaxInFlight    = 10
+	defaultShadowTimeout        = 5 * time.Second
+	defaultShadowTempThresholdC = 1.0
+
+	defaultWeatherAPIUsageFile = "weatherapi_usage.json"
+
+	defaultZipcodeCacheSize        = 10000
+	defaultZipcodeCacheTTL         = 30 * 24 * time.Hour
+	defaultZipcodeCacheNegativeTTL = time.Hour
+
+	defaultWeatherCacheSize     = 1000
+	defaultWeatherCacheTTL      = 10 * time.Minute
+	defaultWeatherCacheStaleTTL = 6 * time.Hour
+
+	defaultRetryMaxAttempts = 3
+	defaultRetryBaseBackoff = 100 * time.Millisecond
+	defaultRetryMaxBackoff  = 2 * time.Second
+	defaultRetryJitter      = 0.2
+
+	defaultBreakerEnabled          = true
+	defaultBreakerFailureRatio     = 0.5
+	defaultBreakerMinRequests      = 10
+	defaultBreakerWindow           = 30 * time.Second
+	defaultBreakerCoolDown         = 15 * time.Second
+	defaultBreakerHalfOpenRequests = 1
+
+	defaultRateLimitEnabled      = false
+	defaultRateLimitRPS          = 5.0
+	defaultRateLimitBurst        = 10
+	defaultRateLimitIdleTTL      = 10 * time.Minute
+	defaultRateLimitAPIKeyHeader = "X-API-Key"
+	defaultRateLimitMaxClients   = 10000
+
+	ConcurrencyModeOff      = "off"
+	ConcurrencyModeFixed    = "fixed"
+	ConcurrencyModeAdaptive = "adaptive"
+
+	defaultConcurrencyMode          = ConcurrencyModeFixed
+	defaultConcurrencyMax           = 100
+	defaultConcurrencyMin           = 10
+	defaultConcurrencyLatencyTarget = time.Second
+	defaultConcurrencyQueueSize     = 50
+	defaultConcurrencyQueueTimeout  = 200 * time.Millisecond
 )
 
+var defaultRetryStatuses = []int{429, 500, 502, 503, 504}
+
+var zipcodeProviders = map[string]bool{
+	ZipcodeProviderViaCEP:    true,
+	ZipcodeProviderBrasilAPI: true,
+	ZipcodeProviderOpenCEP:   true,
+}
+
+var weatherProviders = map[string]bool{
+	WeatherProviderWeatherAPI: true,
+	WeatherProviderOpenMeteo:  true,
+}
+
 type HTTPConfig struct {
-	Addr    string
-	Timeout time.Duration
+	Addr         string
+	AdminAddr    string
+	Timeout      time.Duration
+	TimeoutMin   time.Duration
+	TimeoutMax   time.Duration
+	ZipcodeShare float64
+}
+
+type TransportConfig struct {
+	MaxIdleConns        int
+	MaxIdleConnsPerHost int
+	MaxConnsPerHost     int
+	IdleConnTimeout     time.Duration
+	KeepAlive           time.Duration
+	DialTimeout         time.Duration
+	TLSHandshakeTimeout time.Duration
+	ProxyURL            string
+	CABundle            string
+	DNSCacheTTL         time.Duration
 }
 
 type ViaCEPConfig struct {
 	BaseURL    string
 	ReturnType string
 	Timeout    time.Duration
+	Transport  TransportConfig
+}
+
+type BrasilAPIConfig struct {
+	BaseURL   string
+	Timeout   time.Duration
+	Transport TransportConfig
+}
+
+type OpenCEPConfig struct {
+	BaseURL   string
+	Timeout   time.Duration
+	Transport TransportConfig
 }
 
 type WeatherAPIConfig struct {
-	BaseURL string
-	APIKey  string
-	Timeout time.Duration
+	BaseURL   string
+	APIKey    string
+	APIKeys   []string
+	SoftLimit int
+	UsageFile string
+	Timeout   time.Duration
+	Transport TransportConfig
+}
+
+type OpenMeteoConfig struct {
+	GeocodingURL string
+	ForecastURL  string
+	Timeout      time.Duration
+	Transport    TransportConfig
+}
+
+type ZipcodeFailoverConfig struct {
+	FallbackProviders     []string
+	NotFoundAuthoritative bool
+	CoolDown              time.Duration
+}
+
+type WeatherFailoverConfig struct {
+	FallbackProviders []string
+	HedgePercentile   float64
+	HedgeMinDelay     time.Duration
+	CoolDown          time.Duration
+}
+
+type WeatherEnsembleConfig struct {
+	Providers  []string
+	Strategy   string
+	ThresholdC float64
+}
+
+type WeatherShadowConfig struct {
+	Provider       string
+	SampleRate     float64
+	MaxInFlight    int
+	Timeout        time.Duration
+	TempThresholdC float64
+}
+
+type ZipcodeCacheConfig struct {
+	Size        int
+	TTL         time.Duration
+	NegativeTTL time.Duration
+}
+
+type WeatherCacheConfig struct {
+	Size     int
+	TTL      time.Duration
+	StaleTTL time.Duration
+}
+
+type RetryConfig struct {
+	MaxAttempts       int
+	BaseBackoff       time.Duration
+	MaxBackoff        time.Duration
+	Jitter            float64
+	RetryableStatuses []int
+}
+
+type BreakerConfig struct {
+	Enabled          bool
+	FailureRatio     float64
+	MinRequests      int
+	Window           time.Duration
+	CoolDown         time.Duration
+	HalfOpenRequests int
+}
+
+type RateLimitConfig struct {
+	Enabled           bool
+	RequestsPerSecond float64
+	Burst             int
+	IdleTTL           time.Duration
+	TrustedProxies    []netip.Prefix
+	APIKeyHeader      string
+	APIKeys           []string
+	MaxClients        int
+}
+
+type ConcurrencyConfig struct {
+	Mode          string
+	MaxConcurrent int
+	MinConcurrent int
+	LatencyTarget time.Duration
+	QueueSize     int
+	QueueTimeout  time.Duration
 }
 
 type AppConfig struct {
-	HTTP    HTTPConfig
-	ViaCEP  ViaCEPConfig
-	Weather WeatherAPIConfig
+	HTTP            HTTPConfig
+	ZipcodeProvider string
+	ViaCEP          ViaCEPConfig
+	BrasilAPI       BrasilAPIConfig
+	OpenCEP         OpenCEPConfig
+	ZipcodeFailover ZipcodeFailoverConfig
+	WeatherProvider string
+	Weather         WeatherAPIConfig
+	OpenMeteo       OpenMeteoConfig
+	WeatherFailover WeatherFailoverConfig
+	WeatherEnsemble WeatherEnsembleConfig
+	WeatherShadow   WeatherShadowConfig
+	ZipcodeCache    ZipcodeCacheConfig
+	WeatherCache    WeatherCacheConfig
+	Retry           RetryConfig
+	Breaker         BreakerConfig
+	RateLimit       RateLimitConfig
+	Concurrency     ConcurrencyConfig
 }
 
 func Load(dir string) (AppConfig, error) {
@@ -53,24 +279,181 @@ func Load(dir string) (AppConfig, error) {
 		appPort = defaultPort
 	}
 
+	adminAddr := ""
+	if adminPort := strings.TrimSpace(os.Getenv("ADMIN_PORT")); adminPort != "" {
+		adminHost := strings.TrimSpace(os.Getenv("ADMIN_HOST"))
+		if adminHost == "" {
+			adminHost = defaultAdminHost
+		}
+		adminAddr = net.JoinHostPort(adminHost, adminPort)
+	}
+
 	httpTimeout, err := parseDuration(os.Getenv("HTTP_TIMEOUT"))
 	if err != nil {
 		slog.Warn("invalid HTTP_TIMEOUT:", "default_value", defaultTimeout)
 		httpTimeout = defaultTimeout
 	}
 
+	httpTimeoutMin, err := parseDuration(os.Getenv("HTTP_TIMEOUT_MIN"))
+	if err != nil || httpTimeoutMin <= 0 {
+		slog.Warn("invalid HTTP_TIMEOUT_MIN:", "default_value", defaultTimeoutMin)
+		httpTimeoutMin = defaultTimeoutMin
+	}
+
+	httpTimeoutMax, err := parseDuration(os.Getenv("HTTP_TIMEOUT_MAX"))
+	if err != nil || httpTimeoutMax < httpTimeoutMin {
+		slog.Warn("invalid HTTP_TIMEOUT_MAX:", "default_value", max(defaultTimeoutMax, httpTimeoutMin))
+		httpTimeoutMax = max(defaultTimeoutMax, httpTimeoutMin)
+	}
+
+	zipcodeShare, err := parseFloat(os.Getenv("ZIPCODE_BUDGET_SHARE"))
+	if err != nil || zipcodeShare <= 0 || zipcodeShare >= 1 {
+		slog.Warn("invalid ZIPCODE_BUDGET_SHARE:", "default_value", defaultZipcodeShare)
+		zipcodeShare = defaultZipcodeShare
+	}
+
+	httpTransport := loadTransport("HTTP", TransportConfig{
+		MaxIdleConns:        defaultMaxIdleConns,
+		MaxIdleConnsPerHost: defaultMaxIdleConnsPerHost,
+		IdleConnTimeout:     defaultIdleConnTimeout,
+		KeepAlive:           defaultKeepAlive,
+		DialTimeout:         defaultDialTimeout,
+		TLSHandshakeTimeout: defaultTLSHandshakeTimeout,
+		DNSCacheTTL:         defaultDNSCacheTTL,
+	})
+
 	viaCEPTimeout, err := parseDuration(os.Getenv("VIACEP_TIMEOUT"))
 	if err != nil {
 		slog.Warn("invalid VIACEP_TIMEOUT:", "default_value", defaultTimeout)
 		viaCEPTimeout = defaultTimeout
 	}
 
+	zipcodeProvider := strings.ToLower(strings.TrimSpace(os.Getenv("ZIPCODE_PROVIDER")))
+	if !zipcodeProviders[zipcodeProvider] {
+		slog.Warn("invalid ZIPCODE_PROVIDER:", "default_value", defaultZipcodeProvider)
+		zipcodeProvider = defaultZipcodeProvider
+	}
+
+	zipcodeFallbackProviders, err := parseProviderList(os.Getenv("ZIPCODE_FALLBACK_PROVIDERS"), zipcodeProviders)
+	if err != nil {
+		slog.Warn("invalid ZIPCODE_FALLBACK_PROVIDERS - failover disabled", "error", err.Error())
+		zipcodeFallbackProviders = nil
+	}
+
+	zipcodeNotFoundAuthoritative, err := parseBool(os.Getenv("ZIPCODE_NOT_FOUND_AUTHORITATIVE"))
+	if err != nil {
+		slog.Warn("invalid ZIPCODE_NOT_FOUND_AUTHORITATIVE:", "default_value", defaultZipcodeNotFoundAuthoritative)
+		zipcodeNotFoundAuthoritative = defaultZipcodeNotFoundAuthoritative
+	}
+
+	zipcodeFailoverCoolDown, err := parseDuration(os.Getenv("ZIPCODE_FAILOVER_COOLDOWN"))
+	if err != nil {
+		slog.Warn("invalid ZIPCODE_FAILOVER_COOLDOWN:", "default_value", defaultZipcodeFailoverCoolDown)
+		zipcodeFailoverCoolDown = defaultZipcodeFailoverCoolDown
+	}
+
+	brasilAPITimeout, err := parseDuration(os.Getenv("BRASILAPI_TIMEOUT"))
+	if err != nil {
+		slog.Warn("invalid BRASILAPI_TIMEOUT:", "default_value", defaultTimeout)
+		brasilAPITimeout = defaultTimeout
+	}
+
+	openCEPTimeout, err := parseDuration(os.Getenv("OPENCEP_TIMEOUT"))
+	if err != nil {
+		slog.Warn("invalid OPENCEP_TIMEOUT:", "default_value", defaultTimeout)
+		openCEPTimeout = defaultTimeout
+	}
+
 	weatherTimeout, err := parseDuration(os.Getenv("WEATHER_TIMEOUT"))
 	if err != nil {
 		slog.Warn("invalid WEATHER_TIMEOUT:", "default_value", defaultTimeout)
 		weatherTimeout = defaultTimeout
 	}
 
+	weatherProvider := strings.ToLower(strings.TrimSpace(os.Getenv("WEATHER_PROVIDER")))
+	if !weatherProviders[weatherProvider] {
+		slog.Warn("invalid WEATHER_PROVIDER:", "default_value", defaultWeatherProvider)
+		weatherProvider = defaultWeatherProvider
+	}
+
+	weatherFallbackProviders, err := parseProviderList(os.Getenv("WEATHER_FALLBACK_PROVIDERS"), weatherProviders)
+	if err != nil {
+		slog.Warn("invalid WEATHER_FALLBACK_PROVIDERS - failover disabled", "error", err.Error())
+		weatherFallbackProviders = nil
+	}
+
+	weatherHedgePercentile, err := parseFloat(os.Getenv("WEATHER_HEDGE_PERCENTILE"))
+	if err != nil || weatherHedgePercentile < 0 || weatherHedgePercentile > 1 {
+		slog.Warn("invalid WEATHER_HEDGE_PERCENTILE:", "default_value", defaultWeatherHedgePercentile)
+		weatherHedgePercentile = defaultWeatherHedgePercentile
+	}
+
+	weatherHedgeMinDelay, err := parseDuration(os.Getenv("WEATHER_HEDGE_MIN_DELAY"))
+	if err != nil {
+		slog.Warn("invalid WEATHER_HEDGE_MIN_DELAY:", "default_value", defaultWeatherHedgeMinDelay)
+		weatherHedgeMinDelay = defaultWeatherHedgeMinDelay
+	}
+
+	weatherFailoverCoolDown, err := parseDuration(os.Getenv("WEATHER_FAILOVER_COOLDOWN"))
+	if err != nil {
+		slog.Warn("invalid WEATHER_FAILOVER_COOLDOWN:", "default_value", defaultWeatherFailoverCoolDown)
+		weatherFailoverCoolDown = defaultWeatherFailoverCoolDown
+	}
+
+	weatherEnsembleProviders, err := parseProviderList(os.Getenv("WEATHER_ENSEMBLE_PROVIDERS"), weatherProviders)
+	if err != nil {
+		slog.Warn("invalid WEATHER_ENSEMBLE_PROVIDERS - ensemble disabled", "error", err.Error())
+		weatherEnsembleProviders = nil
+	}
+
+	weatherEnsembleStrategy := strings.ToLower(strings.TrimSpace(os.Getenv("WEATHER_ENSEMBLE_STRATEGY")))
+	if weatherEnsembleStrategy != "median" && weatherEnsembleStrategy != "mean" {
+		slog.Warn("invalid WEATHER_ENSEMBLE_STRATEGY:", "default_value", defaultWeatherEnsembleStrategy)
+		weatherEnsembleStrategy = defaultWeatherEnsembleStrategy
+	}
+
+	weatherEnsembleThresholdC, err := parseFloat(os.Getenv("WEATHER_ENSEMBLE_THRESHOLD_C"))
+	if err != nil || weatherEnsembleThresholdC < 0 {
+		slog.Warn("invalid WEATHER_ENSEMBLE_THRESHOLD_C:", "default_value", defaultWeatherEnsembleThresholdC)
+		weatherEnsembleThresholdC = defaultWeatherEnsembleThresholdC
+	}
+
+	shadowProvider := strings.ToLower(strings.TrimSpace(os.Getenv("SHADOW_WEATHER_PROVIDER")))
+	if shadowProvider != "" && !weatherProviders[shadowProvider] {
+		slog.Warn("invalid SHADOW_WEATHER_PROVIDER - shadow traffic disabled", "value", shadowProvider)
+		shadowProvider = ""
+	}
+
+	shadowSampleRate, err := parseFloat(os.Getenv("SHADOW_SAMPLE_RATE"))
+	if err != nil || shadowSampleRate < 0 || shadowSampleRate > 1 {
+		slog.Warn("invalid SHADOW_SAMPLE_RATE:", "default_value", defaultShadowSampleRate)
+		shadowSampleRate = defaultShadowSampleRate
+	}
+
+	shadowMaxInFlight, err := parseInt(os.Getenv("SHADOW_MAX_IN_FLIGHT"))
+	if err != nil || shadowMaxInFlight < 1 {
+		slog.Warn("invalid SHADOW_MAX_IN_FLIGHT:", "default_value", defaultShadowMaxInFlight)
+		shadowMaxInFlight = defaultShadowMaxInFlight
+	}
+
+	shadowTimeout, err := parseDuration(os.Getenv("SHADOW_TIMEOUT"))
+	if err != nil {
+		slog.Warn("invalid SHADOW_TIMEOUT:", "default_value", defaultShadowTimeout)
+		shadowTimeout = defaultShadowTimeout
+	}
+
+	shadowTempThresholdC, err := parseFloat(os.Getenv("SHADOW_TEMP_THRESHOLD_C"))
+	if err != nil || shadowTempThresholdC < 0 {
+		slog.Warn("invalid SHADOW_TEMP_THRESHOLD_C:", "default_value", defaultShadowTempThresholdC)
+		shadowTempThresholdC = defaultShadowTempThresholdC
+	}
+
+	openMeteoTimeout, err := parseDuration(os.Getenv("OPENMETEO_TIMEOUT"))
+	if err != nil {
+		slog.Warn("invalid OPENMETEO_TIMEOUT:", "default_value", defaultTimeout)
+		openMeteoTimeout = defaultTimeout
+	}
+
 	returnType := os.Getenv("VIACEP_RETURN_TYPE")
 	if returnType == "" {
 		slog.Warn("invalid VIACEP_RETURN_TYPE:", "default_value", defaultAPIReturnType)
@@ -83,26 +466,369 @@ func Load(dir string) (AppConfig, error) {
 		weatherAPIKey = defaultWeatherAPIKey
 	}
 
+	weatherAPIKeys := parseStringList(os.Getenv("WEATHER_API_KEYS"))
+	if len(weatherAPIKeys) == 0 {
+		weatherAPIKeys = []string{weatherAPIKey}
+	}
+
+	weatherAPISoftLimit, err := parseInt(os.Getenv("WEATHER_API_KEY_SOFT_LIMIT"))
+	if err != nil || weatherAPISoftLimit < 0 {
+		slog.Warn("invalid WEATHER_API_KEY_SOFT_LIMIT - soft limit disabled")
+		weatherAPISoftLimit = 0
+	}
+
+	weatherAPIUsageFile := strings.TrimSpace(os.Getenv("WEATHER_API_USAGE_FILE"))
+	if weatherAPIUsageFile == "" {
+		slog.Warn("invalid WEATHER_API_USAGE_FILE:", "default_value", defaultWeatherAPIUsageFile)
+		weatherAPIUsageFile = defaultWeatherAPIUsageFile
+	}
+
+	zipcodeCacheSize, err := parseInt(os.Getenv("ZIPCODE_CACHE_SIZE"))
+	if err != nil || zipcodeCacheSize < 0 {
+		slog.Warn("invalid ZIPCODE_CACHE_SIZE:", "default_value", defaultZipcodeCacheSize)
+		zipcodeCacheSize = defaultZipcodeCacheSize
+	}
+
+	zipcodeCacheTTL, err := parseDuration(os.Getenv("ZIPCODE_CACHE_TTL"))
+	if err != nil {
+		slog.Warn("invalid ZIPCODE_CACHE_TTL:", "default_value", defaultZipcodeCacheTTL)
+		zipcodeCacheTTL = defaultZipcodeCacheTTL
+	}
+
+	zipcodeCacheNegativeTTL, err := parseDuration(os.Getenv("ZIPCODE_CACHE_NEGATIVE_TTL"))
+	if err != nil {
+		slog.Warn("invalid ZIPCODE_CACHE_NEGATIVE_TTL:", "default_value", defaultZipcodeCacheNegativeTTL)
+		zipcodeCacheNegativeTTL = defaultZipcodeCacheNegativeTTL
+	}
+
+	weatherCacheSize, err := parseInt(os.Getenv("WEATHER_CACHE_SIZE"))
+	if err != nil || weatherCacheSize < 0 {
+		slog.Warn("invalid WEATHER_CACHE_SIZE:", "default_value", defaultWeatherCacheSize)
+		weatherCacheSize = defaultWeatherCacheSize
+	}
+
+	weatherCacheTTL, err := parseDuration(os.Getenv("WEATHER_CACHE_TTL"))
+	if err != nil {
+		slog.Warn("invalid WEATHER_CACHE_TTL:", "default_value", defaultWeatherCacheTTL)
+		weatherCacheTTL = defaultWeatherCacheTTL
+	}
+
+	weatherCacheStaleTTL, err := parseDuration(os.Getenv("WEATHER_CACHE_STALE_TTL"))
+	if err != nil {
+		slog.Warn("invalid WEATHER_CACHE_STALE_TTL:", "default_value", defaultWeatherCacheStaleTTL)
+		weatherCacheStaleTTL = defaultWeatherCacheStaleTTL
+	}
+
+	retryMaxAttempts, err := parseInt(os.Getenv("RETRY_MAX_ATTEMPTS"))
+	if err != nil || retryMaxAttempts < 1 {
+		slog.Warn("invalid RETRY_MAX_ATTEMPTS:", "default_value", defaultRetryMaxAttempts)
+		retryMaxAttempts = defaultRetryMaxAttempts
+	}
+
+	retryBaseBackoff, err := parseDuration(os.Getenv("RETRY_BASE_BACKOFF"))
+	if err != nil {
+		slog.Warn("invalid RETRY_BASE_BACKOFF:", "default_value", defaultRetryBaseBackoff)
+		retryBaseBackoff = defaultRetryBaseBackoff
+	}
+
+	retryMaxBackoff, err := parseDuration(os.Getenv("RETRY_MAX_BACKOFF"))
+	if err != nil || retryMaxBackoff < retryBaseBackoff {
+		slog.Warn("invalid RETRY_MAX_BACKOFF:", "default_value", max(defaultRetryMaxBackoff, retryBaseBackoff))
+		retryMaxBackoff = max(defaultRetryMaxBackoff, retryBaseBackoff)
+	}
+
+	retryJitter, err := parseFloat(os.Getenv("RETRY_JITTER"))
+	if err != nil || retryJitter < 0 || retryJitter > 1 {
+		slog.Warn("invalid RETRY_JITTER:", "default_value", defaultRetryJitter)
+		retryJitter = defaultRetryJitter
+	}
+
+	retryStatuses, err := parseIntList(os.Getenv("RETRY_STATUSES"))
+	if err != nil {
+		slog.Warn("invalid RETRY_STATUSES:", "default_value", defaultRetryStatuses)
+		retryStatuses = defaultRetryStatuses
+	}
+
+	breakerEnabled, err := parseBool(os.Getenv("BREAKER_ENABLED"))
+	if err != nil {
+		slog.Warn("invalid BREAKER_ENABLED:", "default_value", defaultBreakerEnabled)
+		breakerEnabled = defaultBreakerEnabled
+	}
+
+	breakerFailureRatio, err := parseFloat(os.Getenv("BREAKER_FAILURE_RATIO"))
+	if err != nil || breakerFailureRatio <= 0 || breakerFailureRatio > 1 {
+		slog.Warn("invalid BREAKER_FAILURE_RATIO:", "default_value", defaultBreakerFailureRatio)
+		breakerFailureRatio = defaultBreakerFailureRatio
+	}
+
+	breakerMinRequests, err := parseInt(os.Getenv("BREAKER_MIN_REQUESTS"))
+	if err != nil || breakerMinRequests < 1 {
+		slog.Warn("invalid BREAKER_MIN_REQUESTS:", "default_value", defaultBreakerMinRequests)
+		breakerMinRequests = defaultBreakerMinRequests
+	}
+
+	breakerWindow, err := parseDuration(os.Getenv("BREAKER_WINDOW"))
+	if err != nil {
+		slog.Warn("invalid BREAKER_WINDOW:", "default_value", defaultBreakerWindow)
+		breakerWindow = defaultBreakerWindow
+	}
+
+	breakerCoolDown, err := parseDuration(os.Getenv("BREAKER_COOLDOWN"))
+	if err != nil {
+		slog.Warn("invalid BREAKER_COOLDOWN:", "default_value", defaultBreakerCoolDown)
+		breakerCoolDown = defaultBreakerCoolDown
+	}
+
+	breakerHalfOpenRequests, err := parseInt(os.Getenv("BREAKER_HALF_OPEN_REQUESTS"))
+	if err != nil || breakerHalfOpenRequests < 1 {
+		slog.Warn("invalid BREAKER_HALF_OPEN_REQUESTS:", "default_value", defaultBreakerHalfOpenRequests)
+		breakerHalfOpenRequests = defaultBreakerHalfOpenRequests
+	}
+
+	rateLimitEnabled, err := parseBool(os.Getenv("RATE_LIMIT_ENABLED"))
+	if err != nil {
+		slog.Warn("invalid RATE_LIMIT_ENABLED:", "default_value", defaultRateLimitEnabled)
+		rateLimitEnabled = defaultRateLimitEnabled
+	}
+
+	rateLimitRPS, err := parseFloat(os.Getenv("RATE_LIMIT_RPS"))
+	if err != nil || rateLimitRPS <= 0 {
+		slog.Warn("invalid RATE_LIMIT_RPS:", "default_value", defaultRateLimitRPS)
+		rateLimitRPS = defaultRateLimitRPS
+	}
+
+	rateLimitBurst, err := parseInt(os.Getenv("RATE_LIMIT_BURST"))
+	if err != nil || rateLimitBurst < 1 {
+		slog.Warn("invalid RATE_LIMIT_BURST:", "default_value", defaultRateLimitBurst)
+		rateLimitBurst = defaultRateLimitBurst
+	}
+
+	rateLimitIdleTTL, err := parseDuration(os.Getenv("RATE_LIMIT_IDLE_TTL"))
+	if err != nil || rateLimitIdleTTL <= 0 {
+		slog.Warn("invalid RATE_LIMIT_IDLE_TTL:", "default_value", defaultRateLimitIdleTTL)
+		rateLimitIdleTTL = defaultRateLimitIdleTTL
+	}
+
+	rateLimitTrustedProxies, err := parsePrefixList(os.Getenv("RATE_LIMIT_TRUSTED_PROXIES"))
+	if err != nil {
+		slog.Warn("invalid RATE_LIMIT_TRUSTED_PROXIES - X-Forwarded-For ignored", "error", err.Error())
+		rateLimitTrustedProxies = nil
+	}
+
+	rateLimitAPIKeyHeader := strings.TrimSpace(os.Getenv("RATE_LIMIT_API_KEY_HEADER"))
+	if rateLimitAPIKeyHeader == "" {
+		slog.Warn("invalid RATE_LIMIT_API_KEY_HEADER:", "default_value", defaultRateLimitAPIKeyHeader)
+		rateLimitAPIKeyHeader = defaultRateLimitAPIKeyHeader
+	}
+
+	rateLimitAPIKeys := parseStringList(os.Getenv("RATE_LIMIT_API_KEYS"))
+
+	rateLimitMaxClients, err := parseInt(os.Getenv("RATE_LIMIT_MAX_CLIENTS"))
+	if err != nil || rateLimitMaxClients < 1 {
+		slog.Warn("invalid RATE_LIMIT_MAX_CLIENTS:", "default_value", defaultRateLimitMaxClients)
+		rateLimitMaxClients = defaultRateLimitMaxClients
+	}
+
+	concurrencyMode := strings.ToLower(strings.TrimSpace(os.Getenv("CONCURRENCY_LIMIT_MODE")))
+	if concurrencyMode != ConcurrencyModeOff && concurrencyMode != ConcurrencyModeFixed && concurrencyMode != ConcurrencyModeAdaptive {
+		slog.Warn("invalid CONCURRENCY_LIMIT_MODE:", "default_value", defaultConcurrencyMode)
+		concurrencyMode = defaultConcurrencyMode
+	}
+
+	concurrencyMax, err := parseInt(os.Getenv("CONCURRENCY_MAX"))
+	if err != nil || concurrencyMax < 1 {
+		slog.Warn("invalid CONCURRENCY_MAX:", "default_value", defaultConcurrencyMax)
+		concurrencyMax = defaultConcurrencyMax
+	}
+
+	concurrencyMin, err := parseInt(os.Getenv("CONCURRENCY_MIN"))
+	if err != nil || concurrencyMin < 1 || concurrencyMin > concurrencyMax {
+		slog.Warn("invalid CONCURRENCY_MIN:", "default_value", min(defaultConcurrencyMin, concurrencyMax))
+		concurrencyMin = min(defaultConcurrencyMin, concurrencyMax)
+	}
+
+	concurrencyLatencyTarget, err := parseDuration(os.Getenv("CONCURRENCY_LATENCY_TARGET"))
+	if err != nil || concurrencyLatencyTarget <= 0 {
+		slog.Warn("invalid CONCURRENCY_LATENCY_TARGET:", "default_value", defaultConcurrencyLatencyTarget)
+		concurrencyLatencyTarget = defaultConcurrencyLatencyTarget
+	}
+
+	concurrencyQueueSize, err := parseInt(os.Getenv("CONCURRENCY_QUEUE_SIZE"))
+	if err != nil || concurrencyQueueSize < 0 {
+		slog.Warn("invalid CONCURRENCY_QUEUE_SIZE:", "default_value", defaultConcurrencyQueueSize)
+		concurrencyQueueSize = defaultConcurrencyQueueSize
+	}
+
+	concurrencyQueueTimeout, err := parseDuration(os.Getenv("CONCURRENCY_QUEUE_TIMEOUT"))
+	if err != nil || concurrencyQueueTimeout <= 0 {
+		slog.Warn("invalid CONCURRENCY_QUEUE_TIMEOUT:", "default_value", defaultConcurrencyQueueTimeout)
+		concurrencyQueueTimeout = defaultConcurrencyQueueTimeout
+	}
+
 	cfg := AppConfig{
 		HTTP: HTTPConfig{
-			Addr:    fmt.Sprintf(":%s", appPort),
-			Timeout: httpTimeout,
+			Addr:         fmt.Sprintf(":%s", appPort),
+			AdminAddr:    adminAddr,
+			Timeout:      httpTimeout,
+			TimeoutMin:   httpTimeoutMin,
+			TimeoutMax:   httpTimeoutMax,
+			ZipcodeShare: zipcodeShare,
 		},
+		ZipcodeProvider: zipcodeProvider,
 		ViaCEP: ViaCEPConfig{
 			BaseURL:    strings.TrimSuffix(os.Getenv("VIACEP_URL"), "/"),
 			ReturnType: returnType,
 			Timeout:    viaCEPTimeout,
+			Transport:  loadTransport("VIACEP", httpTransport),
+		},
+		BrasilAPI: BrasilAPIConfig{
+			BaseURL:   strings.TrimSuffix(os.Getenv("BRASILAPI_URL"), "/"),
+			Timeout:   brasilAPITimeout,
+			Transport: loadTransport("BRASILAPI", httpTransport),
 		},
+		OpenCEP: OpenCEPConfig{
+			BaseURL:   strings.TrimSuffix(os.Getenv("OPENCEP_URL"), "/"),
+			Timeout:   openCEPTimeout,
+			Transport: loadTransport("OPENCEP", httpTransport),
+		},
+		WeatherProvider: weatherProvider,
 		Weather: WeatherAPIConfig{
-			BaseURL: strings.TrimSuffix(os.Getenv("WEATHER_URL"), "/"),
-			APIKey:  weatherAPIKey,
-			Timeout: weatherTimeout,
+			BaseURL:   strings.TrimSuffix(os.Getenv("WEATHER_URL"), "/"),
+			APIKey:    weatherAPIKey,
+			APIKeys:   weatherAPIKeys,
+			SoftLimit: weatherAPISoftLimit,
+			UsageFile: weatherAPIUsageFile,
+			Timeout:   weatherTimeout,
+			Transport: loadTransport("WEATHER", httpTransport),
+		},
+		OpenMeteo: OpenMeteoConfig{
+			GeocodingURL: strings.TrimSuffix(os.Getenv("OPENMETEO_GEOCODING_URL"), "/"),
+			ForecastURL:  strings.TrimSuffix(os.Getenv("OPENMETEO_URL"), "/"),
+			Timeout:      openMeteoTimeout,
+			Transport:    loadTransport("OPENMETEO", httpTransport),
+		},
+		ZipcodeFailover: ZipcodeFailoverConfig{
+			FallbackProviders:     zipcodeFallbackProviders,
+			NotFoundAuthoritative: zipcodeNotFoundAuthoritative,
+			CoolDown:              zipcodeFailoverCoolDown,
+		},
+		WeatherFailover: WeatherFailoverConfig{
+			FallbackProviders: weatherFallbackProviders,
+			HedgePercentile:   weatherHedgePercentile,
+			HedgeMinDelay:     weatherHedgeMinDelay,
+			CoolDown:          weatherFailoverCoolDown,
+		},
+		WeatherEnsemble: WeatherEnsembleConfig{
+			Providers:  weatherEnsembleProviders,
+			Strategy:   weatherEnsembleStrategy,
+			ThresholdC: weatherEnsembleThresholdC,
+		},
+		WeatherShadow: WeatherShadowConfig{
+			Provider:       shadowProvider,
+			SampleRate:     shadowSampleRate,
+			MaxInFlight:    shadowMaxInFlight,
+			Timeout:        shadowTimeout,
+			TempThresholdC: shadowTempThresholdC,
+		},
+		ZipcodeCache: ZipcodeCacheConfig{
+			Size:        zipcodeCacheSize,
+			TTL:         zipcodeCacheTTL,
+			NegativeTTL: zipcodeCacheNegativeTTL,
+		},
+		WeatherCache: WeatherCacheConfig{
+			Size:     weatherCacheSize,
+			TTL:      weatherCacheTTL,
+			StaleTTL: weatherCacheStaleTTL,
+		},
+		Retry: RetryConfig{
+			MaxAttempts:       retryMaxAttempts,
+			BaseBackoff:       retryBaseBackoff,
+			MaxBackoff:        retryMaxBackoff,
+			Jitter:            retryJitter,
+			RetryableStatuses: retryStatuses,
+		},
+		Breaker: BreakerConfig{
+			Enabled:          breakerEnabled,
+			FailureRatio:     breakerFailureRatio,
+			MinRequests:      breakerMinRequests,
+			Window:           breakerWindow,
+			CoolDown:         breakerCoolDown,
+			HalfOpenRequests: breakerHalfOpenRequests,
+		},
+		RateLimit: RateLimitConfig{
+			Enabled:           rateLimitEnabled,
+			RequestsPerSecond: rateLimitRPS,
+			Burst:             rateLimitBurst,
+			IdleTTL:           rateLimitIdleTTL,
+			TrustedProxies:    rateLimitTrustedProxies,
+			APIKeyHeader:      rateLimitAPIKeyHeader,
+			APIKeys:           rateLimitAPIKeys,
+			MaxClients:        rateLimitMaxClients,
+		},
+		Concurrency: ConcurrencyConfig{
+			Mode:          concurrencyMode,
+			MaxConcurrent: concurrencyMax,
+			MinConcurrent: concurrencyMin,
+			LatencyTarget: concurrencyLatencyTarget,
+			QueueSize:     concurrencyQueueSize,
+			QueueTimeout:  concurrencyQueueTimeout,
 		},
 	}
 
 	return cfg, nil
 }
 
+func loadTransport(prefix string, base TransportConfig) TransportConfig {
+	cfg := base
+
+	overrideInt := func(name string, target *int, minValue int) {
+		raw := os.Getenv(prefix + name)
+		if raw == "" {
+			return
+		}
+
+		v, err := parseInt(raw)
+		if err != nil || v < minValue {
+			slog.Warn("invalid "+prefix+name+":", "default_value", *target)
+			return
+		}
+		*target = v
+	}
+
+	overrideDuration := func(name string, target *time.Duration) {
+		raw := os.Getenv(prefix + name)
+		if raw == "" {
+			return
+		}
+
+		v, err := parseDuration(raw)
+		if err != nil || v < 0 {
+			slog.Warn("invalid "+prefix+name+":", "default_value", *target)
+			return
+		}
+		*target = v
+	}
+
+	overrideInt("_MAX_IDLE_CONNS", &cfg.MaxIdleConns, 1)
+	overrideInt("_MAX_IDLE_CONNS_PER_HOST", &cfg.MaxIdleConnsPerHost, 1)
+	overrideInt("_MAX_CONNS_PER_HOST", &cfg.MaxConnsPerHost, 0)
+	overrideDuration("_IDLE_CONN_TIMEOUT", &cfg.IdleConnTimeout)
+	overrideDuration("_KEEP_ALIVE", &cfg.KeepAlive)
+	overrideDuration("_DIAL_TIMEOUT", &cfg.DialTimeout)
+	overrideDuration("_TLS_HANDSHAKE_TIMEOUT", &cfg.TLSHandshakeTimeout)
+	overrideDuration("_DNS_CACHE_TTL", &cfg.DNSCacheTTL)
+
+	if v := strings.TrimSpace(os.Getenv(prefix + "_PROXY_URL")); v != "" {
+		cfg.ProxyURL = v
+	}
+
+	if v := strings.TrimSpace(os.Getenv(prefix + "_CA_BUNDLE")); v != "" {
+		cfg.CABundle = v
+	}
+
+	return cfg
+}
+
 func parseDuration(raw string) (time.Duration, error) {
 	if raw == "" {
 		return 0, errors.New("empty duration string")
@@ -110,3 +836,96 @@ func parseDuration(raw string) (time.Duration, error) {
 
 	return time.ParseDuration(raw)
 }
+
+func parseInt(raw string) (int, error) {
+	if raw == "" {
+		return 0, errors.New("empty integer string")
+	}
+
+	return strconv.Atoi(strings.TrimSpace(raw))
+}
+
+func parseFloat(raw string) (float64, error) {
+	if raw == "" {
+		return 0, errors.New("empty float string")
+	}
+
+	return strconv.ParseFloat(strings.TrimSpace(raw), 64)
+}
+
+func parseIntList(raw string) ([]int, error) {
+	if strings.TrimSpace(raw) == "" {
+		return nil, errors.New("empty integer list")
+	}
+
+	parts := strings.Split(raw, ",")
+	values := make([]int, 0, len(parts))
+	for _, part := range parts {
+		v, err := parseInt(part)
+		if err != nil {
+			return nil, fmt.Errorf("invalid list item %q: %w", part, err)
+		}
+		values = append(values, v)
+	}
+
+	return values, nil
+}
+
+func parseStringList(raw string) []string {
+	var values []string
+	for _, part := range strings.Split(raw, ",") {
+		if v := strings.TrimSpace(part); v != "" {
+			values = append(values, v)
+		}
+	}
+
+	return values
+}
+
+func parseProviderList(raw string, allowed map[string]bool) ([]string, error) {
+	if strings.TrimSpace(raw) == "" {
+		return nil, nil
+	}
+
+	parts := strings.Split(raw, ",")
+	providers := make([]string, 0, len(parts))
+	for _, part := range parts {
+		name := strings.ToLower(strings.TrimSpace(part))
+		if !allowed[name] {
+			return nil, fmt.Errorf("unknown provider %q", part)
+		}
+		providers = append(providers, name)
+	}
+
+	return providers, nil
+}
+
+func parsePrefixList(raw string) ([]netip.Prefix, error) {
+	var prefixes []netip.Prefix
+	for _, part := range parseStringList(raw) {
+		if !strings.Contains(part, "/") {
+			addr, err := netip.ParseAddr(part)
+			if err != nil {
+				return nil, err
+			}
+			prefixes = append(prefixes, netip.PrefixFrom(addr, addr.BitLen()))
+			continue
+		}
+
+		prefix, err := netip.ParsePrefix(part)
+		if err != nil {
+			return nil, err
+		}
+		prefixes = append(prefixes, prefix.Masked())
+	}
+
+	return prefixes, nil
+}
+
+func parseBool(raw string) (bool, error) {
+	if raw == "" {
+		return false, errors.New("empty boolean string")
+	}
+
+	return strconv.ParseBool(strings.TrimSpace(raw))
+}
@@ -1,6 +1,7 @@
 package config_test
 
 import (
+	"net/netip"
 	"os"
 	"path/filepath"
 	"testing"
@@ -15,13 +16,84 @@ import (
 func TestLoad(t *testing.T) {
 	resetEnv := func(t *testing.T) {
 		t.Setenv("PORT", "")
+		t.Setenv("ADMIN_PORT", "")
+		t.Setenv("ADMIN_HOST", "")
 		t.Setenv("HTTP_TIMEOUT", "")
+		t.Setenv("HTTP_TIMEOUT_MIN", "")
+		t.Setenv("HTTP_TIMEOUT_MAX", "")
+		t.Setenv("ZIPCODE_BUDGET_SHARE", "")
 		t.Setenv("VIACEP_URL", "")
 		t.Setenv("VIACEP_TIMEOUT", "")
 		t.Setenv("VIACEP_RETURN_TYPE", "")
+		t.Setenv("ZIPCODE_PROVIDER", "")
+		t.Setenv("ZIPCODE_FALLBACK_PROVIDERS", "")
+		t.Setenv("ZIPCODE_NOT_FOUND_AUTHORITATIVE", "")
+		t.Setenv("ZIPCODE_FAILOVER_COOLDOWN", "")
+		t.Setenv("BRASILAPI_URL", "")
+		t.Setenv("BRASILAPI_TIMEOUT", "")
+		t.Setenv("OPENCEP_URL", "")
+		t.Setenv("OPENCEP_TIMEOUT", "")
 		t.Setenv("WEATHER_URL", "")
 		t.Setenv("WEATHER_API_KEY", "")
 		t.Setenv("WEATHER_TIMEOUT", "")
+		t.Setenv("WEATHER_API_KEYS", "")
+		t.Setenv("WEATHER_API_KEY_SOFT_LIMIT", "")
+		t.Setenv("WEATHER_API_USAGE_FILE", "")
+		t.Setenv("WEATHER_PROVIDER", "")
+		t.Setenv("WEATHER_FALLBACK_PROVIDERS", "")
+		t.Setenv("WEATHER_HEDGE_PERCENTILE", "")
+		t.Setenv("WEATHER_HEDGE_MIN_DELAY", "")
+		t.Setenv("WEATHER_FAILOVER_COOLDOWN", "")
+		t.Setenv("WEATHER_ENSEMBLE_PROVIDERS", "")
+		t.Setenv("WEATHER_ENSEMBLE_STRATEGY", "")
+		t.Setenv("WEATHER_ENSEMBLE_THRESHOLD_C", "")
+		t.Setenv("SHADOW_WEATHER_PROVIDER", "")
+		t.Setenv("SHADOW_SAMPLE_RATE", "")
+		t.Setenv("SHADOW_MAX_IN_FLIGHT", "")
+		t.Setenv("SHADOW_TIMEOUT", "")
+		t.Setenv("SHADOW_TEMP_THRESHOLD_C", "")
+		t.Setenv("OPENMETEO_GEOCODING_URL", "")
+		t.Setenv("OPENMETEO_URL", "")
+		t.Setenv("OPENMETEO_TIMEOUT", "")
+		t.Setenv("ZIPCODE_CACHE_SIZE", "")
+		t.Setenv("ZIPCODE_CACHE_TTL", "")
+		t.Setenv("ZIPCODE_CACHE_NEGATIVE_TTL", "")
+		t.Setenv("WEATHER_CACHE_SIZE", "")
+		t.Setenv("WEATHER_CACHE_TTL", "")
+		t.Setenv("WEATHER_CACHE_STALE_TTL", "")
+		t.Setenv("RETRY_MAX_ATTEMPTS", "")
+		t.Setenv("RETRY_BASE_BACKOFF", "")
+		t.Setenv("RETRY_MAX_BACKOFF", "")
+		t.Setenv("RETRY_JITTER", "")
+		t.Setenv("RETRY_STATUSES", "")
+		t.Setenv("BREAKER_ENABLED", "")
+		t.Setenv("BREAKER_FAILURE_RATIO", "")
+		t.Setenv("BREAKER_MIN_REQUESTS", "")
+		t.Setenv("BREAKER_WINDOW", "")
+		t.Setenv("BREAKER_COOLDOWN", "")
+		t.Setenv("BREAKER_HALF_OPEN_REQUESTS", "")
+		for _, prefix := range []string{"HTTP", "VIACEP", "BRASILAPI", "OPENCEP", "WEATHER", "OPENMETEO"} {
+			for _, name := range []string{
+				"_MAX_IDLE_CONNS", "_MAX_IDLE_CONNS_PER_HOST", "_MAX_CONNS_PER_HOST", "_IDLE_CONN_TIMEOUT", "_KEEP_ALIVE",
+				"_DIAL_TIMEOUT", "_TLS_HANDSHAKE_TIMEOUT", "_PROXY_URL", "_CA_BUNDLE", "_DNS_CACHE_TTL",
+			} {
+				t.Setenv(prefix+name, "")
+			}
+		}
+		t.Setenv("RATE_LIMIT_ENABLED", "")
+		t.Setenv("RATE_LIMIT_RPS", "")
+		t.Setenv("RATE_LIMIT_BURST", "")
+		t.Setenv("RATE_LIMIT_IDLE_TTL", "")
+		t.Setenv("RATE_LIMIT_TRUSTED_PROXIES", "")
+		t.Setenv("RATE_LIMIT_API_KEY_HEADER", "")
+		t.Setenv("RATE_LIMIT_API_KEYS", "")
+		t.Setenv("RATE_LIMIT_MAX_CLIENTS", "")
+		t.Setenv("CONCURRENCY_LIMIT_MODE", "")
+		t.Setenv("CONCURRENCY_MAX", "")
+		t.Setenv("CONCURRENCY_MIN", "")
+		t.Setenv("CONCURRENCY_LATENCY_TARGET", "")
+		t.Setenv("CONCURRENCY_QUEUE_SIZE", "")
+		t.Setenv("CONCURRENCY_QUEUE_TIMEOUT", "")
 	}
 
 	tests := []struct {
@@ -32,6 +104,7 @@ func TestLoad(t *testing.T) {
 		{
 			name: "success",
 			envContent: `PORT=9090
+ADMIN_PORT=9091
 HTTP_TIMEOUT=3s
 VIACEP_URL=https://viacep.com.br/ws/
 VIACEP_RETURN_TYPE=json
@@ -42,6 +115,7 @@ WEATHER_TIMEOUT=6s
 `,
 			assertions: func(t *testing.T, cfg config.AppConfig) {
 				assert.Equal(t, ":9090", cfg.HTTP.Addr)
+				assert.Equal(t, "127.0.0.1:9091", cfg.HTTP.AdminAddr)
 				assert.Equal(t, 3*time.Second, cfg.HTTP.Timeout)
 
 				assert.Equal(t, "https://viacep.com.br/ws", cfg.ViaCEP.BaseURL)
@@ -65,6 +139,7 @@ WEATHER_TIMEOUT=5s
 `,
 			assertions: func(t *testing.T, cfg config.AppConfig) {
 				assert.Equal(t, ":8080", cfg.HTTP.Addr)
+				assert.Empty(t, cfg.HTTP.AdminAddr)
 				assert.Equal(t, 5*time.Second, cfg.HTTP.Timeout)
 
 				assert.Equal(t, "https://viacep.com.br/ws", cfg.ViaCEP.BaseURL)
@@ -93,19 +168,466 @@ WEATHER_TIMEOUT=5s
 				assert.Equal(t, 5*time.Second, cfg.Weather.Timeout)
 			},
 		},
+		{
+			name: "admin listener host",
+			envContent: `ADMIN_PORT=9091
+ADMIN_HOST=::1
+`,
+			assertions: func(t *testing.T, cfg config.AppConfig) {
+				assert.Equal(t, "[::1]:9091", cfg.HTTP.AdminAddr)
+			},
+		},
+		{
+			name: "zipcode provider settings",
+			envContent: `ZIPCODE_PROVIDER=BrasilAPI
+BRASILAPI_URL=https://brasilapi.com.br/api/cep/v2/
+BRASILAPI_TIMEOUT=2s
+OPENCEP_URL=https://opencep.com/v1/
+OPENCEP_TIMEOUT=3s
+`,
+			assertions: func(t *testing.T, cfg config.AppConfig) {
+				assert.Equal(t, config.ZipcodeProviderBrasilAPI, cfg.ZipcodeProvider)
+				assert.Equal(t, "https://brasilapi.com.br/api/cep/v2", cfg.BrasilAPI.BaseURL)
+				assert.Equal(t, 2*time.Second, cfg.BrasilAPI.Timeout)
+				assert.Equal(t, "https://opencep.com/v1", cfg.OpenCEP.BaseURL)
+				assert.Equal(t, 3*time.Second, cfg.OpenCEP.Timeout)
+			},
+		},
+		{
+			name: "invalid zipcode provider settings",
+			envContent: `ZIPCODE_PROVIDER=correios
+BRASILAPI_TIMEOUT=soon
+OPENCEP_TIMEOUT=later
+`,
+			assertions: func(t *testing.T, cfg config.AppConfig) {
+				assert.Equal(t, config.ZipcodeProviderViaCEP, cfg.ZipcodeProvider)
+				assert.Equal(t, 5*time.Second, cfg.BrasilAPI.Timeout)
+				assert.Equal(t, 5*time.Second, cfg.OpenCEP.Timeout)
+			},
+		},
+		{
+			name: "zipcode failover settings",
+			envContent: `ZIPCODE_FALLBACK_PROVIDERS=brasilapi, OpenCEP
+ZIPCODE_NOT_FOUND_AUTHORITATIVE=false
+ZIPCODE_FAILOVER_COOLDOWN=1m
+`,
+			assertions: func(t *testing.T, cfg config.AppConfig) {
+				assert.Equal(t, []string{"brasilapi", "opencep"}, cfg.ZipcodeFailover.FallbackProviders)
+				assert.False(t, cfg.ZipcodeFailover.NotFoundAuthoritative)
+				assert.Equal(t, time.Minute, cfg.ZipcodeFailover.CoolDown)
+			},
+		},
+		{
+			name: "invalid zipcode failover settings",
+			envContent: `ZIPCODE_FALLBACK_PROVIDERS=brasilapi,correios
+ZIPCODE_NOT_FOUND_AUTHORITATIVE=sometimes
+ZIPCODE_FAILOVER_COOLDOWN=never
+`,
+			assertions: func(t *testing.T, cfg config.AppConfig) {
+				assert.Empty(t, cfg.ZipcodeFailover.FallbackProviders)
+				assert.True(t, cfg.ZipcodeFailover.NotFoundAuthoritative)
+				assert.Equal(t, 30*time.Second, cfg.ZipcodeFailover.CoolDown)
+			},
+		},
+		{
+			name: "weather provider settings",
+			envContent: `WEATHER_PROVIDER=OpenMeteo
+OPENMETEO_GEOCODING_URL=https://geocoding-api.open-meteo.com/v1/
+OPENMETEO_URL=https://api.open-meteo.com/v1/
+OPENMETEO_TIMEOUT=2s
+`,
+			assertions: func(t *testing.T, cfg config.AppConfig) {
+				assert.Equal(t, config.WeatherProviderOpenMeteo, cfg.WeatherProvider)
+				assert.Equal(t, "https://geocoding-api.open-meteo.com/v1", cfg.OpenMeteo.GeocodingURL)
+				assert.Equal(t, "https://api.open-meteo.com/v1", cfg.OpenMeteo.ForecastURL)
+				assert.Equal(t, 2*time.Second, cfg.OpenMeteo.Timeout)
+			},
+		},
+		{
+			name: "invalid weather provider settings",
+			envContent: `WEATHER_PROVIDER=inmet
+OPENMETEO_TIMEOUT=fast
+`,
+			assertions: func(t *testing.T, cfg config.AppConfig) {
+				assert.Equal(t, config.WeatherProviderWeatherAPI, cfg.WeatherProvider)
+				assert.Equal(t, 5*time.Second, cfg.OpenMeteo.Timeout)
+			},
+		},
+		{
+			name: "weather failover settings",
+			envContent: `WEATHER_FALLBACK_PROVIDERS=openmeteo
+WEATHER_HEDGE_PERCENTILE=0.9
+WEATHER_HEDGE_MIN_DELAY=250ms
+WEATHER_FAILOVER_COOLDOWN=1m
+`,
+			assertions: func(t *testing.T, cfg config.AppConfig) {
+				assert.Equal(t, []string{"openmeteo"}, cfg.WeatherFailover.FallbackProviders)
+				assert.Equal(t, 0.9, cfg.WeatherFailover.HedgePercentile)
+				assert.Equal(t, 250*time.Millisecond, cfg.WeatherFailover.HedgeMinDelay)
+				assert.Equal(t, time.Minute, cfg.WeatherFailover.CoolDown)
+			},
+		},
+		{
+			name: "invalid weather failover settings",
+			envContent: `WEATHER_FALLBACK_PROVIDERS=openweather
+WEATHER_HEDGE_PERCENTILE=95
+WEATHER_HEDGE_MIN_DELAY=soon
+WEATHER_FAILOVER_COOLDOWN=never
+`,
+			assertions: func(t *testing.T, cfg config.AppConfig) {
+				assert.Empty(t, cfg.WeatherFailover.FallbackProviders)
+				assert.Equal(t, 0.95, cfg.WeatherFailover.HedgePercentile)
+				assert.Equal(t, 100*time.Millisecond, cfg.WeatherFailover.HedgeMinDelay)
+				assert.Equal(t, 30*time.Second, cfg.WeatherFailover.CoolDown)
+			},
+		},
+		{
+			name: "weather ensemble settings",
+			envContent: `WEATHER_ENSEMBLE_PROVIDERS=weatherapi,openmeteo
+WEATHER_ENSEMBLE_STRATEGY=Mean
+WEATHER_ENSEMBLE_THRESHOLD_C=1.5
+`,
+			assertions: func(t *testing.T, cfg config.AppConfig) {
+				assert.Equal(t, []string{"weatherapi", "openmeteo"}, cfg.WeatherEnsemble.Providers)
+				assert.Equal(t, "mean", cfg.WeatherEnsemble.Strategy)
+				assert.Equal(t, 1.5, cfg.WeatherEnsemble.ThresholdC)
+			},
+		},
+		{
+			name: "invalid weather ensemble settings",
+			envContent: `WEATHER_ENSEMBLE_PROVIDERS=weatherapi,climatempo
+WEATHER_ENSEMBLE_STRATEGY=mode
+WEATHER_ENSEMBLE_THRESHOLD_C=-1
+`,
+			assertions: func(t *testing.T, cfg config.AppConfig) {
+				assert.Empty(t, cfg.WeatherEnsemble.Providers)
+				assert.Equal(t, "median", cfg.WeatherEnsemble.Strategy)
+				assert.Equal(t, 2.0, cfg.WeatherEnsemble.ThresholdC)
+			},
+		},
+		{
+			name: "shadow traffic settings",
+			envContent: `SHADOW_WEATHER_PROVIDER=openmeteo
+SHADOW_SAMPLE_RATE=0.25
+SHADOW_MAX_IN_FLIGHT=4
+SHADOW_TIMEOUT=2s
+SHADOW_TEMP_THRESHOLD_C=0.5
+`,
+			assertions: func(t *testing.T, cfg config.AppConfig) {
+				assert.Equal(t, "openmeteo", cfg.WeatherShadow.Provider)
+				assert.Equal(t, 0.25, cfg.WeatherShadow.SampleRate)
+				assert.Equal(t, 4, cfg.WeatherShadow.MaxInFlight)
+				assert.Equal(t, 2*time.Second, cfg.WeatherShadow.Timeout)
+				assert.Equal(t, 0.5, cfg.WeatherShadow.TempThresholdC)
+			},
+		},
+		{
+			name: "invalid shadow traffic settings",
+			envContent: `SHADOW_WEATHER_PROVIDER=accuweather
+SHADOW_SAMPLE_RATE=10
+SHADOW_MAX_IN_FLIGHT=0
+SHADOW_TIMEOUT=eventually
+SHADOW_TEMP_THRESHOLD_C=-2
+`,
+			assertions: func(t *testing.T, cfg config.AppConfig) {
+				assert.Equal(t, "", cfg.WeatherShadow.Provider)
+				assert.Equal(t, 0.1, cfg.WeatherShadow.SampleRate)
+				assert.Equal(t, 10, cfg.WeatherShadow.MaxInFlight)
+				assert.Equal(t, 5*time.Second, cfg.WeatherShadow.Timeout)
+				assert.Equal(t, 1.0, cfg.WeatherShadow.TempThresholdC)
+			},
+		},
+		{
+			name: "weather api key pool settings",
+			envContent: `WEATHER_API_KEY=single
+WEATHER_API_KEYS=key-a, key-b,,key-c
+WEATHER_API_KEY_SOFT_LIMIT=900000
+WEATHER_API_USAGE_FILE=/var/lib/weather/usage.json
+`,
+			assertions: func(t *testing.T, cfg config.AppConfig) {
+				assert.Equal(t, []string{"key-a", "key-b", "key-c"}, cfg.Weather.APIKeys)
+				assert.Equal(t, 900000, cfg.Weather.SoftLimit)
+				assert.Equal(t, "/var/lib/weather/usage.json", cfg.Weather.UsageFile)
+			},
+		},
+		{
+			name: "invalid weather api key pool settings",
+			envContent: `WEATHER_API_KEY=single
+WEATHER_API_KEY_SOFT_LIMIT=lots
+`,
+			assertions: func(t *testing.T, cfg config.AppConfig) {
+				assert.Equal(t, []string{"single"}, cfg.Weather.APIKeys)
+				assert.Equal(t, 0, cfg.Weather.SoftLimit)
+				assert.Equal(t, "weatherapi_usage.json", cfg.Weather.UsageFile)
+			},
+		},
+		{
+			name: "zipcode cache settings",
+			envContent: `ZIPCODE_CACHE_SIZE=500
+ZIPCODE_CACHE_TTL=24h
+ZIPCODE_CACHE_NEGATIVE_TTL=10m
+`,
+			assertions: func(t *testing.T, cfg config.AppConfig) {
+				assert.Equal(t, 500, cfg.ZipcodeCache.Size)
+				assert.Equal(t, 24*time.Hour, cfg.ZipcodeCache.TTL)
+				assert.Equal(t, 10*time.Minute, cfg.ZipcodeCache.NegativeTTL)
+			},
+		},
+		{
+			name: "invalid zipcode cache settings",
+			envContent: `ZIPCODE_CACHE_SIZE=-1
+ZIPCODE_CACHE_TTL=forever
+ZIPCODE_CACHE_NEGATIVE_TTL=abc
+`,
+			assertions: func(t *testing.T, cfg config.AppConfig) {
+				assert.Equal(t, 10000, cfg.ZipcodeCache.Size)
+				assert.Equal(t, 30*24*time.Hour, cfg.ZipcodeCache.TTL)
+				assert.Equal(t, time.Hour, cfg.ZipcodeCache.NegativeTTL)
+			},
+		},
+		{
+			name: "weather cache settings",
+			envContent: `WEATHER_CACHE_SIZE=0
+WEATHER_CACHE_TTL=5m
+WEATHER_CACHE_STALE_TTL=2h
+`,
+			assertions: func(t *testing.T, cfg config.AppConfig) {
+				assert.Equal(t, 0, cfg.WeatherCache.Size)
+				assert.Equal(t, 5*time.Minute, cfg.WeatherCache.TTL)
+				assert.Equal(t, 2*time.Hour, cfg.WeatherCache.StaleTTL)
+			},
+		},
+		{
+			name: "retry settings",
+			envContent: `RETRY_MAX_ATTEMPTS=5
+RETRY_BASE_BACKOFF=50ms
+RETRY_MAX_BACKOFF=1s
+RETRY_JITTER=0.5
+RETRY_STATUSES=502, 503
+`,
+			assertions: func(t *testing.T, cfg config.AppConfig) {
+				assert.Equal(t, 5, cfg.Retry.MaxAttempts)
+				assert.Equal(t, 50*time.Millisecond, cfg.Retry.BaseBackoff)
+				assert.Equal(t, time.Second, cfg.Retry.MaxBackoff)
+				assert.Equal(t, 0.5, cfg.Retry.Jitter)
+				assert.Equal(t, []int{502, 503}, cfg.Retry.RetryableStatuses)
+			},
+		},
+		{
+			name: "invalid retry settings",
+			envContent: `RETRY_MAX_ATTEMPTS=0
+RETRY_BASE_BACKOFF=500ms
+RETRY_MAX_BACKOFF=100ms
+RETRY_JITTER=2
+RETRY_STATUSES=502,abc
+`,
+			assertions: func(t *testing.T, cfg config.AppConfig) {
+				assert.Equal(t, 3, cfg.Retry.MaxAttempts)
+				assert.Equal(t, 500*time.Millisecond, cfg.Retry.BaseBackoff)
+				assert.Equal(t, 2*time.Second, cfg.Retry.MaxBackoff)
+				assert.Equal(t, 0.2, cfg.Retry.Jitter)
+				assert.Equal(t, []int{429, 500, 502, 503, 504}, cfg.Retry.RetryableStatuses)
+			},
+		},
+		{
+			name: "circuit breaker settings",
+			envContent: `BREAKER_ENABLED=false
+BREAKER_FAILURE_RATIO=0.75
+BREAKER_MIN_REQUESTS=20
+BREAKER_WINDOW=1m
+BREAKER_COOLDOWN=30s
+BREAKER_HALF_OPEN_REQUESTS=3
+`,
+			assertions: func(t *testing.T, cfg config.AppConfig) {
+				assert.False(t, cfg.Breaker.Enabled)
+				assert.Equal(t, 0.75, cfg.Breaker.FailureRatio)
+				assert.Equal(t, 20, cfg.Breaker.MinRequests)
+				assert.Equal(t, time.Minute, cfg.Breaker.Window)
+				assert.Equal(t, 30*time.Second, cfg.Breaker.CoolDown)
+				assert.Equal(t, 3, cfg.Breaker.HalfOpenRequests)
+			},
+		},
+		{
+			name: "invalid circuit breaker settings",
+			envContent: `BREAKER_ENABLED=maybe
+BREAKER_FAILURE_RATIO=1.5
+BREAKER_MIN_REQUESTS=0
+`,
+			assertions: func(t *testing.T, cfg config.AppConfig) {
+				assert.True(t, cfg.Breaker.Enabled)
+				assert.Equal(t, 0.5, cfg.Breaker.FailureRatio)
+				assert.Equal(t, 10, cfg.Breaker.MinRequests)
+				assert.Equal(t, 30*time.Second, cfg.Breaker.Window)
+				assert.Equal(t, 15*time.Second, cfg.Breaker.CoolDown)
+				assert.Equal(t, 1, cfg.Breaker.HalfOpenRequests)
+			},
+		},
+		{
+			name: "rate limit settings",
+			envContent: `RATE_LIMIT_ENABLED=true
+RATE_LIMIT_RPS=2.5
+RATE_LIMIT_BURST=5
+RATE_LIMIT_IDLE_TTL=1m
+RATE_LIMIT_TRUSTED_PROXIES=10.0.0.0/8, 192.168.1.10
+RATE_LIMIT_API_KEY_HEADER=X-Client-Key
+RATE_LIMIT_API_KEYS=key-a, key-b
+RATE_LIMIT_MAX_CLIENTS=500
+`,
+			assertions: func(t *testing.T, cfg config.AppConfig) {
+				assert.True(t, cfg.RateLimit.Enabled)
+				assert.Equal(t, 2.5, cfg.RateLimit.RequestsPerSecond)
+				assert.Equal(t, 5, cfg.RateLimit.Burst)
+				assert.Equal(t, time.Minute, cfg.RateLimit.IdleTTL)
+				assert.Equal(t, []netip.Prefix{
+					netip.MustParsePrefix("10.0.0.0/8"),
+					netip.MustParsePrefix("192.168.1.10/32"),
+				}, cfg.RateLimit.TrustedProxies)
+				assert.Equal(t, "X-Client-Key", cfg.RateLimit.APIKeyHeader)
+				assert.Equal(t, []string{"key-a", "key-b"}, cfg.RateLimit.APIKeys)
+				assert.Equal(t, 500, cfg.RateLimit.MaxClients)
+			},
+		},
+		{
+			name: "invalid rate limit settings",
+			envContent: `RATE_LIMIT_ENABLED=maybe
+RATE_LIMIT_RPS=0
+RATE_LIMIT_BURST=-1
+RATE_LIMIT_TRUSTED_PROXIES=10.0.0.0/8,proxy.local
+RATE_LIMIT_MAX_CLIENTS=0
+`,
+			assertions: func(t *testing.T, cfg config.AppConfig) {
+				assert.False(t, cfg.RateLimit.Enabled)
+				assert.Equal(t, 5.0, cfg.RateLimit.RequestsPerSecond)
+				assert.Equal(t, 10, cfg.RateLimit.Burst)
+				assert.Equal(t, 10*time.Minute, cfg.RateLimit.IdleTTL)
+				assert.Empty(t, cfg.RateLimit.TrustedProxies)
+				assert.Equal(t, "X-API-Key", cfg.RateLimit.APIKeyHeader)
+				assert.Empty(t, cfg.RateLimit.APIKeys)
+				assert.Equal(t, 10000, cfg.RateLimit.MaxClients)
+			},
+		},
+		{
+			name: "concurrency settings",
+			envContent: `CONCURRENCY_LIMIT_MODE=Adaptive
+CONCURRENCY_MAX=40
+CONCURRENCY_MIN=4
+CONCURRENCY_LATENCY_TARGET=500ms
+CONCURRENCY_QUEUE_SIZE=0
+CONCURRENCY_QUEUE_TIMEOUT=50ms
+`,
+			assertions: func(t *testing.T, cfg config.AppConfig) {
+				assert.Equal(t, "adaptive", cfg.Concurrency.Mode)
+				assert.Equal(t, 40, cfg.Concurrency.MaxConcurrent)
+				assert.Equal(t, 4, cfg.Concurrency.MinConcurrent)
+				assert.Equal(t, 500*time.Millisecond, cfg.Concurrency.LatencyTarget)
+				assert.Equal(t, 0, cfg.Concurrency.QueueSize)
+				assert.Equal(t, 50*time.Millisecond, cfg.Concurrency.QueueTimeout)
+			},
+		},
+		{
+			name: "invalid concurrency settings",
+			envContent: `CONCURRENCY_LIMIT_MODE=unbounded
+CONCURRENCY_MAX=5
+CONCURRENCY_MIN=8
+CONCURRENCY_QUEUE_SIZE=-1
+`,
+			assertions: func(t *testing.T, cfg config.AppConfig) {
+				assert.Equal(t, "fixed", cfg.Concurrency.Mode)
+				assert.Equal(t, 5, cfg.Concurrency.MaxConcurrent)
+				assert.Equal(t, 5, cfg.Concurrency.MinConcurrent)
+				assert.Equal(t, time.Second, cfg.Concurrency.LatencyTarget)
+				assert.Equal(t, 50, cfg.Concurrency.QueueSize)
+				assert.Equal(t, 200*time.Millisecond, cfg.Concurrency.QueueTimeout)
+			},
+		},
+		{
+			name: "request budget settings",
+			envContent: `HTTP_TIMEOUT=8s
+HTTP_TIMEOUT_MIN=1s
+HTTP_TIMEOUT_MAX=20s
+ZIPCODE_BUDGET_SHARE=0.3
+`,
+			assertions: func(t *testing.T, cfg config.AppConfig) {
+				assert.Equal(t, 8*time.Second, cfg.HTTP.Timeout)
+				assert.Equal(t, time.Second, cfg.HTTP.TimeoutMin)
+				assert.Equal(t, 20*time.Second, cfg.HTTP.TimeoutMax)
+				assert.Equal(t, 0.3, cfg.HTTP.ZipcodeShare)
+			},
+		},
+		{
+			name: "invalid request budget settings",
+			envContent: `HTTP_TIMEOUT_MIN=0s
+HTTP_TIMEOUT_MAX=50ms
+ZIPCODE_BUDGET_SHARE=1
+`,
+			assertions: func(t *testing.T, cfg config.AppConfig) {
+				assert.Equal(t, 100*time.Millisecond, cfg.HTTP.TimeoutMin)
+				assert.Equal(t, 30*time.Second, cfg.HTTP.TimeoutMax)
+				assert.Equal(t, 0.4, cfg.HTTP.ZipcodeShare)
+			},
+		},
+		{
+			name: "transport settings",
+			envContent: `HTTP_MAX_IDLE_CONNS=50
+HTTP_MAX_IDLE_CONNS_PER_HOST=5
+HTTP_IDLE_CONN_TIMEOUT=30s
+HTTP_PROXY_URL=http://proxy.internal:3128
+HTTP_CA_BUNDLE=/etc/ssl/custom.pem
+HTTP_DNS_CACHE_TTL=0s
+WEATHER_MAX_CONNS_PER_HOST=8
+WEATHER_TLS_HANDSHAKE_TIMEOUT=2s
+OPENMETEO_PROXY_URL=http://other-proxy:8080
+`,
+			assertions: func(t *testing.T, cfg config.AppConfig) {
+				assert.Equal(t, config.TransportConfig{
+					MaxIdleConns:        50,
+					MaxIdleConnsPerHost: 5,
+					IdleConnTimeout:     30 * time.Second,
+					KeepAlive:           30 * time.Second,
+					DialTimeout:         5 * time.Second,
+					TLSHandshakeTimeout: 5 * time.Second,
+					ProxyURL:            "http://proxy.internal:3128",
+					CABundle:            "/etc/ssl/custom.pem",
+				}, cfg.ViaCEP.Transport)
+				assert.Equal(t, 8, cfg.Weather.Transport.MaxConnsPerHost)
+				assert.Equal(t, 2*time.Second, cfg.Weather.Transport.TLSHandshakeTimeout)
+				assert.Equal(t, 50, cfg.Weather.Transport.MaxIdleConns)
+				assert.Equal(t, "http://other-proxy:8080", cfg.OpenMeteo.Transport.ProxyURL)
+				assert.Equal(t, 0, cfg.OpenMeteo.Transport.MaxConnsPerHost)
+			},
+		},
+		{
+			name: "invalid transport settings",
+			envContent: `HTTP_MAX_IDLE_CONNS=0
+HTTP_KEEP_ALIVE=forever
+BRASILAPI_MAX_CONNS_PER_HOST=-1
+`,
+			assertions: func(t *testing.T, cfg config.AppConfig) {
+				assert.Equal(t, 100, cfg.BrasilAPI.Transport.MaxIdleConns)
+				assert.Equal(t, 30*time.Second, cfg.BrasilAPI.Transport.KeepAlive)
+				assert.Equal(t, 0, cfg.BrasilAPI.Transport.MaxConnsPerHost)
+				assert.Equal(t, time.Minute, cfg.BrasilAPI.Transport.DNSCacheTTL)
+			},
+		},
 		{
 			name: "missing file",
 			assertions: func(t *testing.T, cfg config.AppConfig) {
 				assert.Equal(t, ":8080", cfg.HTTP.Addr)
 				assert.Equal(t, 5*time.Second, cfg.HTTP.Timeout)
 
+				assert.Equal(t, "viacep", cfg.ZipcodeProvider)
 				assert.Equal(t, "", cfg.ViaCEP.BaseURL)
 				assert.Equal(t, "json", cfg.ViaCEP.ReturnType)
 				assert.Equal(t, 5*time.Second, cfg.ViaCEP.Timeout)
 
+				assert.Equal(t, "weatherapi", cfg.WeatherProvider)
 				assert.Equal(t, "", cfg.Weather.BaseURL)
 				assert.Equal(t, "default_key", cfg.Weather.APIKey)
 				assert.Equal(t, 5*time.Second, cfg.Weather.Timeout)
+
+				assert.Equal(t, 1000, cfg.WeatherCache.Size)
+				assert.Equal(t, 10*time.Minute, cfg.WeatherCache.TTL)
+				assert.Equal(t, 6*time.Hour, cfg.WeatherCache.StaleTTL)
 			},
 		},
 	}
@@ -147,3 +669,138 @@ func TestParseDuration(t *testing.T) {
 		require.Error(t, err)
 	})
 }
+
+func TestParseInt(t *testing.T) {
+	t.Run("valid integer", func(t *testing.T) {
+		got, err := config.ParseInt(" 42 ")
+		require.NoError(t, err)
+		assert.Equal(t, 42, got)
+	})
+
+	t.Run("empty string", func(t *testing.T) {
+		_, err := config.ParseInt("")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "empty integer string")
+	})
+
+	t.Run("invalid format", func(t *testing.T) {
+		_, err := config.ParseInt("abc")
+		require.Error(t, err)
+	})
+}
+
+func TestParseFloat(t *testing.T) {
+	t.Run("valid float", func(t *testing.T) {
+		got, err := config.ParseFloat("0.25")
+		require.NoError(t, err)
+		assert.Equal(t, 0.25, got)
+	})
+
+	t.Run("empty string", func(t *testing.T) {
+		_, err := config.ParseFloat("")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "empty float string")
+	})
+
+	t.Run("invalid format", func(t *testing.T) {
+		_, err := config.ParseFloat("abc")
+		require.Error(t, err)
+	})
+}
+
+func TestParseIntList(t *testing.T) {
+	t.Run("valid list", func(t *testing.T) {
+		got, err := config.ParseIntList("429, 503,504")
+		require.NoError(t, err)
+		assert.Equal(t, []int{429, 503, 504}, got)
+	})
+
+	t.Run("empty string", func(t *testing.T) {
+		_, err := config.ParseIntList(" ")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "empty integer list")
+	})
+
+	t.Run("invalid item", func(t *testing.T) {
+		_, err := config.ParseIntList("429,x")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `invalid list item "x"`)
+	})
+}
+
+func TestParseZipcodeProviderList(t *testing.T) {
+	t.Run("valid list", func(t *testing.T) {
+		got, err := config.ParseZipcodeProviderList("BrasilAPI, opencep")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"brasilapi", "opencep"}, got)
+	})
+
+	t.Run("empty string", func(t *testing.T) {
+		got, err := config.ParseZipcodeProviderList(" ")
+		require.NoError(t, err)
+		assert.Empty(t, got)
+	})
+
+	t.Run("unknown provider", func(t *testing.T) {
+		_, err := config.ParseZipcodeProviderList("viacep,correios")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `unknown provider "correios"`)
+	})
+}
+
+func TestParseWeatherProviderList(t *testing.T) {
+	t.Run("valid list", func(t *testing.T) {
+		got, err := config.ParseWeatherProviderList("OpenMeteo,weatherapi")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"openmeteo", "weatherapi"}, got)
+	})
+
+	t.Run("unknown provider", func(t *testing.T) {
+		_, err := config.ParseWeatherProviderList("openweather")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `unknown provider "openweather"`)
+	})
+}
+
+func TestParseStringList(t *testing.T) {
+	assert.Equal(t, []string{"a", "b"}, config.ParseStringList(" a,, b ,"))
+	assert.Empty(t, config.ParseStringList(""))
+}
+
+func TestParsePrefixList(t *testing.T) {
+	t.Run("valid list", func(t *testing.T) {
+		got, err := config.ParsePrefixList("10.1.2.3/8, ::1")
+		require.NoError(t, err)
+		assert.Equal(t, []netip.Prefix{netip.MustParsePrefix("10.0.0.0/8"), netip.MustParsePrefix("::1/128")}, got)
+	})
+
+	t.Run("empty string", func(t *testing.T) {
+		got, err := config.ParsePrefixList("")
+		require.NoError(t, err)
+		assert.Empty(t, got)
+	})
+
+	t.Run("invalid item", func(t *testing.T) {
+		_, err := config.ParsePrefixList("10.0.0.0/33")
+		require.Error(t, err)
+	})
+}
+
+func TestParseBool(t *testing.T) {
+	t.Run("valid boolean", func(t *testing.T) {
+		got, err := config.ParseBool("true")
+		require.NoError(t, err)
+		assert.True(t, got)
+	})
+
+	t.Run("empty string", func(t *testing.T) {
+		_, err := config.ParseBool("")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "empty boolean string")
+	})
+
+	t.Run("invalid format", func(t *testing.T) {
+		_, err := config.ParseBool("maybe")
+		require.Error(t, err)
+	})
+}
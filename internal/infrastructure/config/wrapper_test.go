@@ -1,7 +1,42 @@
 package config
 
-import "time"
+import (
+	"net/netip"
+	"time"
+)
 
 func ParseDuration(raw string) (time.Duration, error) {
 	return parseDuration(raw)
 }
+
+func ParseInt(raw string) (int, error) {
+	return parseInt(raw)
+}
+
+func ParseFloat(raw string) (float64, error) {
+	return parseFloat(raw)
+}
+
+func ParseIntList(raw string) ([]int, error) {
+	return parseIntList(raw)
+}
+
+func ParseBool(raw string) (bool, error) {
+	return parseBool(raw)
+}
+
+func ParseZipcodeProviderList(raw string) ([]string, error) {
+	return parseProviderList(raw, zipcodeProviders)
+}
+
+func ParseWeatherProviderList(raw string) ([]string, error) {
+	return parseProviderList(raw, weatherProviders)
+}
+
+func ParseStringList(raw string) []string {
+	return parseStringList(raw)
+}
+
+func ParsePrefixList(raw string) ([]netip.Prefix, error) {
+	return parsePrefixList(raw)
+}
@@ -0,0 +1,159 @@
+package ensemble
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"math"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/biraneves/fc-labs-weather/internal/application/dto"
+	"github.com/biraneves/fc-labs-weather/internal/application/ports/outbound"
+	"github.com/biraneves/fc-labs-weather/internal/infrastructure/http/server"
+)
+
+const (
+	StrategyMedian = "median"
+	StrategyMean   = "mean"
+)
+
+var ErrNoProviders = errors.New("ensemble: no providers configured")
+
+type WeatherProvider struct {
+	Name string
+	Port outbound.WeatherProviderPort
+}
+
+type Settings struct {
+	Strategy   string
+	ThresholdC float64
+}
+
+type WeatherEnsemble struct {
+	providers []WeatherProvider
+	settings  Settings
+	logger    *slog.Logger
+}
+
+type result struct {
+	resp dto.WeatherAPIResponseDto
+	err  error
+}
+
+func NewWeatherEnsemble(providers []WeatherProvider, settings Settings, logger *slog.Logger) *WeatherEnsemble {
+	if settings.Strategy != StrategyMean {
+		settings.Strategy = StrategyMedian
+	}
+
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	return &WeatherEnsemble{providers: providers, settings: settings, logger: logger}
+}
+
+func (e *WeatherEnsemble) FetchCurrent(ctx context.Context, request dto.WeatherAPIRequestDto) (dto.WeatherAPIResponseDto, error) {
+	if len(e.providers) == 0 {
+		return dto.WeatherAPIResponseDto{}, ErrNoProviders
+	}
+
+	logger := server.LoggerFromContext(ctx, e.logger)
+
+	results := make([]result, len(e.providers))
+
+	var wg sync.WaitGroup
+	for i, provider := range e.providers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := provider.Port.FetchCurrent(ctx, request)
+			results[i] = result{resp: resp, err: err}
+		}()
+	}
+	wg.Wait()
+
+	var (
+		base     dto.WeatherAPIResponseDto
+		found    bool
+		firstErr error
+		served   []string
+		temps    []float64
+		summary  = &dto.EnsembleDto{Strategy: e.settings.Strategy, ThresholdC: e.settings.ThresholdC}
+	)
+
+	for i, r := range results {
+		name := e.providers[i].Name
+
+		if r.err != nil {
+			if firstErr == nil {
+				firstErr = r.err
+			}
+			summary.Failed = append(summary.Failed, name)
+			logger.Warn("ensemble: provider failed",
+				slog.String("type", "weather_ensemble"),
+				slog.String("provider", name),
+				slog.String("query", request.Q),
+				slog.String("error", r.err.Error()),
+			)
+			continue
+		}
+
+		if !found {
+			base, found = r.resp, true
+		}
+
+		served = append(served, name)
+		temps = append(temps, r.resp.Current.TempC)
+		summary.Readings = append(summary.Readings, dto.EnsembleReadingDto{Provider: name, TempC: r.resp.Current.TempC})
+	}
+
+	if !found {
+		return dto.WeatherAPIResponseDto{}, firstErr
+	}
+
+	summary.SpreadC = math.Round((slices.Max(temps)-slices.Min(temps))*100) / 100
+	summary.Disagreement = e.settings.ThresholdC > 0 && summary.SpreadC > e.settings.ThresholdC
+
+	combined := combine(e.settings.Strategy, temps)
+
+	if summary.Disagreement {
+		logger.Warn("ensemble: providers disagree",
+			slog.String("type", "weather_ensemble"),
+			slog.String("query", request.Q),
+			slog.Float64("spread_c", summary.SpreadC),
+			slog.Float64("threshold_c", e.settings.ThresholdC),
+			slog.Any("readings", summary.Readings),
+		)
+	}
+
+	base.Current.TempC = combined
+	base.Current.TempF = combined*9/5 + 32
+	base.Provider = strings.Join(served, ",")
+	base.Ensemble = summary
+
+	return base, nil
+}
+
+func combine(strategy string, values []float64) float64 {
+	if strategy == StrategyMean {
+		var sum float64
+		for _, v := range values {
+			sum += v
+		}
+		return sum / float64(len(values))
+	}
+
+	sorted := slices.Clone(values)
+	slices.Sort(sorted)
+
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 1 {
+		return sorted[mid]
+	}
+
+	return (sorted[mid-1] + sorted[mid]) / 2
+}
+
+var _ outbound.WeatherProviderPort = (*WeatherEnsemble)(nil)
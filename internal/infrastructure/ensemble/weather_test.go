@@ -0,0 +1,163 @@
+package ensemble_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/biraneves/fc-labs-weather/internal/application/dto"
+	"github.com/biraneves/fc-labs-weather/internal/application/ports/outbound"
+	"github.com/biraneves/fc-labs-weather/internal/infrastructure/ensemble"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var noopLogger = slog.New(slog.NewJSONHandler(io.Discard, nil))
+
+type fakeWeatherPort struct {
+	tempC float64
+	text  string
+	err   error
+}
+
+func (f fakeWeatherPort) FetchCurrent(ctx context.Context, req dto.WeatherAPIRequestDto) (dto.WeatherAPIResponseDto, error) {
+	var resp dto.WeatherAPIResponseDto
+	resp.Current.TempC = f.tempC
+	resp.Current.Condition.Text = f.text
+	return resp, f.err
+}
+
+func newEnsemble(settings ensemble.Settings, ports ...fakeWeatherPort) *ensemble.WeatherEnsemble {
+	names := []string{"weatherapi", "openmeteo", "inmet", "metar"}
+
+	providers := make([]ensemble.WeatherProvider, len(ports))
+	for i, port := range ports {
+		providers[i] = ensemble.WeatherProvider{Name: names[i], Port: port}
+	}
+
+	return ensemble.NewWeatherEnsemble(providers, settings, noopLogger)
+}
+
+func TestWeatherEnsemble_FetchCurrent(t *testing.T) {
+	errUpstream := errors.New("upstream failed")
+
+	tests := []struct {
+		name         string
+		settings     ensemble.Settings
+		ports        []fakeWeatherPort
+		wantTempC    float64
+		wantProvider string
+		wantEnsemble *dto.EnsembleDto
+		wantError    error
+	}{
+		{
+			name:     "median of odd readings",
+			settings: ensemble.Settings{ThresholdC: 2},
+			ports: []fakeWeatherPort{
+				{tempC: 20, text: "Sunny"},
+				{tempC: 21},
+				{tempC: 26},
+			},
+			wantTempC:    21,
+			wantProvider: "weatherapi,openmeteo,inmet",
+			wantEnsemble: &dto.EnsembleDto{
+				Strategy:     "median",
+				SpreadC:      6,
+				ThresholdC:   2,
+				Disagreement: true,
+				Readings: []dto.EnsembleReadingDto{
+					{Provider: "weatherapi", TempC: 20},
+					{Provider: "openmeteo", TempC: 21},
+					{Provider: "inmet", TempC: 26},
+				},
+			},
+		},
+		{
+			name:     "median of even readings",
+			settings: ensemble.Settings{Strategy: "median", ThresholdC: 2},
+			ports: []fakeWeatherPort{
+				{tempC: 20},
+				{tempC: 21},
+			},
+			wantTempC:    20.5,
+			wantProvider: "weatherapi,openmeteo",
+			wantEnsemble: &dto.EnsembleDto{
+				Strategy:   "median",
+				SpreadC:    1,
+				ThresholdC: 2,
+				Readings: []dto.EnsembleReadingDto{
+					{Provider: "weatherapi", TempC: 20},
+					{Provider: "openmeteo", TempC: 21},
+				},
+			},
+		},
+		{
+			name:     "mean ignores failed providers",
+			settings: ensemble.Settings{Strategy: "mean"},
+			ports: []fakeWeatherPort{
+				{err: outbound.ErrUpstreamUnavailable},
+				{tempC: 20},
+				{tempC: 23.3},
+			},
+			wantTempC:    21.65,
+			wantProvider: "openmeteo,inmet",
+			wantEnsemble: &dto.EnsembleDto{
+				Strategy: "mean",
+				SpreadC:  3.3,
+				Readings: []dto.EnsembleReadingDto{
+					{Provider: "openmeteo", TempC: 20},
+					{Provider: "inmet", TempC: 23.3},
+				},
+				Failed: []string{"weatherapi"},
+			},
+		},
+		{
+			name:     "every provider fails",
+			settings: ensemble.Settings{},
+			ports: []fakeWeatherPort{
+				{err: outbound.ErrUpstreamTimeout},
+				{err: errUpstream},
+			},
+			wantError: outbound.ErrUpstreamTimeout,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := newEnsemble(tt.settings, tt.ports...)
+
+			got, err := e.FetchCurrent(context.Background(), dto.WeatherAPIRequestDto{Q: "São Paulo"})
+			if tt.wantError != nil {
+				require.ErrorIs(t, err, tt.wantError)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.InDelta(t, tt.wantTempC, got.Current.TempC, 1e-9)
+			assert.InDelta(t, tt.wantTempC*9/5+32, got.Current.TempF, 1e-9)
+			assert.Equal(t, tt.wantProvider, got.Provider)
+			assert.Equal(t, tt.wantEnsemble, got.Ensemble)
+		})
+	}
+}
+
+func TestWeatherEnsemble_KeepsFirstProviderDetails(t *testing.T) {
+	e := newEnsemble(ensemble.Settings{},
+		fakeWeatherPort{err: outbound.ErrUpstreamUnavailable},
+		fakeWeatherPort{tempC: 20, text: "Cloudy"},
+		fakeWeatherPort{tempC: 22, text: "Sunny"},
+	)
+
+	got, err := e.FetchCurrent(context.Background(), dto.WeatherAPIRequestDto{Q: "São Paulo"})
+	require.NoError(t, err)
+	assert.Equal(t, "Cloudy", got.Current.Condition.Text)
+}
+
+func TestWeatherEnsemble_NoProviders(t *testing.T) {
+	e := ensemble.NewWeatherEnsemble(nil, ensemble.Settings{}, noopLogger)
+
+	_, err := e.FetchCurrent(context.Background(), dto.WeatherAPIRequestDto{Q: "São Paulo"})
+	require.ErrorIs(t, err, ensemble.ErrNoProviders)
+}
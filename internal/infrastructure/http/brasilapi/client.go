@@ -0,0 +1,142 @@
+package brasilapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/biraneves/fc-labs-weather/internal/application/dto"
+	"github.com/biraneves/fc-labs-weather/internal/application/ports/outbound"
+	"github.com/biraneves/fc-labs-weather/internal/infrastructure/http/server"
+	"github.com/biraneves/fc-labs-weather/internal/infrastructure/http/upstream"
+)
+
+type cepResponse struct {
+	CEP          string `json:"cep"`
+	State        string `json:"state"`
+	City         string `json:"city"`
+	Neighborhood string `json:"neighborhood"`
+	Street       string `json:"street"`
+	Service      string `json:"service"`
+}
+
+type HTTPClient struct {
+	httpClient *http.Client
+	baseURL    string
+	timeout    time.Duration
+	logger     *slog.Logger
+}
+
+func NewHTTPClient(httpClient *http.Client, baseURL string, timeout time.Duration, logger *slog.Logger) *HTTPClient {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	if baseURL == "" {
+		baseURL = "https://brasilapi.com.br/api/cep/v2"
+	}
+
+	if timeout <= 0 {
+		timeout = 5 * time.Second
+	}
+
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	return &HTTPClient{
+		httpClient: httpClient,
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+		timeout:    timeout,
+		logger:     logger,
+	}
+}
+
+func (h *HTTPClient) Find(ctx context.Context, request dto.ViaCEPRequestDto) (dto.ViaCEPResponseDto, error) {
+	logger := server.LoggerFromContext(ctx, h.logger)
+
+	ctx, cancel := context.WithTimeout(ctx, h.timeout)
+	defer cancel()
+
+	url := fmt.Sprintf("%s/%s", h.baseURL, request.CEP.String())
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		logger.Error("brasilapi: create request failed",
+			slog.String("type", "outbound_error"),
+			slog.String("cep", request.CEP.Formatted()),
+			slog.String("error", err.Error()),
+		)
+		return dto.ViaCEPResponseDto{}, fmt.Errorf("brasilapi: create request: %w", err)
+	}
+
+	resp, err := h.httpClient.Do(req)
+	if err != nil {
+		logger.Error("brasilapi: http call failed",
+			slog.String("type", "outbound_error"),
+			slog.String("cep", request.CEP.Formatted()),
+			slog.String("error", err.Error()),
+		)
+		return dto.ViaCEPResponseDto{}, fmt.Errorf("brasilapi: do request: %w: %w", upstream.ClassifyTransportError(err), err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		logger.Info("brasilapi: zipcode not found",
+			slog.String("type", "outbound_error"),
+			slog.String("cep", request.CEP.Formatted()),
+		)
+		return dto.ViaCEPResponseDto{}, outbound.ErrZipcodeNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		logger.Error("brasilapi: unexpected status",
+			slog.String("type", "outbound_error"),
+			slog.String("cep", request.CEP.Formatted()),
+			slog.Int("status", resp.StatusCode),
+		)
+		if kind := upstream.ClassifyStatus(resp.StatusCode); kind != nil {
+			return dto.ViaCEPResponseDto{}, fmt.Errorf("brasilapi: unexpected status: %d: %w", resp.StatusCode, kind)
+		}
+		return dto.ViaCEPResponseDto{}, fmt.Errorf("brasilapi: unexpected status: %d", resp.StatusCode)
+	}
+
+	var payload cepResponse
+	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+		logger.Error("brasilapi: decode response failed",
+			slog.String("type", "outbound_error"),
+			slog.String("cep", request.CEP.Formatted()),
+			slog.String("error", err.Error()),
+		)
+		return dto.ViaCEPResponseDto{}, fmt.Errorf("brasilapi: decode response: %w: %w", outbound.ErrUpstreamMalformedPayload, err)
+	}
+
+	logger.Info("brasilapi: lookup succeeded",
+		slog.String("type", "outbound_success"),
+		slog.String("cep", request.CEP.Formatted()),
+		slog.String("localidade", payload.City),
+		slog.String("uf", payload.State),
+		slog.String("service", payload.Service),
+	)
+
+	return dto.ViaCEPResponseDto{
+		CEP:        formatCEP(payload.CEP),
+		Logradouro: payload.Street,
+		Bairro:     payload.Neighborhood,
+		Localidade: payload.City,
+		UF:         payload.State,
+	}, nil
+}
+
+func formatCEP(raw string) string {
+	if len(raw) != 8 {
+		return raw
+	}
+
+	return raw[:5] + "-" + raw[5:]
+}
+
+var _ outbound.ZipcodeLookupPort = (*HTTPClient)(nil)
@@ -0,0 +1,151 @@
+package brasilapi_test
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/biraneves/fc-labs-weather/internal/application/dto"
+	"github.com/biraneves/fc-labs-weather/internal/application/ports/outbound"
+	"github.com/biraneves/fc-labs-weather/internal/domain/entity"
+	"github.com/biraneves/fc-labs-weather/internal/infrastructure/http/brasilapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var noopLogger = slog.New(slog.NewJSONHandler(io.Discard, nil))
+
+func TestNewHTTPClient(t *testing.T) {
+	client := brasilapi.NewHTTPClient(nil, "", 0, nil)
+	require.NotNil(t, client)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err := client.Find(ctx, dto.ViaCEPRequestDto{})
+	assert.Error(t, err)
+}
+
+func TestHTTPClient_Find(t *testing.T) {
+	type fields struct {
+		status int
+		body   string
+		delay  time.Duration
+	}
+
+	tests := []struct {
+		name          string
+		fields        fields
+		want          dto.ViaCEPResponseDto
+		wantError     error
+		expectedError string
+	}{
+		{
+			name: "success",
+			fields: fields{
+				status: http.StatusOK,
+				body: `{
+				  "cep": "01001000",
+				  "state": "SP",
+				  "city": "São Paulo",
+				  "neighborhood": "Sé",
+				  "street": "Praça da Sé",
+				  "service": "open-cep",
+				  "location": {"type": "Point", "coordinates": {}}
+				}`,
+			},
+			want: dto.ViaCEPResponseDto{
+				CEP:        "01001-000",
+				Logradouro: "Praça da Sé",
+				Bairro:     "Sé",
+				Localidade: "São Paulo",
+				UF:         "SP",
+			},
+		},
+		{
+			name: "zipcode not found",
+			fields: fields{
+				status: http.StatusNotFound,
+				body:   `{"name": "CepPromiseError", "type": "service_error"}`,
+			},
+			wantError: outbound.ErrZipcodeNotFound,
+		},
+		{
+			name: "upstream unavailable",
+			fields: fields{
+				status: http.StatusBadGateway,
+				body:   `{}`,
+			},
+			wantError:     outbound.ErrUpstreamUnavailable,
+			expectedError: "brasilapi: unexpected status: 502",
+		},
+		{
+			name: "unexpected status",
+			fields: fields{
+				status: http.StatusBadRequest,
+				body:   `{}`,
+			},
+			expectedError: "brasilapi: unexpected status: 400",
+		},
+		{
+			name: "invalid json",
+			fields: fields{
+				status: http.StatusOK,
+				body:   `{"cep":`,
+			},
+			wantError:     outbound.ErrUpstreamMalformedPayload,
+			expectedError: "brasilapi: decode response",
+		},
+		{
+			name: "context timeout",
+			fields: fields{
+				status: http.StatusOK,
+				body:   `{}`,
+				delay:  200 * time.Millisecond,
+			},
+			wantError:     outbound.ErrUpstreamTimeout,
+			expectedError: "context deadline exceeded",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/api/cep/v2/01001000", r.URL.Path)
+
+				if tt.fields.delay > 0 {
+					time.Sleep(tt.fields.delay)
+				}
+
+				w.WriteHeader(tt.fields.status)
+				_, _ = w.Write([]byte(tt.fields.body))
+			}))
+			defer server.Close()
+
+			client := brasilapi.NewHTTPClient(nil, server.URL+"/api/cep/v2/", 50*time.Millisecond, noopLogger)
+
+			got, err := client.Find(context.Background(), dto.ViaCEPRequestDto{CEP: entity.Cep("01001000")})
+
+			if tt.wantError != nil {
+				require.Error(t, err)
+				assert.ErrorIs(t, err, tt.wantError)
+			}
+
+			if tt.expectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError)
+			}
+
+			if tt.wantError != nil || tt.expectedError != "" {
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,130 @@
+package opencep
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/biraneves/fc-labs-weather/internal/application/dto"
+	"github.com/biraneves/fc-labs-weather/internal/application/ports/outbound"
+	"github.com/biraneves/fc-labs-weather/internal/infrastructure/http/server"
+	"github.com/biraneves/fc-labs-weather/internal/infrastructure/http/upstream"
+)
+
+type HTTPClient struct {
+	httpClient *http.Client
+	baseURL    string
+	timeout    time.Duration
+	logger     *slog.Logger
+}
+
+func NewHTTPClient(httpClient *http.Client, baseURL string, timeout time.Duration, logger *slog.Logger) *HTTPClient {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	if baseURL == "" {
+		baseURL = "https://opencep.com/v1"
+	}
+
+	if timeout <= 0 {
+		timeout = 5 * time.Second
+	}
+
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	return &HTTPClient{
+		httpClient: httpClient,
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+		timeout:    timeout,
+		logger:     logger,
+	}
+}
+
+func (h *HTTPClient) Find(ctx context.Context, request dto.ViaCEPRequestDto) (dto.ViaCEPResponseDto, error) {
+	logger := server.LoggerFromContext(ctx, h.logger)
+
+	ctx, cancel := context.WithTimeout(ctx, h.timeout)
+	defer cancel()
+
+	url := fmt.Sprintf("%s/%s", h.baseURL, request.CEP.String())
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		logger.Error("opencep: create request failed",
+			slog.String("type", "outbound_error"),
+			slog.String("cep", request.CEP.Formatted()),
+			slog.String("error", err.Error()),
+		)
+		return dto.ViaCEPResponseDto{}, fmt.Errorf("opencep: create request: %w", err)
+	}
+
+	resp, err := h.httpClient.Do(req)
+	if err != nil {
+		logger.Error("opencep: http call failed",
+			slog.String("type", "outbound_error"),
+			slog.String("cep", request.CEP.Formatted()),
+			slog.String("error", err.Error()),
+		)
+		return dto.ViaCEPResponseDto{}, fmt.Errorf("opencep: do request: %w: %w", upstream.ClassifyTransportError(err), err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		logger.Info("opencep: zipcode not found",
+			slog.String("type", "outbound_error"),
+			slog.String("cep", request.CEP.Formatted()),
+		)
+		return dto.ViaCEPResponseDto{}, outbound.ErrZipcodeNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		logger.Error("opencep: unexpected status",
+			slog.String("type", "outbound_error"),
+			slog.String("cep", request.CEP.Formatted()),
+			slog.Int("status", resp.StatusCode),
+		)
+		if kind := upstream.ClassifyStatus(resp.StatusCode); kind != nil {
+			return dto.ViaCEPResponseDto{}, fmt.Errorf("opencep: unexpected status: %d: %w", resp.StatusCode, kind)
+		}
+		return dto.ViaCEPResponseDto{}, fmt.Errorf("opencep: unexpected status: %d", resp.StatusCode)
+	}
+
+	var payload struct {
+		dto.ViaCEPResponseDto
+		Error any `json:"error"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+		logger.Error("opencep: decode response failed",
+			slog.String("type", "outbound_error"),
+			slog.String("cep", request.CEP.Formatted()),
+			slog.String("error", err.Error()),
+		)
+		return dto.ViaCEPResponseDto{}, fmt.Errorf("opencep: decode response: %w: %w", outbound.ErrUpstreamMalformedPayload, err)
+	}
+
+	if payload.Error != nil && payload.Error != false {
+		logger.Info("opencep: response flagged error",
+			slog.String("type", "outbound_error"),
+			slog.String("cep", request.CEP.Formatted()),
+		)
+		return dto.ViaCEPResponseDto{}, outbound.ErrZipcodeNotFound
+	}
+
+	logger.Info("opencep: lookup succeeded",
+		slog.String("type", "outbound_success"),
+		slog.String("cep", request.CEP.Formatted()),
+		slog.String("localidade", payload.Localidade),
+		slog.String("uf", payload.UF),
+	)
+
+	return payload.ViaCEPResponseDto, nil
+}
+
+var _ outbound.ZipcodeLookupPort = (*HTTPClient)(nil)
@@ -0,0 +1,286 @@
+package openmeteo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/biraneves/fc-labs-weather/internal/application/dto"
+	"github.com/biraneves/fc-labs-weather/internal/application/ports/outbound"
+	"github.com/biraneves/fc-labs-weather/internal/domain/entity"
+	"github.com/biraneves/fc-labs-weather/internal/infrastructure/http/server"
+	"github.com/biraneves/fc-labs-weather/internal/infrastructure/http/upstream"
+)
+
+const (
+	providerName     = "openmeteo"
+	countryCode      = "BR"
+	currentVariables = "temperature_2m,relative_humidity_2m,apparent_temperature,dew_point_2m,is_day,weather_code," +
+		"cloud_cover,pressure_msl,visibility,wind_speed_10m,wind_direction_10m,wind_gusts_10m"
+
+	kphToMph = 0.621371
+	mbToInHg = 0.02953
+)
+
+var (
+	ErrEmptyQuery       = errors.New("openmeteo: empty query parameter")
+	ErrLocationNotFound = errors.New("openmeteo: location not found")
+)
+
+type geocodingResponse struct {
+	Results []struct {
+		Name      string  `json:"name"`
+		Latitude  float64 `json:"latitude"`
+		Longitude float64 `json:"longitude"`
+		Country   string  `json:"country"`
+		Admin1    string  `json:"admin1"`
+		Timezone  string  `json:"timezone"`
+	} `json:"results"`
+}
+
+type forecastResponse struct {
+	Timezone         string `json:"timezone"`
+	UTCOffsetSeconds int    `json:"utc_offset_seconds"`
+	Current          struct {
+		Time                int64    `json:"time"`
+		Temperature         float64  `json:"temperature_2m"`
+		RelativeHumidity    *float64 `json:"relative_humidity_2m"`
+		ApparentTemperature *float64 `json:"apparent_temperature"`
+		DewPoint            *float64 `json:"dew_point_2m"`
+		IsDay               int      `json:"is_day"`
+		WeatherCode         int      `json:"weather_code"`
+		CloudCover          float64  `json:"cloud_cover"`
+		PressureMSL         *float64 `json:"pressure_msl"`
+		Visibility          *float64 `json:"visibility"`
+		WindSpeed           *float64 `json:"wind_speed_10m"`
+		WindDirection       *float64 `json:"wind_direction_10m"`
+		WindGusts           float64  `json:"wind_gusts_10m"`
+	} `json:"current"`
+}
+
+type HTTPClient struct {
+	httpClient   *http.Client
+	geocodingURL string
+	forecastURL  string
+	timeout      time.Duration
+	logger       *slog.Logger
+}
+
+func NewHTTPClient(httpClient *http.Client, geocodingURL, forecastURL string, timeout time.Duration, logger *slog.Logger) *HTTPClient {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	if geocodingURL == "" {
+		geocodingURL = "https://geocoding-api.open-meteo.com/v1"
+	}
+
+	if forecastURL == "" {
+		forecastURL = "https://api.open-meteo.com/v1"
+	}
+
+	if timeout <= 0 {
+		timeout = 5 * time.Second
+	}
+
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	return &HTTPClient{
+		httpClient:   httpClient,
+		geocodingURL: strings.TrimSuffix(geocodingURL, "/"),
+		forecastURL:  strings.TrimSuffix(forecastURL, "/"),
+		timeout:      timeout,
+		logger:       logger,
+	}
+}
+
+func (h *HTTPClient) FetchCurrent(ctx context.Context, request dto.WeatherAPIRequestDto) (dto.WeatherAPIResponseDto, error) {
+	logger := server.LoggerFromContext(ctx, h.logger)
+
+	query := strings.TrimSpace(request.Q)
+	if query == "" {
+		logger.Warn("openmeteo: empty query parameter",
+			slog.String("type", "outbound_error"),
+		)
+		return dto.WeatherAPIResponseDto{}, fmt.Errorf("%w: %w", ErrEmptyQuery, outbound.ErrWeatherLocationNotFound)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, h.timeout)
+	defer cancel()
+
+	geoParams := url.Values{}
+	geoParams.Set("name", query)
+	geoParams.Set("count", "1")
+	geoParams.Set("language", "pt")
+	geoParams.Set("countryCode", countryCode)
+	geoParams.Set("format", "json")
+
+	var geo geocodingResponse
+	if err := h.get(ctx, logger, "geocoding", h.geocodingURL+"/search", geoParams, query, &geo); err != nil {
+		return dto.WeatherAPIResponseDto{}, err
+	}
+
+	if len(geo.Results) == 0 {
+		logger.Warn("openmeteo: location not found",
+			slog.String("type", "outbound_error"),
+			slog.String("query", query),
+		)
+		return dto.WeatherAPIResponseDto{}, fmt.Errorf("%w: %w", ErrLocationNotFound, outbound.ErrWeatherLocationNotFound)
+	}
+	place := geo.Results[0]
+
+	forecastParams := url.Values{}
+	forecastParams.Set("latitude", strconv.FormatFloat(place.Latitude, 'f', -1, 64))
+	forecastParams.Set("longitude", strconv.FormatFloat(place.Longitude, 'f', -1, 64))
+	forecastParams.Set("current", currentVariables)
+	forecastParams.Set("wind_speed_unit", "kmh")
+	forecastParams.Set("timeformat", "unixtime")
+	forecastParams.Set("timezone", "auto")
+
+	var forecast forecastResponse
+	if err := h.get(ctx, logger, "forecast", h.forecastURL+"/forecast", forecastParams, query, &forecast); err != nil {
+		return dto.WeatherAPIResponseDto{}, err
+	}
+
+	var payload dto.WeatherAPIResponseDto
+	payload.Location.Name = place.Name
+	payload.Location.Region = place.Admin1
+	payload.Location.Country = place.Country
+	payload.Location.Lat = place.Latitude
+	payload.Location.Lon = place.Longitude
+	payload.Location.TzId = forecast.Timezone
+	if payload.Location.TzId == "" {
+		payload.Location.TzId = place.Timezone
+	}
+
+	current := forecast.Current
+	local := time.Unix(current.Time, 0).In(time.FixedZone(forecast.Timezone, forecast.UTCOffsetSeconds))
+	condition := conditionFor(current.WeatherCode, current.IsDay == 1)
+
+	payload.Current.LastUpdatedEpoch = int(current.Time)
+	payload.Current.LastUpdated = local.Format("2006-01-02 15:04")
+	payload.Current.TempC = current.Temperature
+	payload.Current.TempF = celsiusToFahrenheit(current.Temperature)
+	payload.Current.IsDay = current.IsDay
+	payload.Current.Condition.Text = condition.text
+	payload.Current.Condition.Code = condition.code
+	if current.WindSpeed != nil {
+		payload.Current.WindKph = current.WindSpeed
+		payload.Current.WindMph = *current.WindSpeed * kphToMph
+
+		windChill := windChillCelsius(current.Temperature, *current.WindSpeed)
+		payload.Current.WindChillC = &windChill
+		payload.Current.WindChillF = celsiusToFahrenheit(windChill)
+	}
+	if current.WindDirection != nil {
+		payload.Current.WindDegree = current.WindDirection
+		if direction, err := entity.NewWindDirection(*current.WindDirection); err == nil {
+			payload.Current.WindDir = direction.Compass()
+		}
+	}
+	if current.PressureMSL != nil {
+		payload.Current.PressureMb = current.PressureMSL
+		payload.Current.PressureIn = *current.PressureMSL * mbToInHg
+	}
+	if current.RelativeHumidity != nil {
+		payload.Current.Humidity = current.RelativeHumidity
+
+		heatIndex := heatIndexCelsius(current.Temperature, *current.RelativeHumidity)
+		payload.Current.HeatIndexC = &heatIndex
+		payload.Current.HeatIndexF = celsiusToFahrenheit(heatIndex)
+	}
+	if current.Visibility != nil {
+		if visibility, err := entity.NewVisibility(*current.Visibility/1000, entity.Kilometers); err == nil {
+			km := visibility.Kilometers()
+			payload.Current.VisKm = &km
+			payload.Current.VisMiles = visibility.Miles()
+		}
+	}
+	payload.Current.Cloud = current.CloudCover
+	if current.ApparentTemperature != nil {
+		payload.Current.FeelsLikeC = current.ApparentTemperature
+		payload.Current.FeelsLikeF = celsiusToFahrenheit(*current.ApparentTemperature)
+	}
+	if current.DewPoint != nil {
+		payload.Current.DewPointC = current.DewPoint
+		payload.Current.DewPointF = celsiusToFahrenheit(*current.DewPoint)
+	}
+	payload.Current.GustKph = current.WindGusts
+	payload.Current.GustMph = current.WindGusts * kphToMph
+	payload.Provider = providerName
+
+	logger.Info("openmeteo: lookup succeeded",
+		slog.String("type", "outbound_success"),
+		slog.String("query", query),
+		slog.Float64("temp_c", payload.Current.TempC),
+	)
+
+	return payload, nil
+}
+
+func (h *HTTPClient) get(ctx context.Context, logger *slog.Logger, api, endpoint string, params url.Values, query string, out any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		logger.Error("openmeteo: create request failed",
+			slog.String("type", "outbound_error"),
+			slog.String("api", api),
+			slog.String("query", query),
+			slog.String("error", err.Error()),
+		)
+		return fmt.Errorf("openmeteo: create %s request: %w", api, err)
+	}
+	req.URL.RawQuery = params.Encode()
+
+	resp, err := h.httpClient.Do(req)
+	if err != nil {
+		logger.Error("openmeteo: http call failed",
+			slog.String("type", "outbound_error"),
+			slog.String("api", api),
+			slog.String("query", query),
+			slog.String("error", err.Error()),
+		)
+		return fmt.Errorf("openmeteo: do %s request: %w: %w", api, upstream.ClassifyTransportError(err), err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		logger.Error("openmeteo: unexpected status",
+			slog.String("type", "outbound_error"),
+			slog.String("api", api),
+			slog.String("query", query),
+			slog.Int("status", resp.StatusCode),
+		)
+
+		if kind := upstream.ClassifyStatus(resp.StatusCode); kind != nil {
+			return fmt.Errorf("openmeteo: unexpected %s status: %d: %w", api, resp.StatusCode, kind)
+		}
+		return fmt.Errorf("openmeteo: unexpected %s status: %d", api, resp.StatusCode)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		logger.Error("openmeteo: decode response failed",
+			slog.String("type", "outbound_error"),
+			slog.String("api", api),
+			slog.String("query", query),
+			slog.String("error", err.Error()),
+		)
+		return fmt.Errorf("openmeteo: decode %s response: %w: %w", api, outbound.ErrUpstreamMalformedPayload, err)
+	}
+
+	return nil
+}
+
+func celsiusToFahrenheit(c float64) float64 {
+	return c*9/5 + 32
+}
+
+var _ outbound.WeatherProviderPort = (*HTTPClient)(nil)
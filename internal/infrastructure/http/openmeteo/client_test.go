@@ -0,0 +1,267 @@
+package openmeteo_test
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/biraneves/fc-labs-weather/internal/application/dto"
+	"github.com/biraneves/fc-labs-weather/internal/application/ports/outbound"
+	"github.com/biraneves/fc-labs-weather/internal/infrastructure/http/openmeteo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var noopLogger = slog.New(slog.NewJSONHandler(io.Discard, nil))
+
+const (
+	geocodingBody = `{
+	  "results": [{
+	    "name": "São Paulo",
+	    "latitude": -23.5475,
+	    "longitude": -46.63611,
+	    "country": "Brasil",
+	    "admin1": "São Paulo",
+	    "timezone": "America/Sao_Paulo"
+	  }]
+	}`
+	forecastBody = `{
+	  "timezone": "America/Sao_Paulo",
+	  "utc_offset_seconds": -10800,
+	  "current": {
+	    "time": 1735740000,
+	    "temperature_2m": 25,
+	    "relative_humidity_2m": 70,
+	    "apparent_temperature": 27,
+	    "dew_point_2m": 19.2,
+	    "is_day": 1,
+	    "weather_code": 61,
+	    "cloud_cover": 80,
+	    "pressure_msl": 1000,
+	    "visibility": 24140,
+	    "wind_speed_10m": 10,
+	    "wind_direction_10m": 135,
+	    "wind_gusts_10m": 20
+	  }
+	}`
+)
+
+func TestNewHTTPClient(t *testing.T) {
+	client := openmeteo.NewHTTPClient(nil, "", "", 0, nil)
+	require.NotNil(t, client)
+
+	_, err := client.FetchCurrent(context.Background(), dto.WeatherAPIRequestDto{Q: " "})
+	assert.ErrorIs(t, err, openmeteo.ErrEmptyQuery)
+	assert.ErrorIs(t, err, outbound.ErrWeatherLocationNotFound)
+}
+
+func TestHTTPClient_FetchCurrent(t *testing.T) {
+	type fields struct {
+		geocodingStatus int
+		geocodingBody   string
+		forecastStatus  int
+		forecastBody    string
+		delay           time.Duration
+	}
+
+	tests := []struct {
+		name          string
+		fields        fields
+		want          func() dto.WeatherAPIResponseDto
+		wantError     error
+		expectedError string
+	}{
+		{
+			name: "success",
+			fields: fields{
+				geocodingStatus: http.StatusOK,
+				geocodingBody:   geocodingBody,
+				forecastStatus:  http.StatusOK,
+				forecastBody:    forecastBody,
+			},
+			want: func() dto.WeatherAPIResponseDto {
+				var resp dto.WeatherAPIResponseDto
+				resp.Location.Name = "São Paulo"
+				resp.Location.Region = "São Paulo"
+				resp.Location.Country = "Brasil"
+				resp.Location.Lat = -23.5475
+				resp.Location.Lon = -46.63611
+				resp.Location.TzId = "America/Sao_Paulo"
+				resp.Current.LastUpdatedEpoch = 1735740000
+				resp.Current.LastUpdated = "2025-01-01 11:00"
+				resp.Current.TempC = 25
+				resp.Current.TempF = 77
+				resp.Current.IsDay = 1
+				resp.Current.Condition.Text = "Light rain"
+				resp.Current.Condition.Code = 1183
+				resp.Current.WindKph = float64Ptr(10)
+				resp.Current.WindMph = 6.21371
+				resp.Current.WindDegree = float64Ptr(135)
+				resp.Current.WindDir = "SE"
+				resp.Current.PressureMb = float64Ptr(1000)
+				resp.Current.PressureIn = 29.53
+				resp.Current.Humidity = float64Ptr(70)
+				resp.Current.VisKm = float64Ptr(24.14)
+				resp.Current.VisMiles = 24.14 / 1.609344
+				resp.Current.Cloud = 80
+				resp.Current.FeelsLikeC = float64Ptr(27)
+				resp.Current.FeelsLikeF = 80.6
+				resp.Current.HeatIndexC = float64Ptr(25.4)
+				resp.Current.HeatIndexF = 25.4*9/5 + 32
+				resp.Current.WindChillC = float64Ptr(25)
+				resp.Current.WindChillF = 77
+				resp.Current.DewPointC = float64Ptr(19.2)
+				resp.Current.DewPointF = 19.2*9/5 + 32
+				resp.Current.GustKph = 20
+				resp.Current.GustMph = 12.42742
+				resp.Provider = "openmeteo"
+				return resp
+			},
+		},
+		{
+			name: "missing readings are left out",
+			fields: fields{
+				geocodingStatus: http.StatusOK,
+				geocodingBody:   geocodingBody,
+				forecastStatus:  http.StatusOK,
+				forecastBody:    `{"timezone": "America/Sao_Paulo", "utc_offset_seconds": -10800, "current": {"time": 1735740000, "temperature_2m": 25, "relative_humidity_2m": null}}`,
+			},
+			want: func() dto.WeatherAPIResponseDto {
+				var resp dto.WeatherAPIResponseDto
+				resp.Location.Name = "São Paulo"
+				resp.Location.Region = "São Paulo"
+				resp.Location.Country = "Brasil"
+				resp.Location.Lat = -23.5475
+				resp.Location.Lon = -46.63611
+				resp.Location.TzId = "America/Sao_Paulo"
+				resp.Current.LastUpdatedEpoch = 1735740000
+				resp.Current.LastUpdated = "2025-01-01 11:00"
+				resp.Current.TempC = 25
+				resp.Current.TempF = 77
+				resp.Current.Condition.Text = "Clear"
+				resp.Current.Condition.Code = 1000
+				resp.Provider = "openmeteo"
+				return resp
+			},
+		},
+		{
+			name: "location not found",
+			fields: fields{
+				geocodingStatus: http.StatusOK,
+				geocodingBody:   `{"generationtime_ms": 0.5}`,
+			},
+			wantError: openmeteo.ErrLocationNotFound,
+		},
+		{
+			name: "location not found is classified for the handler",
+			fields: fields{
+				geocodingStatus: http.StatusOK,
+				geocodingBody:   `{"results": []}`,
+			},
+			wantError: outbound.ErrWeatherLocationNotFound,
+		},
+		{
+			name: "geocoding unavailable",
+			fields: fields{
+				geocodingStatus: http.StatusServiceUnavailable,
+				geocodingBody:   `{}`,
+			},
+			wantError:     outbound.ErrUpstreamUnavailable,
+			expectedError: "openmeteo: unexpected geocoding status: 503",
+		},
+		{
+			name: "forecast bad request",
+			fields: fields{
+				geocodingStatus: http.StatusOK,
+				geocodingBody:   geocodingBody,
+				forecastStatus:  http.StatusBadRequest,
+				forecastBody:    `{"error": true, "reason": "invalid"}`,
+			},
+			expectedError: "openmeteo: unexpected forecast status: 400",
+		},
+		{
+			name: "forecast invalid json",
+			fields: fields{
+				geocodingStatus: http.StatusOK,
+				geocodingBody:   geocodingBody,
+				forecastStatus:  http.StatusOK,
+				forecastBody:    `{"current":`,
+			},
+			wantError:     outbound.ErrUpstreamMalformedPayload,
+			expectedError: "openmeteo: decode forecast response",
+		},
+		{
+			name: "context timeout",
+			fields: fields{
+				geocodingStatus: http.StatusOK,
+				geocodingBody:   geocodingBody,
+				delay:           200 * time.Millisecond,
+			},
+			wantError:     outbound.ErrUpstreamTimeout,
+			expectedError: "context deadline exceeded",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux := http.NewServeMux()
+			mux.HandleFunc("GET /geo/search", func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "São Paulo", r.URL.Query().Get("name"))
+				assert.Equal(t, "BR", r.URL.Query().Get("countryCode"))
+
+				if tt.fields.delay > 0 {
+					time.Sleep(tt.fields.delay)
+				}
+
+				w.WriteHeader(tt.fields.geocodingStatus)
+				_, _ = w.Write([]byte(tt.fields.geocodingBody))
+			})
+			mux.HandleFunc("GET /forecast/forecast", func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "-23.5475", r.URL.Query().Get("latitude"))
+				assert.Equal(t, "-46.63611", r.URL.Query().Get("longitude"))
+				assert.Contains(t, r.URL.Query().Get("current"), "temperature_2m")
+
+				w.WriteHeader(tt.fields.forecastStatus)
+				_, _ = w.Write([]byte(tt.fields.forecastBody))
+			})
+
+			server := httptest.NewServer(mux)
+			defer server.Close()
+
+			client := openmeteo.NewHTTPClient(nil, server.URL+"/geo/", server.URL+"/forecast", 50*time.Millisecond, noopLogger)
+
+			got, err := client.FetchCurrent(context.Background(), dto.WeatherAPIRequestDto{Q: "São Paulo"})
+			if tt.wantError != nil {
+				require.Error(t, err)
+				assert.ErrorIs(t, err, tt.wantError)
+			}
+
+			if tt.expectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError)
+			}
+
+			if tt.wantError != nil || tt.expectedError != "" {
+				return
+			}
+
+			require.NoError(t, err)
+			want := tt.want()
+			assert.InDelta(t, want.Current.WindMph, got.Current.WindMph, 1e-6)
+			assert.InDelta(t, want.Current.GustMph, got.Current.GustMph, 1e-6)
+			assert.InDelta(t, want.Current.PressureIn, got.Current.PressureIn, 1e-6)
+			assert.InDelta(t, want.Current.VisMiles, got.Current.VisMiles, 1e-9)
+			got.Current.WindMph, got.Current.GustMph, got.Current.PressureIn = want.Current.WindMph, want.Current.GustMph, want.Current.PressureIn
+			got.Current.VisMiles = want.Current.VisMiles
+			assert.Equal(t, want, got)
+		})
+	}
+}
+
+func float64Ptr(v float64) *float64 {
+	return &v
+}
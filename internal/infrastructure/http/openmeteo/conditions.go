@@ -0,0 +1,81 @@
+package openmeteo
+
+import "math"
+
+type condition struct {
+	code int
+	text string
+}
+
+var wmoConditions = map[int]condition{
+	0:  {code: 1000, text: "Sunny"},
+	1:  {code: 1003, text: "Partly cloudy"},
+	2:  {code: 1006, text: "Cloudy"},
+	3:  {code: 1009, text: "Overcast"},
+	45: {code: 1135, text: "Fog"},
+	48: {code: 1147, text: "Freezing fog"},
+	51: {code: 1150, text: "Patchy light drizzle"},
+	53: {code: 1153, text: "Light drizzle"},
+	55: {code: 1153, text: "Light drizzle"},
+	56: {code: 1168, text: "Freezing drizzle"},
+	57: {code: 1171, text: "Heavy freezing drizzle"},
+	61: {code: 1183, text: "Light rain"},
+	63: {code: 1189, text: "Moderate rain"},
+	65: {code: 1195, text: "Heavy rain"},
+	66: {code: 1198, text: "Light freezing rain"},
+	67: {code: 1201, text: "Moderate or heavy freezing rain"},
+	71: {code: 1213, text: "Light snow"},
+	73: {code: 1219, text: "Moderate snow"},
+	75: {code: 1225, text: "Heavy snow"},
+	77: {code: 1237, text: "Ice pellets"},
+	80: {code: 1240, text: "Light rain shower"},
+	81: {code: 1243, text: "Moderate or heavy rain shower"},
+	82: {code: 1246, text: "Torrential rain shower"},
+	85: {code: 1255, text: "Light snow showers"},
+	86: {code: 1258, text: "Moderate or heavy snow showers"},
+	95: {code: 1273, text: "Patchy light rain with thunder"},
+	96: {code: 1276, text: "Moderate or heavy rain with thunder"},
+	99: {code: 1276, text: "Moderate or heavy rain with thunder"},
+}
+
+func conditionFor(wmoCode int, isDay bool) condition {
+	c, ok := wmoConditions[wmoCode]
+	if !ok {
+		return condition{text: "Unknown"}
+	}
+
+	if wmoCode == 0 && !isDay {
+		c.text = "Clear"
+	}
+
+	return c
+}
+
+func heatIndexCelsius(tempC, humidity float64) float64 {
+	t := celsiusToFahrenheit(tempC)
+
+	hi := 0.5 * (t + 61 + (t-68)*1.2 + humidity*0.094)
+	if (hi+t)/2 >= 80 {
+		hi = -42.379 + 2.04901523*t + 10.14333127*humidity -
+			0.22475541*t*humidity - 0.00683783*t*t - 0.05481717*humidity*humidity +
+			0.00122874*t*t*humidity + 0.00085282*t*humidity*humidity - 0.00000199*t*t*humidity*humidity
+
+		switch {
+		case humidity < 13 && t >= 80 && t <= 112:
+			hi -= (13 - humidity) / 4 * math.Sqrt((17-math.Abs(t-95))/17)
+		case humidity > 85 && t >= 80 && t <= 87:
+			hi += (humidity - 85) / 10 * (87 - t) / 5
+		}
+	}
+
+	return math.Round((hi-32)*5/9*10) / 10
+}
+
+func windChillCelsius(tempC, windKph float64) float64 {
+	if tempC > 10 || windKph <= 4.8 {
+		return tempC
+	}
+
+	v := math.Pow(windKph, 0.16)
+	return math.Round((13.12+0.6215*tempC-11.37*v+0.3965*tempC*v)*10) / 10
+}
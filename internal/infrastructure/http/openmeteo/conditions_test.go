@@ -0,0 +1,73 @@
+package openmeteo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConditionFor(t *testing.T) {
+	tests := []struct {
+		name     string
+		wmoCode  int
+		isDay    bool
+		wantCode int
+		wantText string
+	}{
+		{name: "clear day", wmoCode: 0, isDay: true, wantCode: 1000, wantText: "Sunny"},
+		{name: "clear night", wmoCode: 0, isDay: false, wantCode: 1000, wantText: "Clear"},
+		{name: "overcast", wmoCode: 3, isDay: true, wantCode: 1009, wantText: "Overcast"},
+		{name: "thunderstorm with hail", wmoCode: 99, isDay: false, wantCode: 1276, wantText: "Moderate or heavy rain with thunder"},
+		{name: "unknown code", wmoCode: 42, isDay: true, wantCode: 0, wantText: "Unknown"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := conditionFor(tt.wmoCode, tt.isDay)
+			assert.Equal(t, tt.wantCode, got.code)
+			assert.Equal(t, tt.wantText, got.text)
+		})
+	}
+}
+
+func TestHeatIndexCelsius(t *testing.T) {
+	tests := []struct {
+		name     string
+		tempC    float64
+		humidity float64
+		want     float64
+	}{
+		{name: "mild and humid", tempC: 25, humidity: 70, want: 25.4},
+		{name: "cool and dry", tempC: 10, humidity: 30, want: 7.8},
+		{name: "hot and humid", tempC: 32, humidity: 70, want: 40.4},
+		{name: "national weather service table", tempC: 32.2, humidity: 70, want: 41},
+		{name: "hot and very dry", tempC: 38, humidity: 10, want: 34.7},
+		{name: "warm and saturated", tempC: 28, humidity: 90, want: 34.0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.InDelta(t, tt.want, heatIndexCelsius(tt.tempC, tt.humidity), 1e-9)
+		})
+	}
+}
+
+func TestWindChillCelsius(t *testing.T) {
+	tests := []struct {
+		name    string
+		tempC   float64
+		windKph float64
+		want    float64
+	}{
+		{name: "too warm", tempC: 25, windKph: 30, want: 25},
+		{name: "calm", tempC: 0, windKph: 3, want: 0},
+		{name: "cold and windy", tempC: -10, windKph: 30, want: -19.5},
+		{name: "chilly breeze", tempC: 5, windKph: 20, want: 1.1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.InDelta(t, tt.want, windChillCelsius(tt.tempC, tt.windKph), 1e-9)
+		})
+	}
+}
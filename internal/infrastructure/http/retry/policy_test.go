@@ -0,0 +1,70 @@
+package retry
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPolicy_Backoff(t *testing.T) {
+	policy := Policy{BaseBackoff: 100 * time.Millisecond, MaxBackoff: time.Second}
+
+	tests := []struct {
+		name    string
+		attempt int
+		jitter  float64
+		random  float64
+		want    time.Duration
+	}{
+		{name: "first attempt", attempt: 1, want: 100 * time.Millisecond},
+		{name: "exponential growth", attempt: 3, want: 400 * time.Millisecond},
+		{name: "capped by max backoff", attempt: 10, want: time.Second},
+		{name: "lowest jitter", attempt: 2, jitter: 0.5, random: 0, want: 100 * time.Millisecond},
+		{name: "highest jitter", attempt: 2, jitter: 0.5, random: 1, want: 300 * time.Millisecond},
+		{name: "jitter above one is clamped", attempt: 1, jitter: 3, random: 0, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := policy
+			p.Jitter = tt.jitter
+			assert.Equal(t, tt.want, p.backoff(tt.attempt, tt.random))
+		})
+	}
+}
+
+func TestPolicy_RetryableStatus(t *testing.T) {
+	policy := Policy{RetryableStatuses: []int{http.StatusNotFound, http.StatusServiceUnavailable}}
+
+	assert.True(t, policy.retryableStatus(http.StatusServiceUnavailable))
+	assert.False(t, policy.retryableStatus(http.StatusBadRequest))
+	assert.False(t, policy.retryableStatus(http.StatusNotFound), "404 must never be retried")
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		raw    string
+		want   time.Duration
+		wantOK bool
+	}{
+		{name: "empty", raw: ""},
+		{name: "seconds", raw: "3", want: 3 * time.Second, wantOK: true},
+		{name: "negative seconds", raw: "-1"},
+		{name: "http date", raw: now.Add(5 * time.Second).Format(http.TimeFormat), want: 5 * time.Second, wantOK: true},
+		{name: "date in the past", raw: now.Add(-time.Minute).Format(http.TimeFormat), want: 0, wantOK: true},
+		{name: "garbage", raw: "soon"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := parseRetryAfter(tt.raw, now)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,215 @@
+package retry
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"math"
+	"math/rand/v2"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/biraneves/fc-labs-weather/internal/infrastructure/http/server"
+)
+
+const maxDrainBytes = 64 << 10
+
+type Policy struct {
+	MaxAttempts       int
+	BaseBackoff       time.Duration
+	MaxBackoff        time.Duration
+	Jitter            float64
+	RetryableStatuses []int
+}
+
+func DefaultPolicy() Policy {
+	return Policy{
+		MaxAttempts: 3,
+		BaseBackoff: 100 * time.Millisecond,
+		MaxBackoff:  2 * time.Second,
+		Jitter:      0.2,
+		RetryableStatuses: []int{
+			http.StatusTooManyRequests,
+			http.StatusInternalServerError,
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout,
+		},
+	}
+}
+
+func (p Policy) retryableStatus(status int) bool {
+	if status == http.StatusNotFound {
+		return false
+	}
+
+	return slices.Contains(p.RetryableStatuses, status)
+}
+
+func (p Policy) backoff(attempt int, random float64) time.Duration {
+	d := float64(p.BaseBackoff) * math.Pow(2, float64(attempt-1))
+	if p.MaxBackoff > 0 && d > float64(p.MaxBackoff) {
+		d = float64(p.MaxBackoff)
+	}
+
+	if p.Jitter > 0 {
+		jitter := min(p.Jitter, 1)
+		d = d * (1 - jitter + 2*jitter*random)
+	}
+
+	return time.Duration(d)
+}
+
+type Transport struct {
+	next   http.RoundTripper
+	policy Policy
+	logger *slog.Logger
+	random func() float64
+	sleep  func(ctx context.Context, d time.Duration) error
+	now    func() time.Time
+}
+
+func NewTransport(next http.RoundTripper, policy Policy, logger *slog.Logger) *Transport {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	if policy.MaxAttempts <= 0 {
+		policy.MaxAttempts = 1
+	}
+
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	return &Transport{
+		next:   next,
+		policy: policy,
+		logger: logger,
+		random: rand.Float64,
+		sleep:  sleepContext,
+		now:    time.Now,
+	}
+}
+
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	logger := server.LoggerFromContext(ctx, t.logger)
+
+	for attempt := 1; ; attempt++ {
+		attemptReq := req
+		if attempt > 1 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			attemptReq = req.Clone(ctx)
+			attemptReq.Body = body
+		}
+
+		resp, err := t.next.RoundTrip(attemptReq)
+
+		if attempt >= t.policy.MaxAttempts || !t.shouldRetry(req, resp, err) {
+			return resp, err
+		}
+
+		delay := t.policy.backoff(attempt, t.random())
+		if resp != nil {
+			if retryAfter, ok := parseRetryAfter(resp.Header.Get("Retry-After"), t.now()); ok {
+				delay = retryAfter
+				if t.policy.MaxBackoff > 0 {
+					delay = min(delay, t.policy.MaxBackoff)
+				}
+			}
+		}
+
+		if deadline, ok := ctx.Deadline(); ok && !t.now().Add(delay).Before(deadline) {
+			logger.Warn("retry: giving up, backoff would exceed request deadline",
+				slog.String("type", "outbound_retry"),
+				slog.String("host", req.URL.Host),
+				slog.Int("attempt", attempt),
+				slog.Duration("delay", delay),
+			)
+			return resp, err
+		}
+
+		attrs := []any{
+			slog.String("type", "outbound_retry"),
+			slog.String("host", req.URL.Host),
+			slog.Int("attempt", attempt),
+			slog.Duration("delay", delay),
+		}
+		if err != nil {
+			attrs = append(attrs, slog.String("error", err.Error()))
+		} else {
+			attrs = append(attrs, slog.Int("status", resp.StatusCode))
+			drainAndClose(resp)
+		}
+		logger.Warn("retry: retrying outbound request", attrs...)
+
+		if err := t.sleep(ctx, delay); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (t *Transport) shouldRetry(req *http.Request, resp *http.Response, err error) bool {
+	if req.Context().Err() != nil {
+		return false
+	}
+
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return false
+	}
+
+	if err != nil {
+		return true
+	}
+
+	return t.policy.retryableStatus(resp.StatusCode)
+}
+
+func parseRetryAfter(raw string, now time.Time) (time.Duration, bool) {
+	if raw == "" {
+		return 0, false
+	}
+
+	if secs, err := strconv.Atoi(raw); err == nil {
+		if secs < 0 {
+			return 0, false
+		}
+		return time.Duration(secs) * time.Second, true
+	}
+
+	at, err := http.ParseTime(raw)
+	if err != nil {
+		return 0, false
+	}
+
+	return max(at.Sub(now), 0), true
+}
+
+func drainAndClose(resp *http.Response) {
+	_, _ = io.CopyN(io.Discard, resp.Body, maxDrainBytes)
+	_ = resp.Body.Close()
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+var _ http.RoundTripper = (*Transport)(nil)
@@ -0,0 +1,172 @@
+package retry_test
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/biraneves/fc-labs-weather/internal/infrastructure/http/retry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var noopLogger = slog.New(slog.NewJSONHandler(io.Discard, nil))
+
+func TestTransport_RoundTrip(t *testing.T) {
+	tests := []struct {
+		name       string
+		statuses   []int
+		retryAfter string
+		deadline   time.Duration
+		wantStatus int
+		wantCalls  int32
+		wantDelays []time.Duration
+	}{
+		{
+			name:       "success on first attempt",
+			statuses:   []int{http.StatusOK},
+			wantStatus: http.StatusOK,
+			wantCalls:  1,
+		},
+		{
+			name:       "retries transient failures",
+			statuses:   []int{http.StatusServiceUnavailable, http.StatusBadGateway, http.StatusOK},
+			wantStatus: http.StatusOK,
+			wantCalls:  3,
+			wantDelays: []time.Duration{100 * time.Millisecond, 200 * time.Millisecond},
+		},
+		{
+			name:       "gives up after max attempts",
+			statuses:   []int{http.StatusServiceUnavailable, http.StatusServiceUnavailable, http.StatusServiceUnavailable, http.StatusOK},
+			wantStatus: http.StatusServiceUnavailable,
+			wantCalls:  3,
+			wantDelays: []time.Duration{100 * time.Millisecond, 200 * time.Millisecond},
+		},
+		{
+			name:       "never retries not found",
+			statuses:   []int{http.StatusNotFound, http.StatusOK},
+			wantStatus: http.StatusNotFound,
+			wantCalls:  1,
+		},
+		{
+			name:       "does not retry client errors",
+			statuses:   []int{http.StatusBadRequest, http.StatusOK},
+			wantStatus: http.StatusBadRequest,
+			wantCalls:  1,
+		},
+		{
+			name:       "honors retry-after",
+			statuses:   []int{http.StatusTooManyRequests, http.StatusOK},
+			retryAfter: "1",
+			wantStatus: http.StatusOK,
+			wantCalls:  2,
+			wantDelays: []time.Duration{time.Second},
+		},
+		{
+			name:       "caps retry-after at max backoff",
+			statuses:   []int{http.StatusServiceUnavailable, http.StatusOK},
+			retryAfter: "3600",
+			wantStatus: http.StatusOK,
+			wantCalls:  2,
+			wantDelays: []time.Duration{2 * time.Second},
+		},
+		{
+			name:       "stops when backoff exceeds deadline",
+			statuses:   []int{http.StatusTooManyRequests, http.StatusOK},
+			retryAfter: "5",
+			deadline:   time.Second,
+			wantStatus: http.StatusTooManyRequests,
+			wantCalls:  1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls atomic.Int32
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				i := calls.Add(1) - 1
+				if tt.retryAfter != "" {
+					w.Header().Set("Retry-After", tt.retryAfter)
+				}
+				w.WriteHeader(tt.statuses[i])
+			}))
+			defer srv.Close()
+
+			policy := retry.DefaultPolicy()
+			policy.Jitter = 0
+
+			var delays []time.Duration
+			transport := retry.NewTransport(nil, policy, noopLogger)
+			transport.SetSleep(func(ctx context.Context, d time.Duration) error {
+				delays = append(delays, d)
+				return nil
+			})
+
+			ctx := context.Background()
+			if tt.deadline > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, tt.deadline)
+				defer cancel()
+			}
+
+			req, err := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL, nil)
+			require.NoError(t, err)
+
+			resp, err := (&http.Client{Transport: transport}).Do(req)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, tt.wantStatus, resp.StatusCode)
+			assert.Equal(t, tt.wantCalls, calls.Load())
+			assert.Equal(t, tt.wantDelays, delays)
+		})
+	}
+}
+
+func TestTransport_RetriesNetworkErrors(t *testing.T) {
+	var calls atomic.Int32
+	next := roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		if calls.Add(1) == 1 {
+			return nil, io.ErrUnexpectedEOF
+		}
+		return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody, Request: r}, nil
+	})
+
+	transport := retry.NewTransport(next, retry.DefaultPolicy(), noopLogger)
+	transport.SetSleep(func(ctx context.Context, d time.Duration) error { return nil })
+
+	req := httptest.NewRequest(http.MethodGet, "http://upstream.test/", nil)
+	resp, err := transport.RoundTrip(req)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+func TestTransport_StopsOnCanceledContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	var calls atomic.Int32
+	next := roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		calls.Add(1)
+		cancel()
+		return nil, context.Canceled
+	})
+
+	transport := retry.NewTransport(next, retry.DefaultPolicy(), noopLogger)
+
+	req := httptest.NewRequest(http.MethodGet, "http://upstream.test/", nil).WithContext(ctx)
+	_, err := transport.RoundTrip(req)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+type roundTripFunc func(r *http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
@@ -0,0 +1,14 @@
+package retry
+
+import (
+	"context"
+	"time"
+)
+
+func (t *Transport) SetSleep(sleep func(ctx context.Context, d time.Duration) error) {
+	t.sleep = sleep
+}
+
+func (t *Transport) SetRandom(random func() float64) {
+	t.random = random
+}
@@ -0,0 +1,238 @@
+package server
+
+import (
+	"log/slog"
+	"math"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+)
+
+const (
+	ConcurrencyModeFixed    = "fixed"
+	ConcurrencyModeAdaptive = "adaptive"
+
+	defaultConcurrencyMax           = 100
+	defaultConcurrencyMin           = 10
+	defaultConcurrencyLatencyTarget = time.Second
+	defaultConcurrencyBackoff       = 0.9
+	defaultConcurrencyQueueTimeout  = 200 * time.Millisecond
+)
+
+type ConcurrencySettings struct {
+	Mode          string
+	MaxConcurrent int
+	MinConcurrent int
+	LatencyTarget time.Duration
+	Backoff       float64
+	QueueSize     int
+	QueueTimeout  time.Duration
+	ExemptPaths   []string
+}
+
+type ConcurrencySnapshot struct {
+	Mode     string  `json:"mode"`
+	Limit    int     `json:"limit"`
+	InFlight int     `json:"in_flight"`
+	Queued   int     `json:"queued"`
+	Shed     int64   `json:"shed"`
+	Served   int64   `json:"served"`
+	Ratio    float64 `json:"shed_ratio"`
+}
+
+type ConcurrencyMiddleware struct {
+	settings ConcurrencySettings
+	logger   *slog.Logger
+	now      func() time.Time
+
+	mu           sync.Mutex
+	limit        float64
+	inFlight     int
+	waiters      []chan struct{}
+	lastDecrease time.Time
+	shed         int64
+	served       int64
+}
+
+func NewConcurrencyMiddleware(settings ConcurrencySettings, logger *slog.Logger) *ConcurrencyMiddleware {
+	if settings.Mode != ConcurrencyModeAdaptive {
+		settings.Mode = ConcurrencyModeFixed
+	}
+
+	if settings.MaxConcurrent <= 0 {
+		settings.MaxConcurrent = defaultConcurrencyMax
+	}
+
+	if settings.MinConcurrent <= 0 {
+		settings.MinConcurrent = min(defaultConcurrencyMin, settings.MaxConcurrent)
+	}
+
+	if settings.MinConcurrent > settings.MaxConcurrent {
+		settings.MinConcurrent = settings.MaxConcurrent
+	}
+
+	if settings.LatencyTarget <= 0 {
+		settings.LatencyTarget = defaultConcurrencyLatencyTarget
+	}
+
+	if settings.Backoff <= 0 || settings.Backoff >= 1 {
+		settings.Backoff = defaultConcurrencyBackoff
+	}
+
+	if settings.QueueSize < 0 {
+		settings.QueueSize = 0
+	}
+
+	if settings.QueueTimeout <= 0 {
+		settings.QueueTimeout = defaultConcurrencyQueueTimeout
+	}
+
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	return &ConcurrencyMiddleware{
+		settings: settings,
+		logger:   logger,
+		now:      time.Now,
+		limit:    float64(settings.MaxConcurrent),
+	}
+}
+
+func (m *ConcurrencyMiddleware) Wrap(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if slices.Contains(m.settings.ExemptPaths, r.URL.Path) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		if !m.acquire(r) {
+			logger := LoggerFromContext(r.Context(), m.logger)
+			logger.Warn("load shed",
+				slog.String("type", "overloaded"),
+				slog.String("path", r.URL.Path),
+				slog.Int("limit", m.Snapshot().Limit),
+			)
+
+			w.Header().Set("Retry-After", "1")
+			writeErrorCode(w, http.StatusServiceUnavailable, "overloaded", "server overloaded")
+			return
+		}
+
+		start := m.now()
+		defer func() {
+			m.release(m.now().Sub(start))
+		}()
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (m *ConcurrencyMiddleware) Snapshot() ConcurrencySnapshot {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	snapshot := ConcurrencySnapshot{
+		Mode:     m.settings.Mode,
+		Limit:    m.currentLimit(),
+		InFlight: m.inFlight,
+		Queued:   len(m.waiters),
+		Shed:     m.shed,
+		Served:   m.served,
+	}
+
+	if total := m.shed + m.served; total > 0 {
+		snapshot.Ratio = float64(m.shed) / float64(total)
+	}
+
+	return snapshot
+}
+
+func (m *ConcurrencyMiddleware) acquire(r *http.Request) bool {
+	m.mu.Lock()
+	if m.inFlight < m.currentLimit() {
+		m.inFlight++
+		m.served++
+		m.mu.Unlock()
+		return true
+	}
+
+	if len(m.waiters) >= m.settings.QueueSize {
+		m.shed++
+		m.mu.Unlock()
+		return false
+	}
+
+	ready := make(chan struct{})
+	m.waiters = append(m.waiters, ready)
+	m.mu.Unlock()
+
+	timer := time.NewTimer(m.settings.QueueTimeout)
+	defer timer.Stop()
+
+	select {
+	case <-ready:
+		return true
+	case <-timer.C:
+	case <-r.Context().Done():
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if i := slices.Index(m.waiters, ready); i >= 0 {
+		m.waiters = slices.Delete(m.waiters, i, i+1)
+		m.shed++
+		return false
+	}
+
+	return true
+}
+
+func (m *ConcurrencyMiddleware) release(latency time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.inFlight--
+
+	if m.settings.Mode == ConcurrencyModeAdaptive {
+		m.adapt(latency)
+	}
+
+	for len(m.waiters) > 0 && m.inFlight < m.currentLimit() {
+		ready := m.waiters[0]
+		m.waiters = m.waiters[1:]
+		m.inFlight++
+		m.served++
+		close(ready)
+	}
+}
+
+func (m *ConcurrencyMiddleware) adapt(latency time.Duration) {
+	minLimit := float64(m.settings.MinConcurrent)
+	maxLimit := float64(m.settings.MaxConcurrent)
+
+	if latency > m.settings.LatencyTarget {
+		now := m.now()
+		if now.Sub(m.lastDecrease) < m.settings.LatencyTarget {
+			return
+		}
+
+		m.limit = math.Max(minLimit, math.Floor(m.limit*m.settings.Backoff))
+		m.lastDecrease = now
+
+		m.logger.Info("concurrency limit decreased",
+			slog.String("type", "concurrency_limit_decreased"),
+			slog.Int("limit", m.currentLimit()),
+			slog.Duration("latency", latency),
+		)
+		return
+	}
+
+	m.limit = math.Min(maxLimit, m.limit+1/m.limit)
+}
+
+func (m *ConcurrencyMiddleware) currentLimit() int {
+	return int(m.limit)
+}
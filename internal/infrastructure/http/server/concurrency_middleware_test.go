@@ -0,0 +1,157 @@
+package server_test
+
+import (
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/biraneves/fc-labs-weather/internal/infrastructure/http/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newBlockingHandler(settings server.ConcurrencySettings) (http.Handler, *server.ConcurrencyMiddleware, chan struct{}, chan struct{}) {
+	mw := server.NewConcurrencyMiddleware(settings, slog.New(slog.NewJSONHandler(io.Discard, nil)))
+
+	started := make(chan struct{}, 10)
+	unblock := make(chan struct{})
+
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/weather" {
+			started <- struct{}{}
+			<-unblock
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+
+	return mw.Wrap(next), mw, started, unblock
+}
+
+func serveAsync(h http.Handler, path string) chan *httptest.ResponseRecorder {
+	done := make(chan *httptest.ResponseRecorder, 1)
+	go func() {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		done <- rec
+	}()
+
+	return done
+}
+
+func serveSync(h http.Handler, path string) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+
+	return rec
+}
+
+func TestConcurrencyMiddleware_ShedsExcess(t *testing.T) {
+	h, mw, started, unblock := newBlockingHandler(server.ConcurrencySettings{
+		MaxConcurrent: 1,
+		ExemptPaths:   []string{"/healthz"},
+	})
+
+	first := serveAsync(h, "/weather")
+	<-started
+
+	shed := serveSync(h, "/weather")
+	assert.Equal(t, http.StatusServiceUnavailable, shed.Code)
+	assert.Equal(t, "1", shed.Header().Get("Retry-After"))
+
+	var body map[string]string
+	require.NoError(t, json.NewDecoder(shed.Body).Decode(&body))
+	assert.Equal(t, "overloaded", body["code"])
+	assert.Equal(t, "server overloaded", body["error"])
+
+	health := serveSync(h, "/healthz")
+	assert.Equal(t, http.StatusOK, health.Code)
+
+	close(unblock)
+	assert.Equal(t, http.StatusOK, (<-first).Code)
+
+	snapshot := mw.Snapshot()
+	assert.Equal(t, server.ConcurrencyModeFixed, snapshot.Mode)
+	assert.Equal(t, 1, snapshot.Limit)
+	assert.Zero(t, snapshot.InFlight)
+	assert.Equal(t, int64(1), snapshot.Shed)
+	assert.Equal(t, int64(1), snapshot.Served)
+	assert.Equal(t, 0.5, snapshot.Ratio)
+}
+
+func TestConcurrencyMiddleware_Queue(t *testing.T) {
+	t.Run("queued request served when slot frees", func(t *testing.T) {
+		h, mw, started, unblock := newBlockingHandler(server.ConcurrencySettings{
+			MaxConcurrent: 1,
+			QueueSize:     1,
+			QueueTimeout:  5 * time.Second,
+		})
+
+		first := serveAsync(h, "/weather")
+		<-started
+
+		second := serveAsync(h, "/weather")
+		require.Eventually(t, func() bool { return mw.Snapshot().Queued == 1 }, time.Second, time.Millisecond)
+
+		shed := serveSync(h, "/weather")
+		assert.Equal(t, http.StatusServiceUnavailable, shed.Code)
+
+		close(unblock)
+		assert.Equal(t, http.StatusOK, (<-first).Code)
+		assert.Equal(t, http.StatusOK, (<-second).Code)
+		assert.Zero(t, mw.Snapshot().InFlight)
+	})
+
+	t.Run("queued request shed after timeout", func(t *testing.T) {
+		h, mw, started, unblock := newBlockingHandler(server.ConcurrencySettings{
+			MaxConcurrent: 1,
+			QueueSize:     1,
+			QueueTimeout:  20 * time.Millisecond,
+		})
+
+		first := serveAsync(h, "/weather")
+		<-started
+
+		queued := serveSync(h, "/weather")
+		assert.Equal(t, http.StatusServiceUnavailable, queued.Code)
+		assert.Zero(t, mw.Snapshot().Queued)
+
+		close(unblock)
+		assert.Equal(t, http.StatusOK, (<-first).Code)
+	})
+}
+
+func TestConcurrencyMiddleware_Adaptive(t *testing.T) {
+	clock := &rateLimitClock{now: time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)}
+	latency := 200 * time.Millisecond
+
+	mw := server.NewConcurrencyMiddleware(server.ConcurrencySettings{
+		Mode:          server.ConcurrencyModeAdaptive,
+		MaxConcurrent: 10,
+		MinConcurrent: 2,
+		LatencyTarget: 100 * time.Millisecond,
+		Backoff:       0.5,
+	}, slog.New(slog.NewJSONHandler(io.Discard, nil)))
+	mw.SetClock(clock.Now)
+
+	h := mw.Wrap(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		clock.Advance(latency)
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	serveSync(h, "/weather")
+	assert.Equal(t, 5, mw.Snapshot().Limit)
+
+	serveSync(h, "/weather")
+	serveSync(h, "/weather")
+	assert.Equal(t, 2, mw.Snapshot().Limit)
+
+	latency = 10 * time.Millisecond
+	for range 11 {
+		serveSync(h, "/weather")
+	}
+	assert.Equal(t, 5, mw.Snapshot().Limit)
+}
@@ -0,0 +1,123 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStrongETag(t *testing.T) {
+	a := strongETag([]byte(`{"temp_C":28.5}`))
+	b := strongETag([]byte(`{"temp_C":28.5}`))
+	c := strongETag([]byte(`{"temp_C":28.6}`))
+
+	assert.Equal(t, a, b)
+	assert.NotEqual(t, a, c)
+	assert.Regexp(t, `^"[0-9a-f]{32}"$`, a)
+}
+
+func TestCacheControl(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		observedAt time.Time
+		stale      bool
+		want       string
+	}{
+		{
+			name:       "fresh observation",
+			observedAt: now.Add(-5 * time.Minute),
+			want:       "public, max-age=600",
+		},
+		{
+			name:       "observation older than refresh interval",
+			observedAt: now.Add(-time.Hour),
+			want:       "public, max-age=0",
+		},
+		{
+			name: "unknown observation time",
+			want: "no-cache",
+		},
+		{
+			name:       "stale response",
+			observedAt: now.Add(-time.Minute),
+			stale:      true,
+			want:       "no-cache",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, cacheControl(tt.observedAt, now, tt.stale))
+		})
+	}
+}
+
+func TestNotModified(t *testing.T) {
+	lastModified := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	etag := `"abc"`
+
+	tests := []struct {
+		name    string
+		headers map[string]string
+		want    bool
+	}{
+		{
+			name: "no conditional headers",
+			want: false,
+		},
+		{
+			name:    "matching etag",
+			headers: map[string]string{"If-None-Match": `"abc"`},
+			want:    true,
+		},
+		{
+			name:    "matching weak etag in list",
+			headers: map[string]string{"If-None-Match": `"zzz", W/"abc"`},
+			want:    true,
+		},
+		{
+			name:    "wildcard etag",
+			headers: map[string]string{"If-None-Match": "*"},
+			want:    true,
+		},
+		{
+			name: "mismatching etag wins over if-modified-since",
+			headers: map[string]string{
+				"If-None-Match":     `"zzz"`,
+				"If-Modified-Since": lastModified.Format(http.TimeFormat),
+			},
+			want: false,
+		},
+		{
+			name:    "not modified since",
+			headers: map[string]string{"If-Modified-Since": lastModified.Format(http.TimeFormat)},
+			want:    true,
+		},
+		{
+			name:    "modified since",
+			headers: map[string]string{"If-Modified-Since": lastModified.Add(-time.Minute).Format(http.TimeFormat)},
+			want:    false,
+		},
+		{
+			name:    "invalid if-modified-since",
+			headers: map[string]string{"If-Modified-Since": "yesterday"},
+			want:    false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/weather?cep=01001000", nil)
+			for k, v := range tt.headers {
+				req.Header.Set(k, v)
+			}
+
+			assert.Equal(t, tt.want, notModified(req, etag, lastModified))
+		})
+	}
+}
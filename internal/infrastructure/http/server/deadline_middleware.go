@@ -0,0 +1,103 @@
+package server
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	defaultRequestTimeout    = 5 * time.Second
+	defaultRequestTimeoutMin = 100 * time.Millisecond
+	defaultRequestTimeoutMax = 30 * time.Second
+)
+
+type DeadlineSettings struct {
+	Default     time.Duration
+	Min         time.Duration
+	Max         time.Duration
+	ExemptPaths []string
+}
+
+type DeadlineMiddleware struct {
+	settings DeadlineSettings
+	logger   *slog.Logger
+}
+
+func NewDeadlineMiddleware(settings DeadlineSettings, logger *slog.Logger) *DeadlineMiddleware {
+	if settings.Default <= 0 {
+		settings.Default = defaultRequestTimeout
+	}
+
+	if settings.Min <= 0 {
+		settings.Min = defaultRequestTimeoutMin
+	}
+
+	if settings.Max <= 0 {
+		settings.Max = defaultRequestTimeoutMax
+	}
+
+	settings.Max = max(settings.Max, settings.Min)
+	settings.Default = min(max(settings.Default, settings.Min), settings.Max)
+
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	return &DeadlineMiddleware{settings: settings, logger: logger}
+}
+
+func (m *DeadlineMiddleware) Wrap(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if slices.Contains(m.settings.ExemptPaths, r.URL.Path) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		budget := m.budget(r)
+
+		ctx, cancel := context.WithTimeout(r.Context(), budget)
+		defer cancel()
+
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+func (m *DeadlineMiddleware) budget(r *http.Request) time.Duration {
+	raw := strings.TrimSpace(r.Header.Get("Request-Timeout"))
+	if raw == "" {
+		return m.settings.Default
+	}
+
+	requested, ok := parseRequestTimeout(raw)
+	if !ok {
+		logger := LoggerFromContext(r.Context(), m.logger)
+		logger.Warn("invalid Request-Timeout header ignored",
+			slog.String("type", "handler_error"),
+			slog.String("value", raw),
+		)
+		return m.settings.Default
+	}
+
+	return min(max(requested, m.settings.Min), m.settings.Max)
+}
+
+func parseRequestTimeout(raw string) (time.Duration, bool) {
+	if secs, err := strconv.ParseFloat(raw, 64); err == nil {
+		if secs <= 0 {
+			return 0, false
+		}
+		return time.Duration(secs * float64(time.Second)), true
+	}
+
+	d, err := time.ParseDuration(raw)
+	if err != nil || d <= 0 {
+		return 0, false
+	}
+
+	return d, true
+}
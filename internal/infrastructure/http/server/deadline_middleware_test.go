@@ -0,0 +1,101 @@
+package server_test
+
+import (
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/biraneves/fc-labs-weather/internal/infrastructure/http/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeadlineMiddleware_Wrap(t *testing.T) {
+	tests := []struct {
+		name       string
+		path       string
+		header     string
+		wantBudget time.Duration
+		noDeadline bool
+	}{
+		{
+			name:       "default budget",
+			path:       "/weather",
+			wantBudget: 2 * time.Second,
+		},
+		{
+			name:       "seconds header",
+			path:       "/weather",
+			header:     "1.5",
+			wantBudget: 1500 * time.Millisecond,
+		},
+		{
+			name:       "duration header",
+			path:       "/weather",
+			header:     "800ms",
+			wantBudget: 800 * time.Millisecond,
+		},
+		{
+			name:       "header clamped to max",
+			path:       "/weather",
+			header:     "60",
+			wantBudget: 5 * time.Second,
+		},
+		{
+			name:       "header clamped to min",
+			path:       "/weather",
+			header:     "10ms",
+			wantBudget: 500 * time.Millisecond,
+		},
+		{
+			name:       "invalid header ignored",
+			path:       "/weather",
+			header:     "soon",
+			wantBudget: 2 * time.Second,
+		},
+		{
+			name:       "exempt path",
+			path:       "/healthz",
+			header:     "1",
+			noDeadline: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mw := server.NewDeadlineMiddleware(server.DeadlineSettings{
+				Default:     2 * time.Second,
+				Min:         500 * time.Millisecond,
+				Max:         5 * time.Second,
+				ExemptPaths: []string{"/healthz"},
+			}, slog.New(slog.NewJSONHandler(io.Discard, nil)))
+
+			var (
+				deadline time.Time
+				ok       bool
+			)
+			h := mw.Wrap(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				deadline, ok = r.Context().Deadline()
+			}))
+
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			if tt.header != "" {
+				req.Header.Set("Request-Timeout", tt.header)
+			}
+
+			start := time.Now()
+			h.ServeHTTP(httptest.NewRecorder(), req)
+
+			if tt.noDeadline {
+				assert.False(t, ok)
+				return
+			}
+
+			require.True(t, ok)
+			assert.WithinDuration(t, start.Add(tt.wantBudget), deadline, 100*time.Millisecond)
+		})
+	}
+}
@@ -1,13 +1,18 @@
 package server
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 	"log/slog"
+	"math"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/biraneves/fc-labs-weather/internal/application/dto"
 	"github.com/biraneves/fc-labs-weather/internal/application/ports/inbound"
+	"github.com/biraneves/fc-labs-weather/internal/application/ports/outbound"
 	"github.com/biraneves/fc-labs-weather/internal/application/usecase"
 	"github.com/biraneves/fc-labs-weather/internal/domain/entity"
 )
@@ -15,22 +20,48 @@ import (
 type Handler struct {
 	useCase inbound.GetWeatherByCEPUseCase
 	logger  *slog.Logger
+	now     func() time.Time
 }
 
 func NewHandler(uc inbound.GetWeatherByCEPUseCase, logger *slog.Logger) *Handler {
-	return &Handler{useCase: uc, logger: logger}
+	return &Handler{useCase: uc, logger: logger, now: time.Now}
 }
 
 func (h *Handler) RegisterRoutes(mux *http.ServeMux) {
 	mux.HandleFunc("GET /weather", h.handleWeather)
+	mux.HandleFunc("GET /v2/weather", h.handleWeatherV2)
 	mux.HandleFunc("GET /healthz", h.handleHealth)
 }
 
 type errorBody struct {
 	Error string `json:"error"`
+	Code  string `json:"code,omitempty"`
+}
+
+type upstreamErrorMapping struct {
+	target  error
+	status  int
+	code    string
+	message string
+}
+
+var upstreamErrors = []upstreamErrorMapping{
+	{outbound.ErrUpstreamTimeout, http.StatusGatewayTimeout, "upstream_timeout", "upstream timeout"},
+	{outbound.ErrUpstreamQuotaExceeded, http.StatusServiceUnavailable, "upstream_quota_exceeded", "upstream quota exceeded"},
+	{outbound.ErrUpstreamUnavailable, http.StatusServiceUnavailable, "upstream_unavailable", "upstream unavailable"},
+	{outbound.ErrUpstreamUnauthorized, http.StatusBadGateway, "upstream_unauthorized", "upstream rejected credentials"},
+	{outbound.ErrUpstreamMalformedPayload, http.StatusBadGateway, "upstream_malformed_payload", "upstream returned malformed payload"},
 }
 
 func (h *Handler) handleWeather(w http.ResponseWriter, r *http.Request) {
+	h.serveWeather(w, r, false)
+}
+
+func (h *Handler) handleWeatherV2(w http.ResponseWriter, r *http.Request) {
+	h.serveWeather(w, r, true)
+}
+
+func (h *Handler) serveWeather(w http.ResponseWriter, r *http.Request, detailed bool) {
 	logger := LoggerFromContext(r.Context(), h.logger)
 
 	if r.Method != http.MethodGet {
@@ -54,6 +85,14 @@ func (h *Handler) handleWeather(w http.ResponseWriter, r *http.Request) {
 	}
 
 	cepToSearch, err := entity.NewCep(cepParam)
+	if errors.Is(err, entity.ErrZipCodeUnallocated) {
+		logger.Info("cep outside allocated ranges",
+			slog.String("type", "handler_error"),
+			slog.String("cep", cepParam),
+		)
+		writeError(w, http.StatusNotFound, usecase.ErrZipcodeNotFound.Error())
+		return
+	}
 	if err != nil {
 		logger.Warn("invalid cep received",
 			slog.String("type", "handler_error"),
@@ -64,13 +103,27 @@ func (h *Handler) handleWeather(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	format, err := parseResponseFormat(r.URL.Query(), r.Header.Get("Accept-Language"), detailed)
+	if err != nil {
+		logger.Warn("invalid response format requested",
+			slog.String("type", "handler_error"),
+			slog.String("query", r.URL.RawQuery),
+			slog.String("error", err.Error()),
+		)
+		writeErrorCode(w, http.StatusBadRequest, "invalid_parameter", err.Error())
+		return
+	}
+
 	out, err := h.useCase.Execute(r.Context(), dto.RequestInDto{CEP: cepToSearch})
 	if err != nil {
+		var openErr *outbound.CircuitOpenError
+		mapping, isUpstream := mapUpstreamError(err)
+
 		switch {
 		case errors.Is(err, usecase.ErrInvalidZipCode):
 			logger.Warn("use case rejected cep as invalid",
 				slog.String("type", "handler_error"),
-				slog.String("cep", cepParam),
+				slog.String("cep", cepToSearch.Formatted()),
 			)
 			writeError(w, http.StatusUnprocessableEntity, err.Error())
 			return
@@ -78,15 +131,54 @@ func (h *Handler) handleWeather(w http.ResponseWriter, r *http.Request) {
 		case errors.Is(err, usecase.ErrZipcodeNotFound):
 			logger.Info("zipcode not found",
 				slog.String("type", "handler_error"),
-				slog.String("cep", cepParam),
+				slog.String("cep", cepToSearch.Formatted()),
 			)
 			writeError(w, http.StatusNotFound, err.Error())
 			return
 
+		case errors.Is(err, outbound.ErrWeatherLocationNotFound):
+			logger.Info("weather location not found",
+				slog.String("type", "handler_error"),
+				slog.String("cep", cepToSearch.Formatted()),
+				slog.String("error", err.Error()),
+			)
+			writeErrorCode(w, http.StatusNotFound, "location_not_found", "cannot find weather for location")
+			return
+
+		case errors.Is(err, usecase.ErrDeadlineExceeded):
+			logger.Warn("request deadline exceeded",
+				slog.String("type", "handler_error"),
+				slog.String("cep", cepToSearch.Formatted()),
+				slog.String("error", err.Error()),
+			)
+			writeErrorCode(w, http.StatusGatewayTimeout, "deadline_exceeded", usecase.ErrDeadlineExceeded.Error())
+			return
+
+		case errors.As(err, &openErr):
+			logger.Warn("upstream circuit open",
+				slog.String("type", "handler_error"),
+				slog.String("cep", cepToSearch.Formatted()),
+				slog.String("upstream", openErr.Upstream),
+				slog.Duration("retry_after", openErr.RetryAfter),
+			)
+			w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(openErr.RetryAfter.Seconds()))))
+			writeErrorCode(w, http.StatusServiceUnavailable, "upstream_circuit_open", "upstream unavailable")
+			return
+
+		case isUpstream:
+			logger.Error("upstream failure executing use case",
+				slog.String("type", "handler_error"),
+				slog.String("cep", cepToSearch.Formatted()),
+				slog.String("code", mapping.code),
+				slog.String("error", err.Error()),
+			)
+			writeErrorCode(w, mapping.status, mapping.code, mapping.message)
+			return
+
 		default:
 			logger.Error("unexpected failure executing use case",
 				slog.String("type", "handler_error"),
-				slog.String("cep", cepParam),
+				slog.String("cep", cepToSearch.Formatted()),
 				slog.String("error", err.Error()),
 			)
 			writeError(w, http.StatusInternalServerError, "internal error")
@@ -94,9 +186,60 @@ func (h *Handler) handleWeather(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	if out.Stale {
+		logger.Warn("serving stale weather data",
+			slog.String("type", "handler_stale"),
+			slog.String("cep", cepToSearch.Formatted()),
+		)
+		w.Header().Set("X-Cache-Status", "stale")
+	}
+
+	if out.Provider != "" {
+		w.Header().Set("X-Weather-Provider", out.Provider)
+	}
+
+	out, err = format.apply(out)
+	if err != nil {
+		logger.Error("failed to format response",
+			slog.String("type", "handler_error"),
+			slog.String("cep", cepToSearch.Formatted()),
+			slog.String("error", err.Error()),
+		)
+		writeError(w, http.StatusInternalServerError, "internal error")
+		return
+	}
+
+	var body bytes.Buffer
+	if err := json.NewEncoder(&body).Encode(out); err != nil {
+		logger.Error("failed to encode response",
+			slog.String("type", "handler_error"),
+			slog.String("cep", cepToSearch.Formatted()),
+			slog.String("error", err.Error()),
+		)
+		writeError(w, http.StatusInternalServerError, "internal error")
+		return
+	}
+
+	w.Header().Set("Vary", "Accept-Language")
+	if format.localized {
+		w.Header().Set("Content-Language", format.locale.Tag)
+	}
+
+	etag := strongETag(body.Bytes())
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Cache-Control", cacheControl(out.ObservedAt, h.now(), out.Stale))
+	if !out.ObservedAt.IsZero() {
+		w.Header().Set("Last-Modified", out.ObservedAt.UTC().Format(http.TimeFormat))
+	}
+
+	if notModified(r, etag, out.ObservedAt) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
-	_ = json.NewEncoder(w).Encode(out)
+	_, _ = w.Write(body.Bytes())
 }
 
 func (h *Handler) handleHealth(w http.ResponseWriter, r *http.Request) {
@@ -110,8 +253,22 @@ func (h *Handler) handleHealth(w http.ResponseWriter, r *http.Request) {
 	_ = json.NewEncoder(w).Encode(map[string]string{"status": "ok"})
 }
 
+func mapUpstreamError(err error) (upstreamErrorMapping, bool) {
+	for _, mapping := range upstreamErrors {
+		if errors.Is(err, mapping.target) {
+			return mapping, true
+		}
+	}
+
+	return upstreamErrorMapping{}, false
+}
+
 func writeError(w http.ResponseWriter, status int, message string) {
+	writeErrorCode(w, status, "", message)
+}
+
+func writeErrorCode(w http.ResponseWriter, status int, code, message string) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(status)
-	_ = json.NewEncoder(w).Encode(errorBody{Error: message})
+	_ = json.NewEncoder(w).Encode(errorBody{Error: message, Code: code})
 }
@@ -3,15 +3,18 @@ package server_test
 import (
 	"context"
 	"errors"
+	"fmt"
 	"io"
 	"log/slog"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/biraneves/fc-labs-weather/internal/application/dto"
 	"github.com/biraneves/fc-labs-weather/internal/application/ports/inbound"
+	"github.com/biraneves/fc-labs-weather/internal/application/ports/outbound"
 	"github.com/biraneves/fc-labs-weather/internal/application/usecase"
 	"github.com/biraneves/fc-labs-weather/internal/domain/entity"
 	"github.com/biraneves/fc-labs-weather/internal/infrastructure/http/server"
@@ -41,15 +44,29 @@ var _ inbound.GetWeatherByCEPUseCase = (*fakeUseCase)(nil)
 var noopLogger = slog.New(slog.NewJSONHandler(io.Discard, nil))
 
 func TestHandler_HandleWeather(t *testing.T) {
-	tempC, err := entity.NewTemperatureCelsius(28.5)
+	tempC, err := entity.NewTemperature(28.5, entity.Celsius)
 	require.NoError(t, err)
 
-	tempF, err := entity.NewTemperatureFahrenheit(tempC.ToFahrenheit())
+	tempF := tempC.In(entity.Fahrenheit)
+	tempK := tempC.In(entity.Kelvin)
+
+	weatherAPIReading, err := entity.NewTemperature(27, entity.Celsius)
 	require.NoError(t, err)
 
-	tempK, err := entity.NewTemperatureKelvin(tempC.ToKelvin())
+	openMeteoReading, err := entity.NewTemperature(30.06, entity.Celsius)
 	require.NoError(t, err)
 
+	ensemble := &dto.EnsembleOutDto{
+		Strategy:     "median",
+		SpreadC:      3.06,
+		ThresholdC:   2,
+		Disagreement: true,
+		Readings: []dto.EnsembleReadingOutDto{
+			{Provider: "weatherapi", TemperaturesDto: dto.TemperaturesDto{TempC: weatherAPIReading}},
+			{Provider: "openmeteo", TemperaturesDto: dto.TemperaturesDto{TempC: openMeteoReading}},
+		},
+	}
+
 	type fields struct {
 		useCaseResp dto.RequestOutDto
 		useCaseErr  error
@@ -63,6 +80,7 @@ func TestHandler_HandleWeather(t *testing.T) {
 		expectedCEP       entity.Cep
 		method            string
 		url               string
+		headers           map[string]string
 	}
 
 	tests := []struct {
@@ -88,6 +106,206 @@ func TestHandler_HandleWeather(t *testing.T) {
 				url:               "/weather?cep=01001000",
 			},
 		},
+		{
+			name: "stale response",
+			f: fields{
+				useCaseResp: dto.RequestOutDto{
+					TempC: tempC,
+					TempF: tempF,
+					TempK: tempK,
+					Stale: true,
+				},
+			},
+			exp: expectations{
+				status:            http.StatusOK,
+				bodyEquals:        `{"temp_C":28.5,"temp_F":83.3,"temp_K":301.7,"stale":true}`,
+				expectUseCaseCall: true,
+				expectedCEP:       entity.Cep("01001000"),
+				method:            http.MethodGet,
+				url:               "/weather?cep=01001000",
+				headers:           map[string]string{"X-Cache-Status": "stale"},
+			},
+		},
+		{
+			name: "serving provider",
+			f: fields{
+				useCaseResp: dto.RequestOutDto{
+					TempC:    tempC,
+					TempF:    tempF,
+					TempK:    tempK,
+					Provider: "openmeteo",
+				},
+			},
+			exp: expectations{
+				status:            http.StatusOK,
+				bodyEquals:        `{"temp_C":28.5,"temp_F":83.3,"temp_K":301.7}`,
+				expectUseCaseCall: true,
+				expectedCEP:       entity.Cep("01001000"),
+				method:            http.MethodGet,
+				url:               "/weather?cep=01001000",
+				headers:           map[string]string{"X-Weather-Provider": "openmeteo"},
+			},
+		},
+		{
+			name: "ensemble omitted from basic response",
+			f: fields{
+				useCaseResp: dto.RequestOutDto{
+					TempC:    tempC,
+					TempF:    tempF,
+					TempK:    tempK,
+					Provider: "weatherapi,openmeteo",
+					Ensemble: ensemble,
+				},
+			},
+			exp: expectations{
+				status:            http.StatusOK,
+				bodyEquals:        `{"temp_C":28.5,"temp_F":83.3,"temp_K":301.7}`,
+				expectUseCaseCall: true,
+				expectedCEP:       entity.Cep("01001000"),
+				method:            http.MethodGet,
+				url:               "/weather?cep=01001000",
+				headers:           map[string]string{"X-Weather-Provider": "weatherapi,openmeteo"},
+			},
+		},
+		{
+			name: "ensemble response",
+			f: fields{
+				useCaseResp: dto.RequestOutDto{
+					TempC:    tempC,
+					TempF:    tempF,
+					TempK:    tempK,
+					Provider: "weatherapi,openmeteo",
+					Ensemble: ensemble,
+				},
+			},
+			exp: expectations{
+				status: http.StatusOK,
+				bodyEquals: `{"temp_C":28,"temp_F":83,"ensemble":{"strategy":"median","spread_C":3.06,` +
+					`"threshold_C":2,"disagreement":true,"readings":[{"provider":"weatherapi","temp_C":27,"temp_F":81},` +
+					`{"provider":"openmeteo","temp_C":30,"temp_F":86}]}}`,
+				expectUseCaseCall: true,
+				expectedCEP:       entity.Cep("01001000"),
+				method:            http.MethodGet,
+				url:               "/weather?cep=01001000&detail=full&units=C,F&precision=0&rounding=half_even",
+				headers:           map[string]string{"X-Weather-Provider": "weatherapi,openmeteo"},
+			},
+		},
+		{
+			name: "single unit",
+			f: fields{
+				useCaseResp: dto.RequestOutDto{TempC: tempC, TempF: tempF, TempK: tempK},
+			},
+			exp: expectations{
+				status:            http.StatusOK,
+				bodyEquals:        `{"temp_F":83.3}`,
+				expectUseCaseCall: true,
+				expectedCEP:       entity.Cep("01001000"),
+				method:            http.MethodGet,
+				url:               "/weather?cep=01001000&units=f",
+			},
+		},
+		{
+			name: "units with precision",
+			f: fields{
+				useCaseResp: dto.RequestOutDto{TempC: tempC, TempF: tempF, TempK: tempK},
+			},
+			exp: expectations{
+				status:            http.StatusOK,
+				bodyEquals:        `{"temp_C":28.50,"temp_K":301.65,"temp_R":542.97}`,
+				expectUseCaseCall: true,
+				expectedCEP:       entity.Cep("01001000"),
+				method:            http.MethodGet,
+				url:               "/weather?cep=01001000&units=R,K,C,K&precision=2",
+			},
+		},
+		{
+			name: "precision with rounding mode",
+			f: fields{
+				useCaseResp: dto.RequestOutDto{TempC: tempC, TempF: tempF, TempK: tempK},
+			},
+			exp: expectations{
+				status:            http.StatusOK,
+				bodyEquals:        `{"temp_C":28.5,"temp_F":83.3,"temp_K":301.6}`,
+				expectUseCaseCall: true,
+				expectedCEP:       entity.Cep("01001000"),
+				method:            http.MethodGet,
+				url:               "/weather?cep=01001000&rounding=half_even",
+			},
+		},
+		{
+			name: "zero precision",
+			f: fields{
+				useCaseResp: dto.RequestOutDto{TempC: tempC, TempF: tempF, TempK: tempK},
+			},
+			exp: expectations{
+				status:            http.StatusOK,
+				bodyEquals:        `{"temp_C":28}`,
+				expectUseCaseCall: true,
+				expectedCEP:       entity.Cep("01001000"),
+				method:            http.MethodGet,
+				url:               "/weather?cep=01001000&units=C&precision=0&rounding=floor",
+			},
+		},
+		{
+			name: "unknown unit",
+			exp: expectations{
+				status:            http.StatusBadRequest,
+				bodyEquals:        `{"error":"invalid query parameter: units: must be a comma-separated list of C,F,K,R","code":"invalid_parameter"}`,
+				expectUseCaseCall: false,
+				method:            http.MethodGet,
+				url:               "/weather?cep=01001000&units=C,X",
+			},
+		},
+		{
+			name: "unit without response field",
+			exp: expectations{
+				status:            http.StatusBadRequest,
+				bodyContains:      `invalid query parameter: units`,
+				expectUseCaseCall: false,
+				method:            http.MethodGet,
+				url:               "/weather?cep=01001000&units=Re",
+			},
+		},
+		{
+			name: "empty units",
+			exp: expectations{
+				status:            http.StatusBadRequest,
+				bodyContains:      `invalid query parameter: units`,
+				expectUseCaseCall: false,
+				method:            http.MethodGet,
+				url:               "/weather?cep=01001000&units=",
+			},
+		},
+		{
+			name: "precision out of range",
+			exp: expectations{
+				status:            http.StatusBadRequest,
+				bodyEquals:        `{"error":"invalid query parameter: precision: must be an integer between 0 and 3","code":"invalid_parameter"}`,
+				expectUseCaseCall: false,
+				method:            http.MethodGet,
+				url:               "/weather?cep=01001000&precision=4",
+			},
+		},
+		{
+			name: "precision not a number",
+			exp: expectations{
+				status:            http.StatusBadRequest,
+				bodyContains:      `invalid query parameter: precision`,
+				expectUseCaseCall: false,
+				method:            http.MethodGet,
+				url:               "/weather?cep=01001000&precision=one",
+			},
+		},
+		{
+			name: "unknown rounding mode",
+			exp: expectations{
+				status:            http.StatusBadRequest,
+				bodyEquals:        `{"error":"invalid query parameter: rounding: must be one of half_up,half_even,floor,ceil,trunc","code":"invalid_parameter"}`,
+				expectUseCaseCall: false,
+				method:            http.MethodGet,
+				url:               "/weather?cep=01001000&rounding=bankers",
+			},
+		},
 		{
 			name: "method not allowed",
 			exp: expectations{
@@ -118,6 +336,26 @@ func TestHandler_HandleWeather(t *testing.T) {
 				url:               "/weather?cep=123",
 			},
 		},
+		{
+			name: "unallocated cep range",
+			exp: expectations{
+				status:            http.StatusNotFound,
+				bodyEquals:        `{"error":"cannot find zipcode"}`,
+				expectUseCaseCall: false,
+				method:            http.MethodGet,
+				url:               "/weather?cep=00999-999",
+			},
+		},
+		{
+			name: "formatted cep",
+			exp: expectations{
+				status:            http.StatusOK,
+				expectUseCaseCall: true,
+				expectedCEP:       entity.Cep("01001000"),
+				method:            http.MethodGet,
+				url:               "/weather?cep=01.001-000",
+			},
+		},
 		{
 			name: "use case invalid zipcode",
 			f: fields{
@@ -146,6 +384,122 @@ func TestHandler_HandleWeather(t *testing.T) {
 				url:               "/weather?cep=01001000",
 			},
 		},
+		{
+			name: "upstream circuit open",
+			f: fields{
+				useCaseErr: fmt.Errorf("weather provider failed: %w", &outbound.CircuitOpenError{
+					Upstream:   "weatherapi",
+					RetryAfter: 2500 * time.Millisecond,
+				}),
+			},
+			exp: expectations{
+				status:            http.StatusServiceUnavailable,
+				bodyEquals:        `{"error":"upstream unavailable","code":"upstream_circuit_open"}`,
+				expectUseCaseCall: true,
+				expectedCEP:       entity.Cep("01001000"),
+				method:            http.MethodGet,
+				url:               "/weather?cep=01001000",
+				headers:           map[string]string{"Retry-After": "3"},
+			},
+		},
+		{
+			name: "upstream timeout",
+			f: fields{
+				useCaseErr: fmt.Errorf("weather provider failed: %w", outbound.ErrUpstreamTimeout),
+			},
+			exp: expectations{
+				status:            http.StatusGatewayTimeout,
+				bodyEquals:        `{"error":"upstream timeout","code":"upstream_timeout"}`,
+				expectUseCaseCall: true,
+				expectedCEP:       entity.Cep("01001000"),
+				method:            http.MethodGet,
+				url:               "/weather?cep=01001000",
+			},
+		},
+		{
+			name: "request deadline exceeded",
+			f: fields{
+				useCaseErr: fmt.Errorf("weather provider failed: %w: %w", usecase.ErrDeadlineExceeded, outbound.ErrUpstreamTimeout),
+			},
+			exp: expectations{
+				status:            http.StatusGatewayTimeout,
+				bodyEquals:        `{"error":"request deadline exceeded","code":"deadline_exceeded"}`,
+				expectUseCaseCall: true,
+				expectedCEP:       entity.Cep("01001000"),
+				method:            http.MethodGet,
+				url:               "/weather?cep=01001000",
+			},
+		},
+		{
+			name: "upstream unavailable",
+			f: fields{
+				useCaseErr: fmt.Errorf("zipcode lookup failed: %w", outbound.ErrUpstreamUnavailable),
+			},
+			exp: expectations{
+				status:            http.StatusServiceUnavailable,
+				bodyEquals:        `{"error":"upstream unavailable","code":"upstream_unavailable"}`,
+				expectUseCaseCall: true,
+				expectedCEP:       entity.Cep("01001000"),
+				method:            http.MethodGet,
+				url:               "/weather?cep=01001000",
+			},
+		},
+		{
+			name: "upstream quota exceeded",
+			f: fields{
+				useCaseErr: fmt.Errorf("weather provider failed: %w", outbound.ErrUpstreamQuotaExceeded),
+			},
+			exp: expectations{
+				status:            http.StatusServiceUnavailable,
+				bodyEquals:        `{"error":"upstream quota exceeded","code":"upstream_quota_exceeded"}`,
+				expectUseCaseCall: true,
+				expectedCEP:       entity.Cep("01001000"),
+				method:            http.MethodGet,
+				url:               "/weather?cep=01001000",
+			},
+		},
+		{
+			name: "upstream unauthorized",
+			f: fields{
+				useCaseErr: fmt.Errorf("weather provider failed: %w", outbound.ErrUpstreamUnauthorized),
+			},
+			exp: expectations{
+				status:            http.StatusBadGateway,
+				bodyEquals:        `{"error":"upstream rejected credentials","code":"upstream_unauthorized"}`,
+				expectUseCaseCall: true,
+				expectedCEP:       entity.Cep("01001000"),
+				method:            http.MethodGet,
+				url:               "/weather?cep=01001000",
+			},
+		},
+		{
+			name: "upstream malformed payload",
+			f: fields{
+				useCaseErr: fmt.Errorf("weather provider failed: %w", outbound.ErrUpstreamMalformedPayload),
+			},
+			exp: expectations{
+				status:            http.StatusBadGateway,
+				bodyEquals:        `{"error":"upstream returned malformed payload","code":"upstream_malformed_payload"}`,
+				expectUseCaseCall: true,
+				expectedCEP:       entity.Cep("01001000"),
+				method:            http.MethodGet,
+				url:               "/weather?cep=01001000",
+			},
+		},
+		{
+			name: "weather location not found",
+			f: fields{
+				useCaseErr: fmt.Errorf("weather provider failed: %w", outbound.ErrWeatherLocationNotFound),
+			},
+			exp: expectations{
+				status:            http.StatusNotFound,
+				bodyEquals:        `{"error":"cannot find weather for location","code":"location_not_found"}`,
+				expectUseCaseCall: true,
+				expectedCEP:       entity.Cep("01001000"),
+				method:            http.MethodGet,
+				url:               "/weather?cep=01001000",
+			},
+		},
 		{
 			name: "use case generic error",
 			f: fields{
@@ -182,6 +536,10 @@ func TestHandler_HandleWeather(t *testing.T) {
 				assert.True(t, strings.Contains(rec.Body.String(), tt.exp.bodyContains))
 			}
 
+			for k, v := range tt.exp.headers {
+				assert.Equal(t, v, rec.Header().Get(k))
+			}
+
 			assert.Equal(t, tt.exp.expectUseCaseCall, uc.called)
 			if tt.exp.expectUseCaseCall {
 				assert.Equal(t, tt.exp.expectedCEP, uc.received.CEP)
@@ -189,3 +547,287 @@ func TestHandler_HandleWeather(t *testing.T) {
 		})
 	}
 }
+
+func TestHandler_HandleWeather_Localization(t *testing.T) {
+	tempC, err := entity.NewTemperature(28.5, entity.Celsius)
+	require.NoError(t, err)
+
+	out := dto.RequestOutDto{TempC: tempC, TempF: tempC.In(entity.Fahrenheit), TempK: tempC.In(entity.Kelvin)}
+
+	tests := []struct {
+		name            string
+		url             string
+		acceptLanguage  string
+		wantStatus      int
+		wantBody        string
+		wantContentLang string
+	}{
+		{
+			name:       "no language preference",
+			url:        "/weather?cep=01001000",
+			wantStatus: http.StatusOK,
+			wantBody:   `{"temp_C":28.5,"temp_F":83.3,"temp_K":301.7}`,
+		},
+		{
+			name:            "lang parameter",
+			url:             "/weather?cep=01001000&lang=pt-BR",
+			wantStatus:      http.StatusOK,
+			wantBody:        `{"temp_C":28.5,"temp_F":83.3,"temp_K":301.7,"formatted":{"temp_C":"28,5 °C","temp_F":"83,3 °F","temp_K":"301,7 K"}}`,
+			wantContentLang: "pt-BR",
+		},
+		{
+			name:            "lang parameter wins over header",
+			url:             "/weather?cep=01001000&lang=en-US&units=F,R&precision=2",
+			acceptLanguage:  "es-ES",
+			wantStatus:      http.StatusOK,
+			wantBody:        `{"temp_F":83.30,"temp_R":542.97,"formatted":{"temp_F":"83.30°F","temp_R":"542.97°R"}}`,
+			wantContentLang: "en-US",
+		},
+		{
+			name:            "accept-language by quality",
+			url:             "/weather?cep=01001000&units=C",
+			acceptLanguage:  "fr-FR, en;q=0.5, es;q=0.8",
+			wantStatus:      http.StatusOK,
+			wantBody:        `{"temp_C":28.5,"formatted":{"temp_C":"28,5 °C"}}`,
+			wantContentLang: "es-ES",
+		},
+		{
+			name:            "accept-language wildcard",
+			url:             "/weather?cep=01001000&units=K",
+			acceptLanguage:  "de-DE, *;q=0.1",
+			wantStatus:      http.StatusOK,
+			wantBody:        `{"temp_K":301.7,"formatted":{"temp_K":"301,7 K"}}`,
+			wantContentLang: "pt-BR",
+		},
+		{
+			name:           "unsupported accept-language",
+			url:            "/weather?cep=01001000&units=C",
+			acceptLanguage: "de-DE, fr;q=0.9, en;q=0",
+			wantStatus:     http.StatusOK,
+			wantBody:       `{"temp_C":28.5}`,
+		},
+		{
+			name:       "unsupported lang parameter",
+			url:        "/weather?cep=01001000&lang=fr-FR",
+			wantStatus: http.StatusBadRequest,
+			wantBody:   `{"error":"invalid query parameter: lang: must be one of pt-BR,en-US,es-ES","code":"invalid_parameter"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := server.NewHandler(&fakeUseCase{resp: out}, noopLogger)
+			mux := http.NewServeMux()
+			handler.RegisterRoutes(mux)
+
+			req := httptest.NewRequest(http.MethodGet, tt.url, nil)
+			if tt.acceptLanguage != "" {
+				req.Header.Set("Accept-Language", tt.acceptLanguage)
+			}
+
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, req)
+
+			require.Equal(t, tt.wantStatus, rec.Code)
+			assert.JSONEq(t, tt.wantBody, rec.Body.String())
+			assert.Equal(t, tt.wantContentLang, rec.Header().Get("Content-Language"))
+			if tt.wantStatus == http.StatusOK {
+				assert.Equal(t, "Accept-Language", rec.Header().Get("Vary"))
+			}
+		})
+	}
+}
+
+func TestHandler_HandleWeather_Detail(t *testing.T) {
+	tempC, err := entity.NewTemperature(28.5, entity.Celsius)
+	require.NoError(t, err)
+
+	feelsLike, err := entity.NewTemperature(31.26, entity.Celsius)
+	require.NoError(t, err)
+
+	humidity, err := entity.NewRelativeHumidity(65)
+	require.NoError(t, err)
+
+	windSpeed, err := entity.NewWindSpeed(11.2, entity.KilometersPerHour)
+	require.NoError(t, err)
+
+	windDirection, err := entity.NewWindDirection(220)
+	require.NoError(t, err)
+
+	pressure, err := entity.NewPressure(1015, entity.Millibar)
+	require.NoError(t, err)
+
+	visibility, err := entity.NewVisibility(10, entity.Kilometers)
+	require.NoError(t, err)
+
+	observedAt := time.Date(2025, 1, 1, 11, 55, 0, 0, time.UTC)
+	out := dto.RequestOutDto{
+		TempC:    tempC,
+		TempF:    tempC.In(entity.Fahrenheit),
+		TempK:    tempC.In(entity.Kelvin),
+		Location: &dto.LocationDto{CEP: "01001-000", City: "São Paulo", UF: "SP"},
+		Conditions: &dto.ConditionsDto{
+			ObservedAt: observedAt,
+			Text:       "Partly cloudy",
+			Code:       1003,
+			Humidity:   humidity,
+			Wind:       dto.WindDto{Speed: windSpeed, Direction: windDirection},
+			Pressure:   pressure,
+			Visibility: visibility,
+			FeelsLike:  dto.TemperaturesDto{TempC: feelsLike, TempF: feelsLike.In(entity.Fahrenheit), TempK: feelsLike.In(entity.Kelvin)},
+		},
+		ObservedAt: observedAt,
+	}
+
+	basic := `{"temp_C":28.5,"temp_F":83.3,"temp_K":301.7}`
+	full := `{"temp_C":28.5,"temp_F":83.3,"temp_K":301.7,` +
+		`"location":{"cep":"01001-000","city":"São Paulo","uf":"SP"},` +
+		`"conditions":{"observed_at":"2025-01-01T11:55:00Z","text":"Partly cloudy","code":1003,"humidity":65.0,` +
+		`"wind":{"speed_kph":11.2,"degree":220.0},"pressure_mb":1015.0,"visibility_km":10.0,` +
+		`"feels_like":{"temp_C":31.3,"temp_F":88.3,"temp_K":304.4}}}`
+
+	tests := []struct {
+		name       string
+		url        string
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name:       "basic by default",
+			url:        "/weather?cep=01001000",
+			wantStatus: http.StatusOK,
+			wantBody:   basic,
+		},
+		{
+			name:       "full detail on request",
+			url:        "/weather?cep=01001000&detail=full",
+			wantStatus: http.StatusOK,
+			wantBody:   full,
+		},
+		{
+			name:       "full detail on v2",
+			url:        "/v2/weather?cep=01001000",
+			wantStatus: http.StatusOK,
+			wantBody:   full,
+		},
+		{
+			name:       "basic detail on v2",
+			url:        "/v2/weather?cep=01001000&detail=basic",
+			wantStatus: http.StatusOK,
+			wantBody:   basic,
+		},
+		{
+			name:       "nested temperatures follow units and locale",
+			url:        "/v2/weather?cep=01001000&units=F&precision=0&lang=en-US",
+			wantStatus: http.StatusOK,
+			wantBody: `{"temp_F":83,"formatted":{"temp_F":"83°F"},` +
+				`"location":{"cep":"01001-000","city":"São Paulo","uf":"SP"},` +
+				`"conditions":{"observed_at":"2025-01-01T11:55:00Z","text":"Partly cloudy","code":1003,"humidity":65.0,` +
+				`"wind":{"speed_kph":11.2,"degree":220.0},"pressure_mb":1015.0,"visibility_km":10.0,` +
+				`"feels_like":{"temp_F":88,"formatted":{"temp_F":"88°F"}}}}`,
+		},
+		{
+			name:       "unknown detail level",
+			url:        "/weather?cep=01001000&detail=verbose",
+			wantStatus: http.StatusBadRequest,
+			wantBody:   `{"error":"invalid query parameter: detail: must be one of basic,full","code":"invalid_parameter"}`,
+		},
+		{
+			name:       "invalid cep on v2",
+			url:        "/v2/weather?cep=123",
+			wantStatus: http.StatusUnprocessableEntity,
+			wantBody:   `{"error":"invalid zipcode"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := server.NewHandler(&fakeUseCase{resp: out}, noopLogger)
+			mux := http.NewServeMux()
+			handler.RegisterRoutes(mux)
+
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.url, nil))
+
+			require.Equal(t, tt.wantStatus, rec.Code)
+			assert.JSONEq(t, tt.wantBody, rec.Body.String())
+		})
+	}
+}
+
+func TestHandler_HandleWeather_ConditionalRequests(t *testing.T) {
+	tempC, err := entity.NewTemperature(28.5, entity.Celsius)
+	require.NoError(t, err)
+
+	tempF := tempC.In(entity.Fahrenheit)
+	tempK := tempC.In(entity.Kelvin)
+
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	observedAt := now.Add(-5 * time.Minute)
+
+	out := dto.RequestOutDto{TempC: tempC, TempF: tempF, TempK: tempK, ObservedAt: observedAt}
+
+	newMux := func() *http.ServeMux {
+		handler := server.NewHandler(&fakeUseCase{resp: out}, noopLogger)
+		handler.SetClock(func() time.Time { return now })
+		mux := http.NewServeMux()
+		handler.RegisterRoutes(mux)
+		return mux
+	}
+
+	first := httptest.NewRecorder()
+	newMux().ServeHTTP(first, httptest.NewRequest(http.MethodGet, "/weather?cep=01001000", nil))
+	require.Equal(t, http.StatusOK, first.Code)
+
+	etag := first.Header().Get("ETag")
+	require.NotEmpty(t, etag)
+	assert.Equal(t, "public, max-age=600", first.Header().Get("Cache-Control"))
+	assert.Equal(t, observedAt.Format(http.TimeFormat), first.Header().Get("Last-Modified"))
+
+	tests := []struct {
+		name       string
+		headers    map[string]string
+		wantStatus int
+	}{
+		{
+			name:       "if-none-match hit",
+			headers:    map[string]string{"If-None-Match": etag},
+			wantStatus: http.StatusNotModified,
+		},
+		{
+			name:       "if-none-match miss",
+			headers:    map[string]string{"If-None-Match": `"other"`},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "if-modified-since hit",
+			headers:    map[string]string{"If-Modified-Since": observedAt.Format(http.TimeFormat)},
+			wantStatus: http.StatusNotModified,
+		},
+		{
+			name:       "if-modified-since miss",
+			headers:    map[string]string{"If-Modified-Since": observedAt.Add(-time.Hour).Format(http.TimeFormat)},
+			wantStatus: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/weather?cep=01001000", nil)
+			for k, v := range tt.headers {
+				req.Header.Set(k, v)
+			}
+			rec := httptest.NewRecorder()
+
+			newMux().ServeHTTP(rec, req)
+
+			require.Equal(t, tt.wantStatus, rec.Code)
+			assert.Equal(t, etag, rec.Header().Get("ETag"))
+			if tt.wantStatus == http.StatusNotModified {
+				assert.Empty(t, rec.Body.String())
+			} else {
+				assert.Equal(t, first.Body.String(), rec.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,239 @@
+package server
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"math"
+	"net"
+	"net/http"
+	"net/netip"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultRateLimitRPS     = 5
+	defaultRateLimitBurst   = 10
+	defaultRateLimitIdleTTL = 10 * time.Minute
+	defaultRateLimitClients = 10000
+	defaultAPIKeyHeader     = "X-API-Key"
+)
+
+type RateLimitSettings struct {
+	RequestsPerSecond float64
+	Burst             int
+	IdleTTL           time.Duration
+	TrustedProxies    []netip.Prefix
+	APIKeyHeader      string
+	APIKeys           []string
+	MaxClients        int
+	ExemptPaths       []string
+}
+
+type tokenBucket struct {
+	client   string
+	tokens   float64
+	lastSeen time.Time
+}
+
+type RateLimitMiddleware struct {
+	settings RateLimitSettings
+	logger   *slog.Logger
+	now      func() time.Time
+
+	apiKeys map[string]string
+
+	mu      sync.Mutex
+	buckets map[string]*list.Element
+	order   *list.List
+}
+
+func NewRateLimitMiddleware(settings RateLimitSettings, logger *slog.Logger) *RateLimitMiddleware {
+	if settings.RequestsPerSecond <= 0 {
+		settings.RequestsPerSecond = defaultRateLimitRPS
+	}
+
+	if settings.Burst <= 0 {
+		settings.Burst = defaultRateLimitBurst
+	}
+
+	if settings.IdleTTL <= 0 {
+		settings.IdleTTL = defaultRateLimitIdleTTL
+	}
+
+	if settings.MaxClients <= 0 {
+		settings.MaxClients = defaultRateLimitClients
+	}
+
+	if settings.APIKeyHeader == "" {
+		settings.APIKeyHeader = defaultAPIKeyHeader
+	}
+
+	apiKeys := make(map[string]string, len(settings.APIKeys))
+	for _, key := range settings.APIKeys {
+		if key = strings.TrimSpace(key); key != "" {
+			sum := sha256.Sum256([]byte(key))
+			apiKeys[key] = "key:" + hex.EncodeToString(sum[:4])
+		}
+	}
+
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	return &RateLimitMiddleware{
+		settings: settings,
+		logger:   logger,
+		now:      time.Now,
+		apiKeys:  apiKeys,
+		buckets:  make(map[string]*list.Element),
+		order:    list.New(),
+	}
+}
+
+func (m *RateLimitMiddleware) Wrap(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if slices.Contains(m.settings.ExemptPaths, r.URL.Path) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		client := m.clientKey(r)
+		allowed, remaining, wait := m.take(client)
+
+		window := math.Ceil(float64(m.settings.Burst) / m.settings.RequestsPerSecond)
+		refill := math.Ceil((float64(m.settings.Burst) - remaining) / m.settings.RequestsPerSecond)
+
+		w.Header().Set("RateLimit-Policy", fmt.Sprintf("%d;w=%d", m.settings.Burst, int(window)))
+		w.Header().Set("RateLimit-Limit", strconv.Itoa(m.settings.Burst))
+		w.Header().Set("RateLimit-Remaining", strconv.Itoa(int(math.Floor(remaining))))
+		w.Header().Set("RateLimit-Reset", strconv.Itoa(int(refill)))
+
+		if !allowed {
+			logger := LoggerFromContext(r.Context(), m.logger)
+			logger.Warn("rate limit exceeded",
+				slog.String("type", "rate_limited"),
+				slog.String("client", client),
+				slog.String("path", r.URL.Path),
+				slog.Duration("retry_after", wait),
+			)
+
+			w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+			writeErrorCode(w, http.StatusTooManyRequests, "rate_limited", "too many requests")
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (m *RateLimitMiddleware) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return len(m.buckets)
+}
+
+func (m *RateLimitMiddleware) take(client string) (bool, float64, time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.now()
+	m.sweep(now)
+
+	burst := float64(m.settings.Burst)
+
+	var b *tokenBucket
+	if elem, ok := m.buckets[client]; ok {
+		m.order.MoveToFront(elem)
+		b = elem.Value.(*tokenBucket)
+	} else {
+		if len(m.buckets) >= m.settings.MaxClients {
+			m.evict(m.order.Back())
+		}
+		b = &tokenBucket{client: client, tokens: burst, lastSeen: now}
+		m.buckets[client] = m.order.PushFront(b)
+	}
+
+	elapsed := now.Sub(b.lastSeen).Seconds()
+	b.tokens = math.Min(burst, b.tokens+elapsed*m.settings.RequestsPerSecond)
+	b.lastSeen = now
+
+	if b.tokens < 1 {
+		wait := time.Duration((1 - b.tokens) / m.settings.RequestsPerSecond * float64(time.Second))
+		return false, b.tokens, wait
+	}
+
+	b.tokens--
+	return true, b.tokens, 0
+}
+
+func (m *RateLimitMiddleware) sweep(now time.Time) {
+	for elem := m.order.Back(); elem != nil; elem = m.order.Back() {
+		if now.Sub(elem.Value.(*tokenBucket).lastSeen) < m.settings.IdleTTL {
+			return
+		}
+		m.evict(elem)
+	}
+}
+
+func (m *RateLimitMiddleware) evict(elem *list.Element) {
+	if elem == nil {
+		return
+	}
+
+	m.order.Remove(elem)
+	delete(m.buckets, elem.Value.(*tokenBucket).client)
+}
+
+func (m *RateLimitMiddleware) clientKey(r *http.Request) string {
+	if id, ok := m.apiKeys[strings.TrimSpace(r.Header.Get(m.settings.APIKeyHeader))]; ok {
+		return id
+	}
+
+	return "ip:" + m.clientIP(r)
+}
+
+func (m *RateLimitMiddleware) clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+
+	remote, err := netip.ParseAddr(host)
+	if err != nil || !m.trusted(remote) {
+		return host
+	}
+
+	hops := strings.Split(strings.Join(r.Header.Values("X-Forwarded-For"), ","), ",")
+	for i := len(hops) - 1; i >= 0; i-- {
+		hop, err := netip.ParseAddr(strings.TrimSpace(hops[i]))
+		if err != nil {
+			break
+		}
+
+		if !m.trusted(hop) {
+			return hop.String()
+		}
+		remote = hop
+	}
+
+	return remote.String()
+}
+
+func (m *RateLimitMiddleware) trusted(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	for _, prefix := range m.settings.TrustedProxies {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+
+	return false
+}
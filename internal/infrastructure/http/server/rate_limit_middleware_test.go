@@ -0,0 +1,215 @@
+package server_test
+
+import (
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/biraneves/fc-labs-weather/internal/infrastructure/http/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type rateLimitClock struct {
+	now time.Time
+}
+
+func (c *rateLimitClock) Now() time.Time {
+	return c.now
+}
+
+func (c *rateLimitClock) Advance(d time.Duration) {
+	c.now = c.now.Add(d)
+}
+
+func newRateLimitedHandler(settings server.RateLimitSettings) (http.Handler, *server.RateLimitMiddleware, *rateLimitClock) {
+	clock := &rateLimitClock{now: time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)}
+
+	mw := server.NewRateLimitMiddleware(settings, slog.New(slog.NewJSONHandler(io.Discard, nil)))
+	mw.SetClock(clock.Now)
+
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	return mw.Wrap(next), mw, clock
+}
+
+func serveRateLimited(h http.Handler, remoteAddr string, headers map[string]string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/weather?cep=01001000", nil)
+	req.RemoteAddr = remoteAddr
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	return rec
+}
+
+func TestRateLimitMiddleware_Wrap(t *testing.T) {
+	h, _, clock := newRateLimitedHandler(server.RateLimitSettings{RequestsPerSecond: 1, Burst: 2})
+
+	first := serveRateLimited(h, "203.0.113.10:1234", nil)
+	assert.Equal(t, http.StatusOK, first.Code)
+	assert.Equal(t, "2", first.Header().Get("RateLimit-Limit"))
+	assert.Equal(t, "1", first.Header().Get("RateLimit-Remaining"))
+	assert.Equal(t, "1", first.Header().Get("RateLimit-Reset"))
+	assert.Equal(t, "2;w=2", first.Header().Get("RateLimit-Policy"))
+
+	second := serveRateLimited(h, "203.0.113.10:1234", nil)
+	assert.Equal(t, http.StatusOK, second.Code)
+	assert.Equal(t, "0", second.Header().Get("RateLimit-Remaining"))
+
+	limited := serveRateLimited(h, "203.0.113.10:1234", nil)
+	assert.Equal(t, http.StatusTooManyRequests, limited.Code)
+	assert.Equal(t, "1", limited.Header().Get("Retry-After"))
+	assert.Equal(t, "application/json", limited.Header().Get("Content-Type"))
+
+	var body map[string]string
+	require.NoError(t, json.NewDecoder(limited.Body).Decode(&body))
+	assert.Equal(t, "rate_limited", body["code"])
+	assert.Equal(t, "too many requests", body["error"])
+
+	other := serveRateLimited(h, "203.0.113.11:1234", nil)
+	assert.Equal(t, http.StatusOK, other.Code)
+
+	clock.Advance(time.Second)
+	refilled := serveRateLimited(h, "203.0.113.10:1234", nil)
+	assert.Equal(t, http.StatusOK, refilled.Code)
+}
+
+func TestRateLimitMiddleware_ClientKey(t *testing.T) {
+	type request struct {
+		remoteAddr string
+		headers    map[string]string
+	}
+
+	tests := []struct {
+		name        string
+		trusted     []netip.Prefix
+		first       request
+		second      request
+		sameClients bool
+	}{
+		{
+			name:        "api key shared across addresses",
+			first:       request{remoteAddr: "203.0.113.10:1234", headers: map[string]string{"X-API-Key": "abc"}},
+			second:      request{remoteAddr: "203.0.113.20:1234", headers: map[string]string{"X-API-Key": "abc"}},
+			sameClients: true,
+		},
+		{
+			name:        "distinct api keys on same address",
+			first:       request{remoteAddr: "203.0.113.10:1234", headers: map[string]string{"X-API-Key": "abc"}},
+			second:      request{remoteAddr: "203.0.113.10:1234", headers: map[string]string{"X-API-Key": "def"}},
+			sameClients: false,
+		},
+		{
+			name:        "unknown api key falls back to address",
+			first:       request{remoteAddr: "203.0.113.10:1234"},
+			second:      request{remoteAddr: "203.0.113.10:1234", headers: map[string]string{"X-API-Key": "forged"}},
+			sameClients: true,
+		},
+		{
+			name:        "unknown api keys do not split an address",
+			first:       request{remoteAddr: "203.0.113.10:1234", headers: map[string]string{"X-API-Key": "forged-1"}},
+			second:      request{remoteAddr: "203.0.113.10:1234", headers: map[string]string{"X-API-Key": "forged-2"}},
+			sameClients: true,
+		},
+		{
+			name:        "forwarded for ignored from untrusted peer",
+			first:       request{remoteAddr: "198.51.100.1:1234", headers: map[string]string{"X-Forwarded-For": "203.0.113.10"}},
+			second:      request{remoteAddr: "198.51.100.1:1234", headers: map[string]string{"X-Forwarded-For": "203.0.113.20"}},
+			sameClients: true,
+		},
+		{
+			name:        "forwarded for honored from trusted proxy",
+			trusted:     []netip.Prefix{netip.MustParsePrefix("10.0.0.0/8")},
+			first:       request{remoteAddr: "10.0.0.1:1234", headers: map[string]string{"X-Forwarded-For": "203.0.113.10"}},
+			second:      request{remoteAddr: "10.0.0.2:1234", headers: map[string]string{"X-Forwarded-For": "203.0.113.20"}},
+			sameClients: false,
+		},
+		{
+			name:        "spoofed forwarded for skipped",
+			trusted:     []netip.Prefix{netip.MustParsePrefix("10.0.0.0/8")},
+			first:       request{remoteAddr: "10.0.0.1:1234", headers: map[string]string{"X-Forwarded-For": "1.1.1.1, 203.0.113.10, 10.0.0.5"}},
+			second:      request{remoteAddr: "10.0.0.2:1234", headers: map[string]string{"X-Forwarded-For": "2.2.2.2, 203.0.113.10"}},
+			sameClients: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h, _, _ := newRateLimitedHandler(server.RateLimitSettings{
+				RequestsPerSecond: 1,
+				Burst:             1,
+				TrustedProxies:    tt.trusted,
+				APIKeys:           []string{"abc", "def"},
+			})
+
+			first := serveRateLimited(h, tt.first.remoteAddr, tt.first.headers)
+			require.Equal(t, http.StatusOK, first.Code)
+
+			second := serveRateLimited(h, tt.second.remoteAddr, tt.second.headers)
+			if tt.sameClients {
+				assert.Equal(t, http.StatusTooManyRequests, second.Code)
+			} else {
+				assert.Equal(t, http.StatusOK, second.Code)
+			}
+		})
+	}
+}
+
+func TestRateLimitMiddleware_ExemptPaths(t *testing.T) {
+	h, mw, _ := newRateLimitedHandler(server.RateLimitSettings{RequestsPerSecond: 1, Burst: 1, ExemptPaths: []string{"/healthz"}})
+
+	for range 3 {
+		req := httptest.NewRequest(http.MethodGet, "/healthz", nil)
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Empty(t, rec.Header().Get("RateLimit-Limit"))
+	}
+
+	assert.Zero(t, mw.Len())
+}
+
+func TestRateLimitMiddleware_EvictsIdleBuckets(t *testing.T) {
+	h, mw, clock := newRateLimitedHandler(server.RateLimitSettings{RequestsPerSecond: 1, Burst: 1, IdleTTL: time.Minute})
+
+	serveRateLimited(h, "203.0.113.10:1234", nil)
+	serveRateLimited(h, "203.0.113.11:1234", nil)
+	assert.Equal(t, 2, mw.Len())
+
+	clock.Advance(30 * time.Second)
+	serveRateLimited(h, "203.0.113.11:1234", nil)
+	assert.Equal(t, 2, mw.Len())
+
+	clock.Advance(45 * time.Second)
+	serveRateLimited(h, "203.0.113.12:1234", nil)
+	assert.Equal(t, 2, mw.Len())
+}
+
+func TestRateLimitMiddleware_EvictsOldestBucketWhenFull(t *testing.T) {
+	h, mw, clock := newRateLimitedHandler(server.RateLimitSettings{RequestsPerSecond: 1, Burst: 1, MaxClients: 2})
+
+	require.Equal(t, http.StatusOK, serveRateLimited(h, "203.0.113.10:1234", nil).Code)
+	clock.Advance(100 * time.Millisecond)
+	require.Equal(t, http.StatusOK, serveRateLimited(h, "203.0.113.11:1234", nil).Code)
+	clock.Advance(100 * time.Millisecond)
+	require.Equal(t, http.StatusTooManyRequests, serveRateLimited(h, "203.0.113.10:1234", nil).Code)
+
+	clock.Advance(100 * time.Millisecond)
+	require.Equal(t, http.StatusOK, serveRateLimited(h, "203.0.113.12:1234", nil).Code)
+	assert.Equal(t, 2, mw.Len())
+
+	assert.Equal(t, http.StatusTooManyRequests, serveRateLimited(h, "203.0.113.10:1234", nil).Code)
+	assert.Equal(t, http.StatusOK, serveRateLimited(h, "203.0.113.11:1234", nil).Code)
+}
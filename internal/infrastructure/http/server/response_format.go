@@ -0,0 +1,265 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/biraneves/fc-labs-weather/internal/application/dto"
+	"github.com/biraneves/fc-labs-weather/internal/domain/entity"
+)
+
+var (
+	errInvalidUnits     = errors.New("invalid query parameter: units")
+	errInvalidPrecision = errors.New("invalid query parameter: precision")
+	errInvalidRounding  = errors.New("invalid query parameter: rounding")
+	errInvalidLang      = errors.New("invalid query parameter: lang")
+	errInvalidDetail    = errors.New("invalid query parameter: detail")
+)
+
+var responseUnits = []entity.TemperatureUnit{entity.Celsius, entity.Fahrenheit, entity.Kelvin, entity.Rankine}
+
+var defaultResponseFormat = responseFormat{
+	units:     []entity.TemperatureUnit{entity.Celsius, entity.Fahrenheit, entity.Kelvin},
+	precision: entity.DefaultTemperaturePrecision,
+	rounding:  entity.RoundHalfUp,
+}
+
+type responseFormat struct {
+	units     []entity.TemperatureUnit
+	precision int
+	rounding  entity.RoundingMode
+	locale    entity.Locale
+	localized bool
+	detailed  bool
+}
+
+func parseResponseFormat(query url.Values, acceptLanguage string, detailed bool) (responseFormat, error) {
+	format := defaultResponseFormat
+	format.detailed = detailed
+
+	if query.Has("detail") {
+		switch strings.ToLower(strings.TrimSpace(query.Get("detail"))) {
+		case "basic":
+			format.detailed = false
+		case "full":
+			format.detailed = true
+		default:
+			return responseFormat{}, fmt.Errorf("%w: must be one of basic,full", errInvalidDetail)
+		}
+	}
+
+	if query.Has("lang") {
+		locale, err := entity.ParseLocale(query.Get("lang"))
+		if err != nil {
+			return responseFormat{}, fmt.Errorf("%w: must be one of %s", errInvalidLang, joinLocales())
+		}
+		format.locale, format.localized = locale, true
+	} else {
+		format.locale, format.localized = negotiateLocale(acceptLanguage)
+	}
+
+	if query.Has("units") {
+		units, err := parseResponseUnits(query.Get("units"))
+		if err != nil {
+			return responseFormat{}, err
+		}
+		format.units = units
+	}
+
+	if query.Has("precision") {
+		precision, err := strconv.Atoi(strings.TrimSpace(query.Get("precision")))
+		if err != nil || precision < 0 || precision > entity.MaxTemperaturePrecision {
+			return responseFormat{}, fmt.Errorf("%w: must be an integer between 0 and %d", errInvalidPrecision, entity.MaxTemperaturePrecision)
+		}
+		format.precision = precision
+	}
+
+	if query.Has("rounding") {
+		rounding, err := entity.ParseRoundingMode(query.Get("rounding"))
+		if err != nil {
+			return responseFormat{}, fmt.Errorf("%w: must be one of %s", errInvalidRounding, joinRoundingModes())
+		}
+		format.rounding = rounding
+	}
+
+	return format, nil
+}
+
+func parseResponseUnits(raw string) ([]entity.TemperatureUnit, error) {
+	var units []entity.TemperatureUnit
+	for part := range strings.SplitSeq(raw, ",") {
+		unit, err := entity.ParseTemperatureUnit(part)
+		if err != nil || !slices.Contains(responseUnits, unit) {
+			return nil, fmt.Errorf("%w: must be a comma-separated list of %s", errInvalidUnits, joinResponseUnits())
+		}
+
+		if !slices.Contains(units, unit) {
+			units = append(units, unit)
+		}
+	}
+
+	return units, nil
+}
+
+func (f responseFormat) apply(out dto.RequestOutDto) (dto.RequestOutDto, error) {
+	temps, err := f.temperatures(out.TempC)
+	if err != nil {
+		return dto.RequestOutDto{}, err
+	}
+	out.TempC, out.TempF, out.TempK, out.TempR, out.Formatted = temps.TempC, temps.TempF, temps.TempK, temps.TempR, temps.Formatted
+
+	if !f.detailed {
+		out.Location, out.Conditions, out.Ensemble = nil, nil, nil
+		return out, nil
+	}
+
+	if out.Conditions != nil {
+		conditions := *out.Conditions
+		for _, set := range []*dto.TemperaturesDto{&conditions.FeelsLike, &conditions.HeatIndex, &conditions.WindChill, &conditions.DewPoint} {
+			if *set, err = f.temperatures(set.TempC); err != nil {
+				return dto.RequestOutDto{}, err
+			}
+		}
+		out.Conditions = &conditions
+	}
+
+	if out.Ensemble != nil {
+		ensemble := *out.Ensemble
+		ensemble.Readings = make([]dto.EnsembleReadingOutDto, len(out.Ensemble.Readings))
+		for i, reading := range out.Ensemble.Readings {
+			if reading.TemperaturesDto, err = f.temperatures(reading.TempC); err != nil {
+				return dto.RequestOutDto{}, err
+			}
+			ensemble.Readings[i] = reading
+		}
+		out.Ensemble = &ensemble
+	}
+
+	return out, nil
+}
+
+func (f responseFormat) temperatures(celsius entity.Temperature) (dto.TemperaturesDto, error) {
+	if !celsius.IsValid() {
+		return dto.TemperaturesDto{}, nil
+	}
+
+	base, err := celsius.WithFormat(f.precision, f.rounding)
+	if err != nil {
+		return dto.TemperaturesDto{}, err
+	}
+
+	var temps dto.TemperaturesDto
+	for _, unit := range f.units {
+		converted := base.In(unit)
+		switch unit {
+		case entity.Celsius:
+			temps.TempC = converted
+		case entity.Fahrenheit:
+			temps.TempF = converted
+		case entity.Kelvin:
+			temps.TempK = converted
+		case entity.Rankine:
+			temps.TempR = converted
+		}
+	}
+
+	if f.localized {
+		temps.Formatted = &dto.FormattedTempsDto{
+			TempC: temps.TempC.Format(f.locale),
+			TempF: temps.TempF.Format(f.locale),
+			TempK: temps.TempK.Format(f.locale),
+			TempR: temps.TempR.Format(f.locale),
+		}
+	}
+
+	return temps, nil
+}
+
+func negotiateLocale(header string) (entity.Locale, bool) {
+	type candidate struct {
+		tag string
+		q   float64
+	}
+
+	var candidates []candidate
+	for part := range strings.SplitSeq(header, ",") {
+		tag, params, _ := strings.Cut(part, ";")
+		tag = strings.TrimSpace(tag)
+		if tag == "" {
+			continue
+		}
+
+		q := 1.0
+		for param := range strings.SplitSeq(params, ";") {
+			key, value, ok := strings.Cut(strings.TrimSpace(param), "=")
+			if !ok || !strings.EqualFold(strings.TrimSpace(key), "q") {
+				continue
+			}
+
+			parsed, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+			if err != nil || parsed < 0 || parsed > 1 {
+				parsed = 0
+			}
+			q = parsed
+		}
+
+		if q > 0 {
+			candidates = append(candidates, candidate{tag: tag, q: q})
+		}
+	}
+
+	slices.SortStableFunc(candidates, func(a, b candidate) int {
+		switch {
+		case a.q > b.q:
+			return -1
+		case a.q < b.q:
+			return 1
+		}
+		return 0
+	})
+
+	for _, c := range candidates {
+		if c.tag == "*" {
+			return entity.DefaultLocale, true
+		}
+
+		if locale, err := entity.ParseLocale(c.tag); err == nil {
+			return locale, true
+		}
+	}
+
+	return entity.Locale{}, false
+}
+
+func joinResponseUnits() string {
+	codes := make([]string, len(responseUnits))
+	for i, unit := range responseUnits {
+		codes[i] = unit.Code
+	}
+
+	return strings.Join(codes, ",")
+}
+
+func joinLocales() string {
+	locales := entity.Locales()
+	tags := make([]string, len(locales))
+	for i, locale := range locales {
+		tags[i] = locale.Tag
+	}
+
+	return strings.Join(tags, ",")
+}
+
+func joinRoundingModes() string {
+	modes := entity.RoundingModes()
+	names := make([]string, len(modes))
+	for i, mode := range modes {
+		names[i] = string(mode)
+	}
+
+	return strings.Join(names, ",")
+}
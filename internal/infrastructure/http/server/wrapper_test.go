@@ -0,0 +1,15 @@
+package server
+
+import "time"
+
+func (h *Handler) SetClock(now func() time.Time) {
+	h.now = now
+}
+
+func (m *RateLimitMiddleware) SetClock(now func() time.Time) {
+	m.now = now
+}
+
+func (m *ConcurrencyMiddleware) SetClock(now func() time.Time) {
+	m.now = now
+}
@@ -0,0 +1,89 @@
+package transport
+
+import (
+	"context"
+	"net"
+	"sync"
+	"time"
+)
+
+type dnsEntry struct {
+	addrs   []string
+	expires time.Time
+}
+
+type DNSCache struct {
+	ttl    time.Duration
+	lookup func(ctx context.Context, host string) ([]string, error)
+	now    func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]dnsEntry
+}
+
+func NewDNSCache(ttl time.Duration, resolver *net.Resolver) *DNSCache {
+	if resolver == nil {
+		resolver = net.DefaultResolver
+	}
+
+	return &DNSCache{
+		ttl:     ttl,
+		lookup:  resolver.LookupHost,
+		now:     time.Now,
+		entries: make(map[string]dnsEntry),
+	}
+}
+
+func (c *DNSCache) LookupHost(ctx context.Context, host string) ([]string, error) {
+	c.mu.Lock()
+	entry, ok := c.entries[host]
+	c.mu.Unlock()
+
+	if ok && c.now().Before(entry.expires) {
+		return entry.addrs, nil
+	}
+
+	addrs, err := c.lookup(ctx, host)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	c.entries[host] = dnsEntry{addrs: addrs, expires: c.now().Add(c.ttl)}
+	c.mu.Unlock()
+
+	return addrs, nil
+}
+
+func (c *DNSCache) Forget(host string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.entries, host)
+}
+
+func (c *DNSCache) DialContext(dialer *net.Dialer) func(ctx context.Context, network, addr string) (net.Conn, error) {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		host, port, err := net.SplitHostPort(addr)
+		if err != nil || net.ParseIP(host) != nil {
+			return dialer.DialContext(ctx, network, addr)
+		}
+
+		addrs, err := c.LookupHost(ctx, host)
+		if err != nil {
+			return nil, err
+		}
+
+		var lastErr error = &net.DNSError{Err: "no such host", Name: host, IsNotFound: true}
+		for _, ip := range addrs {
+			conn, err := dialer.DialContext(ctx, network, net.JoinHostPort(ip, port))
+			if err == nil {
+				return conn, nil
+			}
+			lastErr = err
+		}
+
+		c.Forget(host)
+		return nil, lastErr
+	}
+}
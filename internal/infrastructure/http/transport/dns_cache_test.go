@@ -0,0 +1,96 @@
+package transport_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/biraneves/fc-labs-weather/internal/infrastructure/http/transport"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.now = c.now.Add(d)
+}
+
+func TestDNSCache_LookupHost(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)}
+
+	var lookups atomic.Int32
+	cache := transport.NewDNSCache(time.Minute, nil)
+	cache.SetClock(clock.Now)
+	cache.SetLookup(func(ctx context.Context, host string) ([]string, error) {
+		lookups.Add(1)
+		if host == "missing.test" {
+			return nil, errors.New("no such host")
+		}
+		return []string{"127.0.0.1"}, nil
+	})
+
+	got, err := cache.LookupHost(context.Background(), "viacep.test")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"127.0.0.1"}, got)
+
+	_, err = cache.LookupHost(context.Background(), "viacep.test")
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), lookups.Load())
+
+	clock.Advance(time.Minute)
+	_, err = cache.LookupHost(context.Background(), "viacep.test")
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), lookups.Load())
+
+	_, err = cache.LookupHost(context.Background(), "missing.test")
+	require.Error(t, err)
+	_, err = cache.LookupHost(context.Background(), "missing.test")
+	require.Error(t, err)
+	assert.Equal(t, int32(4), lookups.Load())
+}
+
+func TestDNSCache_DialContext(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	serverURL, err := url.Parse(server.URL)
+	require.NoError(t, err)
+	_, port, err := net.SplitHostPort(serverURL.Host)
+	require.NoError(t, err)
+
+	var lookups atomic.Int32
+	cache := transport.NewDNSCache(time.Minute, nil)
+	cache.SetLookup(func(ctx context.Context, host string) ([]string, error) {
+		lookups.Add(1)
+		return []string{"127.0.0.1"}, nil
+	})
+
+	client := &http.Client{Transport: &http.Transport{
+		DialContext:       cache.DialContext(&net.Dialer{Timeout: time.Second}),
+		DisableKeepAlives: true,
+	}}
+
+	for range 3 {
+		resp, err := client.Get("http://weather.test:" + port + "/")
+		require.NoError(t, err)
+		_ = resp.Body.Close()
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	}
+
+	assert.Equal(t, int32(1), lookups.Load())
+}
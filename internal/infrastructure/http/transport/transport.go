@@ -0,0 +1,155 @@
+package transport
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+)
+
+const (
+	defaultMaxIdleConns        = 100
+	defaultMaxIdleConnsPerHost = 10
+	defaultIdleConnTimeout     = 90 * time.Second
+	defaultKeepAlive           = 30 * time.Second
+	defaultDialTimeout         = 5 * time.Second
+	defaultTLSHandshakeTimeout = 5 * time.Second
+)
+
+var (
+	ErrInvalidProxyURL = errors.New("transport: invalid proxy url")
+	ErrInvalidCABundle = errors.New("transport: invalid ca bundle")
+)
+
+type Settings struct {
+	Name                string
+	MaxIdleConns        int
+	MaxIdleConnsPerHost int
+	MaxConnsPerHost     int
+	IdleConnTimeout     time.Duration
+	KeepAlive           time.Duration
+	DialTimeout         time.Duration
+	TLSHandshakeTimeout time.Duration
+	ProxyURL            string
+	CABundle            string
+	DNSCacheTTL         time.Duration
+}
+
+func New(settings Settings, logger *slog.Logger) (*http.Transport, error) {
+	if settings.MaxIdleConns <= 0 {
+		settings.MaxIdleConns = defaultMaxIdleConns
+	}
+
+	if settings.MaxIdleConnsPerHost <= 0 {
+		settings.MaxIdleConnsPerHost = defaultMaxIdleConnsPerHost
+	}
+
+	if settings.IdleConnTimeout <= 0 {
+		settings.IdleConnTimeout = defaultIdleConnTimeout
+	}
+
+	if settings.KeepAlive <= 0 {
+		settings.KeepAlive = defaultKeepAlive
+	}
+
+	if settings.DialTimeout <= 0 {
+		settings.DialTimeout = defaultDialTimeout
+	}
+
+	if settings.TLSHandshakeTimeout <= 0 {
+		settings.TLSHandshakeTimeout = defaultTLSHandshakeTimeout
+	}
+
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	proxy, err := proxyFunc(settings.ProxyURL)
+	if err != nil {
+		return nil, err
+	}
+
+	rootCAs, err := loadCABundle(settings.CABundle)
+	if err != nil {
+		return nil, err
+	}
+
+	dialer := &net.Dialer{
+		Timeout:   settings.DialTimeout,
+		KeepAlive: settings.KeepAlive,
+	}
+
+	dial := dialer.DialContext
+	if settings.DNSCacheTTL > 0 {
+		dial = NewDNSCache(settings.DNSCacheTTL, nil).DialContext(dialer)
+	}
+
+	logger.Info("transport: configured",
+		slog.String("upstream", settings.Name),
+		slog.Int("max_idle_conns_per_host", settings.MaxIdleConnsPerHost),
+		slog.Int("max_conns_per_host", settings.MaxConnsPerHost),
+		slog.Bool("proxy", settings.ProxyURL != ""),
+		slog.Bool("ca_bundle", settings.CABundle != ""),
+		slog.Duration("dns_cache_ttl", settings.DNSCacheTTL),
+	)
+
+	return &http.Transport{
+		Proxy:               proxy,
+		DialContext:         dial,
+		ForceAttemptHTTP2:   true,
+		MaxIdleConns:        settings.MaxIdleConns,
+		MaxIdleConnsPerHost: settings.MaxIdleConnsPerHost,
+		MaxConnsPerHost:     settings.MaxConnsPerHost,
+		IdleConnTimeout:     settings.IdleConnTimeout,
+		TLSHandshakeTimeout: settings.TLSHandshakeTimeout,
+		TLSClientConfig: &tls.Config{
+			MinVersion: tls.VersionTLS12,
+			RootCAs:    rootCAs,
+		},
+	}, nil
+}
+
+func proxyFunc(raw string) (func(*http.Request) (*url.URL, error), error) {
+	if raw == "" {
+		return http.ProxyFromEnvironment, nil
+	}
+
+	proxyURL, err := url.Parse(raw)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidProxyURL, err)
+	}
+
+	if proxyURL.Scheme != "http" && proxyURL.Scheme != "https" && proxyURL.Scheme != "socks5" || proxyURL.Host == "" {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidProxyURL, raw)
+	}
+
+	return http.ProxyURL(proxyURL), nil
+}
+
+func loadCABundle(path string) (*x509.CertPool, error) {
+	if path == "" {
+		return nil, nil
+	}
+
+	pem, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidCABundle, err)
+	}
+
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("%w: no certificates found in %s", ErrInvalidCABundle, path)
+	}
+
+	return pool, nil
+}
@@ -0,0 +1,105 @@
+package transport_test
+
+import (
+	"encoding/pem"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/biraneves/fc-labs-weather/internal/infrastructure/http/transport"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var noopLogger = slog.New(slog.NewJSONHandler(io.Discard, nil))
+
+func TestNew(t *testing.T) {
+	tr, err := transport.New(transport.Settings{Name: "viacep", MaxConnsPerHost: 4}, noopLogger)
+	require.NoError(t, err)
+
+	assert.Equal(t, 100, tr.MaxIdleConns)
+	assert.Equal(t, 10, tr.MaxIdleConnsPerHost)
+	assert.Equal(t, 4, tr.MaxConnsPerHost)
+	assert.Equal(t, 90*time.Second, tr.IdleConnTimeout)
+	assert.Equal(t, 5*time.Second, tr.TLSHandshakeTimeout)
+	assert.Nil(t, tr.TLSClientConfig.RootCAs)
+}
+
+func TestNew_Proxy(t *testing.T) {
+	tests := []struct {
+		name      string
+		proxyURL  string
+		wantProxy string
+		wantError error
+	}{
+		{name: "http proxy", proxyURL: "http://proxy.internal:3128", wantProxy: "http://proxy.internal:3128"},
+		{name: "missing host", proxyURL: "http://", wantError: transport.ErrInvalidProxyURL},
+		{name: "unsupported scheme", proxyURL: "ftp://proxy.internal", wantError: transport.ErrInvalidProxyURL},
+		{name: "unparsable", proxyURL: "http://[::1", wantError: transport.ErrInvalidProxyURL},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tr, err := transport.New(transport.Settings{ProxyURL: tt.proxyURL}, noopLogger)
+			if tt.wantError != nil {
+				require.ErrorIs(t, err, tt.wantError)
+				return
+			}
+
+			require.NoError(t, err)
+			req := httptest.NewRequest(http.MethodGet, "https://viacep.com.br/ws/01001000/json", nil)
+			got, err := tr.Proxy(req)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantProxy, got.String())
+		})
+	}
+}
+
+func TestNew_CABundle(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+
+	bundle := filepath.Join(dir, "ca.pem")
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	require.NoError(t, os.WriteFile(bundle, certPEM, 0o600))
+
+	garbage := filepath.Join(dir, "garbage.pem")
+	require.NoError(t, os.WriteFile(garbage, []byte("not a certificate"), 0o600))
+
+	t.Run("trusted bundle", func(t *testing.T) {
+		tr, err := transport.New(transport.Settings{CABundle: bundle}, noopLogger)
+		require.NoError(t, err)
+
+		resp, err := (&http.Client{Transport: tr}).Get(server.URL)
+		require.NoError(t, err)
+		_ = resp.Body.Close()
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	})
+
+	t.Run("without bundle", func(t *testing.T) {
+		tr, err := transport.New(transport.Settings{}, noopLogger)
+		require.NoError(t, err)
+
+		_, err = (&http.Client{Transport: tr}).Get(server.URL)
+		require.Error(t, err)
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		_, err := transport.New(transport.Settings{CABundle: filepath.Join(dir, "missing.pem")}, noopLogger)
+		require.ErrorIs(t, err, transport.ErrInvalidCABundle)
+	})
+
+	t.Run("no certificates", func(t *testing.T) {
+		_, err := transport.New(transport.Settings{CABundle: garbage}, noopLogger)
+		require.ErrorIs(t, err, transport.ErrInvalidCABundle)
+	})
+}
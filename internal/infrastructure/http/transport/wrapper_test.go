@@ -0,0 +1,14 @@
+package transport
+
+import (
+	"context"
+	"time"
+)
+
+func (c *DNSCache) SetClock(now func() time.Time) {
+	c.now = now
+}
+
+func (c *DNSCache) SetLookup(lookup func(ctx context.Context, host string) ([]string, error)) {
+	c.lookup = lookup
+}
@@ -0,0 +1,23 @@
+package upstream
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/biraneves/fc-labs-weather/internal/application/ports/outbound"
+)
+
+func Budget(ctx context.Context, timeout time.Duration) (time.Duration, error) {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return timeout, nil
+	}
+
+	remaining := time.Until(deadline)
+	if remaining <= 0 {
+		return 0, fmt.Errorf("request budget exhausted: %w: %w", outbound.ErrUpstreamTimeout, context.DeadlineExceeded)
+	}
+
+	return min(timeout, remaining), nil
+}
@@ -0,0 +1,49 @@
+package upstream_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/biraneves/fc-labs-weather/internal/application/ports/outbound"
+	"github.com/biraneves/fc-labs-weather/internal/infrastructure/http/upstream"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBudget(t *testing.T) {
+	t.Run("no deadline", func(t *testing.T) {
+		got, err := upstream.Budget(context.Background(), 5*time.Second)
+		require.NoError(t, err)
+		assert.Equal(t, 5*time.Second, got)
+	})
+
+	t.Run("deadline shorter than timeout", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+
+		got, err := upstream.Budget(ctx, 5*time.Second)
+		require.NoError(t, err)
+		assert.LessOrEqual(t, got, time.Second)
+		assert.Greater(t, got, 500*time.Millisecond)
+	})
+
+	t.Run("deadline longer than timeout", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+
+		got, err := upstream.Budget(ctx, 5*time.Second)
+		require.NoError(t, err)
+		assert.Equal(t, 5*time.Second, got)
+	})
+
+	t.Run("deadline passed", func(t *testing.T) {
+		ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+		defer cancel()
+
+		_, err := upstream.Budget(ctx, 5*time.Second)
+		require.Error(t, err)
+		assert.ErrorIs(t, err, outbound.ErrUpstreamTimeout)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+}
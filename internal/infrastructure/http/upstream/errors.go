@@ -0,0 +1,50 @@
+package upstream
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+
+	"github.com/biraneves/fc-labs-weather/internal/application/ports/outbound"
+)
+
+func ClassifyTransportError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	if errors.Is(err, context.Canceled) {
+		return err
+	}
+
+	if errors.Is(err, context.DeadlineExceeded) {
+		return outbound.ErrUpstreamTimeout
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return outbound.ErrUpstreamTimeout
+	}
+
+	return outbound.ErrUpstreamUnavailable
+}
+
+func ClassifyStatus(status int) error {
+	switch {
+	case status == http.StatusUnauthorized || status == http.StatusForbidden:
+		return outbound.ErrUpstreamUnauthorized
+
+	case status == http.StatusTooManyRequests:
+		return outbound.ErrUpstreamQuotaExceeded
+
+	case status == http.StatusGatewayTimeout || status == http.StatusRequestTimeout:
+		return outbound.ErrUpstreamTimeout
+
+	case status >= http.StatusInternalServerError:
+		return outbound.ErrUpstreamUnavailable
+
+	default:
+		return nil
+	}
+}
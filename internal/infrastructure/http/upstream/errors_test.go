@@ -0,0 +1,76 @@
+package upstream_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"testing"
+
+	"github.com/biraneves/fc-labs-weather/internal/application/ports/outbound"
+	"github.com/biraneves/fc-labs-weather/internal/infrastructure/http/upstream"
+	"github.com/stretchr/testify/assert"
+)
+
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "i/o timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
+
+var _ net.Error = timeoutError{}
+
+func TestClassifyTransportError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want error
+	}{
+		{name: "nil", err: nil, want: nil},
+		{name: "caller canceled", err: fmt.Errorf("do: %w", context.Canceled), want: context.Canceled},
+		{name: "deadline exceeded", err: fmt.Errorf("do: %w", context.DeadlineExceeded), want: outbound.ErrUpstreamTimeout},
+		{name: "network timeout", err: &net.OpError{Op: "dial", Err: timeoutError{}}, want: outbound.ErrUpstreamTimeout},
+		{name: "connection refused", err: errors.New("connection refused"), want: outbound.ErrUpstreamUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := upstream.ClassifyTransportError(tt.err)
+			if tt.want == nil {
+				assert.NoError(t, got)
+				return
+			}
+			assert.ErrorIs(t, got, tt.want)
+		})
+	}
+}
+
+func TestClassifyStatus(t *testing.T) {
+	tests := []struct {
+		status int
+		want   error
+	}{
+		{status: http.StatusOK, want: nil},
+		{status: http.StatusBadRequest, want: nil},
+		{status: http.StatusNotFound, want: nil},
+		{status: http.StatusUnauthorized, want: outbound.ErrUpstreamUnauthorized},
+		{status: http.StatusForbidden, want: outbound.ErrUpstreamUnauthorized},
+		{status: http.StatusTooManyRequests, want: outbound.ErrUpstreamQuotaExceeded},
+		{status: http.StatusRequestTimeout, want: outbound.ErrUpstreamTimeout},
+		{status: http.StatusGatewayTimeout, want: outbound.ErrUpstreamTimeout},
+		{status: http.StatusInternalServerError, want: outbound.ErrUpstreamUnavailable},
+		{status: http.StatusServiceUnavailable, want: outbound.ErrUpstreamUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(http.StatusText(tt.status), func(t *testing.T) {
+			got := upstream.ClassifyStatus(tt.status)
+			if tt.want == nil {
+				assert.NoError(t, got)
+				return
+			}
+			assert.ErrorIs(t, got, tt.want)
+		})
+	}
+}
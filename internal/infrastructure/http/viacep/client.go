@@ -12,6 +12,7 @@ import (
 	"github.com/biraneves/fc-labs-weather/internal/application/dto"
 	"github.com/biraneves/fc-labs-weather/internal/application/ports/outbound"
 	"github.com/biraneves/fc-labs-weather/internal/infrastructure/http/server"
+	"github.com/biraneves/fc-labs-weather/internal/infrastructure/http/upstream"
 )
 
 type HTTPClient struct {
@@ -49,7 +50,16 @@ func NewHTTPClient(httpClient *http.Client, baseURL string, timeout time.Duratio
 func (h *HTTPClient) Find(ctx context.Context, request dto.ViaCEPRequestDto) (dto.ViaCEPResponseDto, error) {
 	logger := server.LoggerFromContext(ctx, h.logger)
 
-	ctx, cancel := context.WithTimeout(ctx, h.timeout)
+	timeout, err := upstream.Budget(ctx, h.timeout)
+	if err != nil {
+		logger.Warn("viacep: request budget exhausted",
+			slog.String("type", "outbound_error"),
+			slog.String("cep", request.CEP.Formatted()),
+		)
+		return dto.ViaCEPResponseDto{}, fmt.Errorf("viacep: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
 	url := fmt.Sprintf("%s/%s/json", h.baseURL, request.CEP.String())
@@ -58,7 +68,7 @@ func (h *HTTPClient) Find(ctx context.Context, request dto.ViaCEPRequestDto) (dt
 	if err != nil {
 		logger.Error("viacep: create request failed",
 			slog.String("type", "outbound_error"),
-			slog.String("cep", request.CEP.String()),
+			slog.String("cep", request.CEP.Formatted()),
 			slog.String("error", err.Error()),
 		)
 		return dto.ViaCEPResponseDto{}, fmt.Errorf("viacep: create request: %w", err)
@@ -68,26 +78,29 @@ func (h *HTTPClient) Find(ctx context.Context, request dto.ViaCEPRequestDto) (dt
 	if err != nil {
 		logger.Error("viacep: http call failed",
 			slog.String("type", "outbound_error"),
-			slog.String("cep", request.CEP.String()),
+			slog.String("cep", request.CEP.Formatted()),
 			slog.String("error", err.Error()),
 		)
-		return dto.ViaCEPResponseDto{}, fmt.Errorf("viacep: do request: %w", err)
+		return dto.ViaCEPResponseDto{}, fmt.Errorf("viacep: do request: %w: %w", upstream.ClassifyTransportError(err), err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode == http.StatusNotFound {
 		logger.Info("viacep: zipcode not found",
 			slog.String("type", "outbound_error"),
-			slog.String("cep", request.CEP.String()),
+			slog.String("cep", request.CEP.Formatted()),
 		)
 		return dto.ViaCEPResponseDto{}, outbound.ErrZipcodeNotFound
 	}
 	if resp.StatusCode != http.StatusOK {
 		logger.Error("viacep: unexpected status",
 			slog.String("type", "outbound_error"),
-			slog.String("cep", request.CEP.String()),
+			slog.String("cep", request.CEP.Formatted()),
 			slog.Int("status", resp.StatusCode),
 		)
+		if kind := upstream.ClassifyStatus(resp.StatusCode); kind != nil {
+			return dto.ViaCEPResponseDto{}, fmt.Errorf("viacep: unexpected status: %d: %w", resp.StatusCode, kind)
+		}
 		return dto.ViaCEPResponseDto{}, fmt.Errorf("viacep: unexpected status: %d", resp.StatusCode)
 	}
 
@@ -99,23 +112,23 @@ func (h *HTTPClient) Find(ctx context.Context, request dto.ViaCEPRequestDto) (dt
 	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
 		logger.Error("viacep: decode response failed",
 			slog.String("type", "outbound_error"),
-			slog.String("cep", request.CEP.String()),
+			slog.String("cep", request.CEP.Formatted()),
 			slog.String("error", err.Error()),
 		)
-		return dto.ViaCEPResponseDto{}, fmt.Errorf("viacep: decode response: %w", err)
+		return dto.ViaCEPResponseDto{}, fmt.Errorf("viacep: decode response: %w: %w", outbound.ErrUpstreamMalformedPayload, err)
 	}
 
 	if normalizeErrorFlag(payload.Erro) {
 		logger.Info("viacep: response flagged erro=true",
 			slog.String("type", "outbound_error"),
-			slog.String("cep", request.CEP.String()),
+			slog.String("cep", request.CEP.Formatted()),
 		)
 		return dto.ViaCEPResponseDto{}, outbound.ErrZipcodeNotFound
 	}
 
 	logger.Info("viacep: lookup succeeded",
 		slog.String("type", "outbound_success"),
-		slog.String("cep", request.CEP.String()),
+		slog.String("cep", request.CEP.Formatted()),
 		slog.String("localidade", payload.Localidade),
 		slog.String("uf", payload.UF),
 	)
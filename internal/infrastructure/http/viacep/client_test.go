@@ -7,11 +7,13 @@ import (
 	"log/slog"
 	"net/http"
 	"net/http/httptest"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/biraneves/fc-labs-weather/internal/application/dto"
 	"github.com/biraneves/fc-labs-weather/internal/application/ports/outbound"
+	"github.com/biraneves/fc-labs-weather/internal/infrastructure/http/retry"
 	"github.com/biraneves/fc-labs-weather/internal/infrastructure/http/viacep"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -87,14 +89,25 @@ func TestHTTPClient_Find(t *testing.T) {
 				status: http.StatusInternalServerError,
 				body:   `{}`,
 			},
+			wantError:     outbound.ErrUpstreamUnavailable,
 			expectedError: "viacep: unexpected status: 500",
 		},
+		{
+			name: "rate limited",
+			fields: fields{
+				status: http.StatusTooManyRequests,
+				body:   `{}`,
+			},
+			wantError:     outbound.ErrUpstreamQuotaExceeded,
+			expectedError: "viacep: unexpected status: 429",
+		},
 		{
 			name: "invalid json",
 			fields: fields{
 				status: http.StatusOK,
 				body:   `{"cep":`,
 			},
+			wantError:     outbound.ErrUpstreamMalformedPayload,
 			expectedError: "viacep: decode response",
 		},
 		{
@@ -108,6 +121,7 @@ func TestHTTPClient_Find(t *testing.T) {
 				}`,
 				delay: 200 * time.Millisecond,
 			},
+			wantError:     outbound.ErrUpstreamTimeout,
 			expectedError: "context deadline exceeded",
 		},
 	}
@@ -137,7 +151,6 @@ func TestHTTPClient_Find(t *testing.T) {
 			if tt.wantError != nil {
 				require.Error(t, err)
 				assert.ErrorIs(t, err, tt.wantError)
-				return
 			}
 
 			if tt.expectedError != "" {
@@ -151,3 +164,81 @@ func TestHTTPClient_Find(t *testing.T) {
 		})
 	}
 }
+
+func TestHTTPClient_Find_WithRetryTransport(t *testing.T) {
+	tests := []struct {
+		name      string
+		statuses  []int
+		bodies    []string
+		wantCalls int32
+		wantError error
+	}{
+		{
+			name:      "recovers from transient failure",
+			statuses:  []int{http.StatusServiceUnavailable, http.StatusOK},
+			bodies:    []string{`{}`, `{"cep":"01001-000","localidade":"São Paulo","uf":"SP"}`},
+			wantCalls: 2,
+		},
+		{
+			name:      "not found is not retried",
+			statuses:  []int{http.StatusNotFound, http.StatusOK},
+			bodies:    []string{`{}`, `{}`},
+			wantCalls: 1,
+			wantError: outbound.ErrZipcodeNotFound,
+		},
+		{
+			name:      "erro flag is not retried",
+			statuses:  []int{http.StatusOK, http.StatusOK},
+			bodies:    []string{`{"erro": true}`, `{}`},
+			wantCalls: 1,
+			wantError: outbound.ErrZipcodeNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				i := calls.Add(1) - 1
+				w.WriteHeader(tt.statuses[i])
+				_, _ = w.Write([]byte(tt.bodies[i]))
+			}))
+			defer server.Close()
+
+			policy := retry.DefaultPolicy()
+			policy.BaseBackoff = time.Millisecond
+			httpClient := &http.Client{Transport: retry.NewTransport(nil, policy, noopLogger)}
+
+			client := viacep.NewHTTPClient(httpClient, server.URL, time.Second, noopLogger)
+
+			got, err := client.Find(context.Background(), dto.ViaCEPRequestDto{})
+			assert.Equal(t, tt.wantCalls, calls.Load())
+
+			if tt.wantError != nil {
+				assert.ErrorIs(t, err, tt.wantError)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, "São Paulo", got.Localidade)
+		})
+	}
+}
+
+func TestHTTPClient_Find_BudgetExhausted(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+	}))
+	defer server.Close()
+
+	client := viacep.NewHTTPClient(nil, server.URL, time.Second, noopLogger)
+
+	ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Millisecond))
+	defer cancel()
+
+	_, err := client.Find(ctx, dto.ViaCEPRequestDto{})
+	require.Error(t, err)
+	assert.ErrorIs(t, err, outbound.ErrUpstreamTimeout)
+	assert.Zero(t, calls.Load())
+}
@@ -7,13 +7,23 @@ import (
 	"fmt"
 	"log/slog"
 	"net/http"
+	"net/http/httptrace"
 	"net/url"
+	"slices"
 	"strings"
 	"time"
 
 	"github.com/biraneves/fc-labs-weather/internal/application/dto"
 	"github.com/biraneves/fc-labs-weather/internal/application/ports/outbound"
+	"github.com/biraneves/fc-labs-weather/internal/infrastructure/http/retry"
 	"github.com/biraneves/fc-labs-weather/internal/infrastructure/http/server"
+	"github.com/biraneves/fc-labs-weather/internal/infrastructure/http/upstream"
+)
+
+const (
+	providerName             = "weatherapi"
+	apiErrorLocationNotFound = 1006
+	apiErrorQuotaExceeded    = 2007
 )
 
 var (
@@ -21,15 +31,33 @@ var (
 	ErrEmptyQuery    = errors.New("weatherapi: empty query parameter")
 )
 
+type apiErrorBody struct {
+	Error struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
 type HTTPClient struct {
 	httpClient *http.Client
 	baseURL    string
-	apiKey     string
+	keys       *KeyPool
 	timeout    time.Duration
 	logger     *slog.Logger
 }
 
 func NewHTTPClient(httpClient *http.Client, baseURL, apiKey string, timeout time.Duration, logger *slog.Logger) *HTTPClient {
+	var keys []string
+	if apiKey != "" {
+		keys = []string{apiKey}
+	}
+
+	pool, _ := NewKeyPool(keys, 0, "", logger)
+
+	return NewHTTPClientWithKeyPool(httpClient, baseURL, pool, timeout, logger)
+}
+
+func NewHTTPClientWithKeyPool(httpClient *http.Client, baseURL string, keys *KeyPool, timeout time.Duration, logger *slog.Logger) *HTTPClient {
 	if httpClient == nil {
 		httpClient = http.DefaultClient
 	}
@@ -45,21 +73,26 @@ func NewHTTPClient(httpClient *http.Client, baseURL, apiKey string, timeout time
 	return &HTTPClient{
 		httpClient: httpClient,
 		baseURL:    strings.TrimSuffix(baseURL, "/"),
-		apiKey:     apiKey,
+		keys:       keys,
 		timeout:    timeout,
 		logger:     logger,
 	}
 }
 
+func RetryPolicy(policy retry.Policy) retry.Policy {
+	policy.RetryableStatuses = slices.DeleteFunc(slices.Clone(policy.RetryableStatuses), rotatesKey)
+	return policy
+}
+
 func (h *HTTPClient) FetchCurrent(ctx context.Context, request dto.WeatherAPIRequestDto) (dto.WeatherAPIResponseDto, error) {
 	logger := server.LoggerFromContext(ctx, h.logger)
 
-	if h.apiKey == "" {
+	if h.keys.Len() == 0 {
 		logger.Error("weatherapi: missing api key",
 			slog.String("type", "outbound_error"),
 			slog.String("query", request.Q),
 		)
-		return dto.WeatherAPIResponseDto{}, ErrMissingAPIKey
+		return dto.WeatherAPIResponseDto{}, fmt.Errorf("%w: %w", ErrMissingAPIKey, outbound.ErrUpstreamUnauthorized)
 	}
 
 	query := strings.TrimSpace(request.Q)
@@ -70,9 +103,47 @@ func (h *HTTPClient) FetchCurrent(ctx context.Context, request dto.WeatherAPIReq
 		return dto.WeatherAPIResponseDto{}, ErrEmptyQuery
 	}
 
-	ctx, cancel := context.WithTimeout(ctx, h.timeout)
+	timeout, err := upstream.Budget(ctx, h.timeout)
+	if err != nil {
+		logger.Warn("weatherapi: request budget exhausted",
+			slog.String("type", "outbound_error"),
+			slog.String("query", query),
+		)
+		return dto.WeatherAPIResponseDto{}, fmt.Errorf("weatherapi: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
+	var lastErr error
+	for range h.keys.Len() {
+		key, err := h.keys.Acquire()
+		if err != nil {
+			logger.Error("weatherapi: no api key available",
+				slog.String("type", "outbound_error"),
+				slog.String("query", query),
+				slog.String("error", err.Error()),
+			)
+			return dto.WeatherAPIResponseDto{}, err
+		}
+
+		payload, status, apiCode, err := h.fetch(ctx, logger, key, query)
+		if err == nil {
+			return payload, nil
+		}
+
+		if h.keys.Len() < 2 || !rotatesKey(status) {
+			return dto.WeatherAPIResponseDto{}, err
+		}
+
+		h.keys.Reject(key, status, apiCode)
+		lastErr = err
+	}
+
+	return dto.WeatherAPIResponseDto{}, lastErr
+}
+
+func (h *HTTPClient) fetch(ctx context.Context, logger *slog.Logger, key, query string) (dto.WeatherAPIResponseDto, int, int, error) {
 	endpoint := fmt.Sprintf("%s/current.json", h.baseURL)
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
@@ -82,14 +153,18 @@ func (h *HTTPClient) FetchCurrent(ctx context.Context, request dto.WeatherAPIReq
 			slog.String("query", query),
 			slog.String("error", err.Error()),
 		)
-		return dto.WeatherAPIResponseDto{}, fmt.Errorf("weatherapi: create request: %w", err)
+		return dto.WeatherAPIResponseDto{}, 0, 0, fmt.Errorf("weatherapi: create request: %w", err)
 	}
 
 	q := url.Values{}
-	q.Set("key", h.apiKey)
+	q.Set("key", key)
 	q.Set("q", query)
 	req.URL.RawQuery = q.Encode()
 
+	req = req.WithContext(httptrace.WithClientTrace(ctx, &httptrace.ClientTrace{
+		GotFirstResponseByte: func() { h.keys.Record(key) },
+	}))
+
 	resp, err := h.httpClient.Do(req)
 	if err != nil {
 		logger.Error("weatherapi: http call failed",
@@ -97,17 +172,25 @@ func (h *HTTPClient) FetchCurrent(ctx context.Context, request dto.WeatherAPIReq
 			slog.String("query", query),
 			slog.String("error", err.Error()),
 		)
-		return dto.WeatherAPIResponseDto{}, fmt.Errorf("weatherapi: do request: %w", err)
+		return dto.WeatherAPIResponseDto{}, 0, 0, fmt.Errorf("weatherapi: do request: %w: %w", upstream.ClassifyTransportError(err), err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
+		var apiErr apiErrorBody
+		_ = json.NewDecoder(resp.Body).Decode(&apiErr)
+
 		logger.Error("weatherapi: unexpected status",
 			slog.String("type", "outbound_error"),
 			slog.String("query", query),
 			slog.Int("status", resp.StatusCode),
+			slog.Int("api_error_code", apiErr.Error.Code),
 		)
-		return dto.WeatherAPIResponseDto{}, fmt.Errorf("weatherapi: unexpected status: %d", resp.StatusCode)
+
+		if kind := classifyStatus(resp.StatusCode, apiErr.Error.Code); kind != nil {
+			return dto.WeatherAPIResponseDto{}, resp.StatusCode, apiErr.Error.Code, fmt.Errorf("weatherapi: unexpected status: %d: %w", resp.StatusCode, kind)
+		}
+		return dto.WeatherAPIResponseDto{}, resp.StatusCode, apiErr.Error.Code, fmt.Errorf("weatherapi: unexpected status: %d", resp.StatusCode)
 	}
 
 	var payload dto.WeatherAPIResponseDto
@@ -117,16 +200,33 @@ func (h *HTTPClient) FetchCurrent(ctx context.Context, request dto.WeatherAPIReq
 			slog.String("query", query),
 			slog.String("error", err.Error()),
 		)
-		return dto.WeatherAPIResponseDto{}, fmt.Errorf("weatherapi: decode response: %w", err)
+		return dto.WeatherAPIResponseDto{}, resp.StatusCode, 0, fmt.Errorf("weatherapi: decode response: %w: %w", outbound.ErrUpstreamMalformedPayload, err)
 	}
 
+	payload.Provider = providerName
+
 	logger.Info("weatherapi: lookup succeeded",
 		slog.String("type", "outbound_success"),
 		slog.String("query", query),
 		slog.Float64("temp_c", payload.Current.TempC),
 	)
 
-	return payload, nil
+	return payload, resp.StatusCode, 0, nil
+}
+
+func rotatesKey(status int) bool {
+	return status == http.StatusUnauthorized || status == http.StatusForbidden || status == http.StatusTooManyRequests
+}
+
+func classifyStatus(status, apiErrorCode int) error {
+	switch apiErrorCode {
+	case apiErrorLocationNotFound:
+		return outbound.ErrWeatherLocationNotFound
+	case apiErrorQuotaExceeded:
+		return outbound.ErrUpstreamQuotaExceeded
+	}
+
+	return upstream.ClassifyStatus(status)
 }
 
 var _ outbound.WeatherProviderPort = (*HTTPClient)(nil)
@@ -6,10 +6,13 @@ import (
 	"log/slog"
 	"net/http"
 	"net/http/httptest"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/biraneves/fc-labs-weather/internal/application/dto"
+	"github.com/biraneves/fc-labs-weather/internal/application/ports/outbound"
+	"github.com/biraneves/fc-labs-weather/internal/infrastructure/http/retry"
 	weatherapi "github.com/biraneves/fc-labs-weather/internal/infrastructure/http/weather_api"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -40,6 +43,7 @@ func TestHTTPClient_FetchCurrent(t *testing.T) {
 		fields        fields
 		request       dto.WeatherAPIRequestDto
 		want          dto.WeatherAPIResponseDto
+		wantError     error
 		expectedError string
 	}{
 		{
@@ -60,6 +64,7 @@ func TestHTTPClient_FetchCurrent(t *testing.T) {
 				var resp dto.WeatherAPIResponseDto
 				resp.Location.Name = "Sao Paulo"
 				resp.Current.TempC = 22.5
+				resp.Provider = "weatherapi"
 				return resp
 			}(),
 		},
@@ -72,6 +77,46 @@ func TestHTTPClient_FetchCurrent(t *testing.T) {
 			request:       dto.WeatherAPIRequestDto{Q: "São Paulo"},
 			expectedError: "weatherapi: unexpected status: 400",
 		},
+		{
+			name: "location not found",
+			fields: fields{
+				status: http.StatusBadRequest,
+				body:   `{"error": {"code": 1006, "message": "No matching location found."}}`,
+			},
+			request:       dto.WeatherAPIRequestDto{Q: "Lugar Nenhum"},
+			wantError:     outbound.ErrWeatherLocationNotFound,
+			expectedError: "weatherapi: unexpected status: 400",
+		},
+		{
+			name: "invalid api key",
+			fields: fields{
+				status: http.StatusUnauthorized,
+				body:   `{"error": {"code": 2006, "message": "API key is invalid."}}`,
+			},
+			request:       dto.WeatherAPIRequestDto{Q: "São Paulo"},
+			wantError:     outbound.ErrUpstreamUnauthorized,
+			expectedError: "weatherapi: unexpected status: 401",
+		},
+		{
+			name: "quota exceeded",
+			fields: fields{
+				status: http.StatusForbidden,
+				body:   `{"error": {"code": 2007, "message": "API key has exceeded calls per month quota."}}`,
+			},
+			request:       dto.WeatherAPIRequestDto{Q: "São Paulo"},
+			wantError:     outbound.ErrUpstreamQuotaExceeded,
+			expectedError: "weatherapi: unexpected status: 403",
+		},
+		{
+			name: "upstream unavailable",
+			fields: fields{
+				status: http.StatusServiceUnavailable,
+				body:   `{}`,
+			},
+			request:       dto.WeatherAPIRequestDto{Q: "São Paulo"},
+			wantError:     outbound.ErrUpstreamUnavailable,
+			expectedError: "weatherapi: unexpected status: 503",
+		},
 		{
 			name: "invalid json",
 			fields: fields{
@@ -79,6 +124,7 @@ func TestHTTPClient_FetchCurrent(t *testing.T) {
 				body:   `{"location":`,
 			},
 			request:       dto.WeatherAPIRequestDto{Q: "São Paulo"},
+			wantError:     outbound.ErrUpstreamMalformedPayload,
 			expectedError: "weatherapi: decode response",
 		},
 		{
@@ -89,6 +135,7 @@ func TestHTTPClient_FetchCurrent(t *testing.T) {
 				delay:  100 * time.Millisecond,
 			},
 			request:       dto.WeatherAPIRequestDto{Q: "São Paulo"},
+			wantError:     outbound.ErrUpstreamTimeout,
 			expectedError: "context deadline exceeded",
 		},
 		{
@@ -125,6 +172,11 @@ func TestHTTPClient_FetchCurrent(t *testing.T) {
 			}
 
 			got, err := client.FetchCurrent(ctx, tt.request)
+			if tt.wantError != nil {
+				require.Error(t, err)
+				assert.ErrorIs(t, err, tt.wantError)
+			}
+
 			if tt.expectedError != "" {
 				require.Error(t, err)
 				assert.Contains(t, err.Error(), tt.expectedError)
@@ -142,4 +194,171 @@ func TestHTTPClient_FetchCurrent_MissingAPIKey(t *testing.T) {
 	_, err := client.FetchCurrent(context.Background(), dto.WeatherAPIRequestDto{Q: "São Paulo"})
 	require.Error(t, err)
 	assert.ErrorIs(t, err, weatherapi.ErrMissingAPIKey)
+	assert.ErrorIs(t, err, outbound.ErrUpstreamUnauthorized)
+}
+
+func TestHTTPClient_FetchCurrent_WithRetryTransport(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"current": {"temp_c": 21.0}}`))
+	}))
+	defer server.Close()
+
+	policy := retry.DefaultPolicy()
+	policy.BaseBackoff = time.Millisecond
+	httpClient := &http.Client{Transport: retry.NewTransport(nil, policy, noopLogger)}
+
+	client := weatherapi.NewHTTPClient(httpClient, server.URL, "token", time.Second, noopLogger)
+
+	got, err := client.FetchCurrent(context.Background(), dto.WeatherAPIRequestDto{Q: "São Paulo"})
+	require.NoError(t, err)
+	assert.Equal(t, 21.0, got.Current.TempC)
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+func TestHTTPClient_FetchCurrent_RecordsEveryAttempt(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"current": {"temp_c": 21.0}}`))
+	}))
+	defer server.Close()
+
+	pool, err := weatherapi.NewKeyPool([]string{"key-a"}, 0, "", noopLogger)
+	require.NoError(t, err)
+
+	policy := retry.DefaultPolicy()
+	policy.BaseBackoff = time.Millisecond
+	httpClient := &http.Client{Transport: retry.NewTransport(nil, weatherapi.RetryPolicy(policy), noopLogger)}
+
+	client := weatherapi.NewHTTPClientWithKeyPool(httpClient, server.URL, pool, time.Second, noopLogger)
+
+	_, err = client.FetchCurrent(context.Background(), dto.WeatherAPIRequestDto{Q: "São Paulo"})
+	require.NoError(t, err)
+	assert.Equal(t, int32(3), calls.Load())
+	assert.Equal(t, int64(3), pool.Snapshot()[0].Calls)
+}
+
+func TestHTTPClient_FetchCurrent_RotatesRateLimitedKeyWithoutRetrying(t *testing.T) {
+	var keys []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := r.URL.Query().Get("key")
+		keys = append(keys, key)
+
+		if key == "key-a" {
+			w.Header().Set("Retry-After", "30")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"current": {"temp_c": 19.5}}`))
+	}))
+	defer server.Close()
+
+	pool, err := weatherapi.NewKeyPool([]string{"key-a", "key-b"}, 0, "", noopLogger)
+	require.NoError(t, err)
+
+	policy := retry.DefaultPolicy()
+	policy.BaseBackoff = time.Millisecond
+	httpClient := &http.Client{Transport: retry.NewTransport(nil, weatherapi.RetryPolicy(policy), noopLogger)}
+
+	client := weatherapi.NewHTTPClientWithKeyPool(httpClient, server.URL, pool, time.Second, noopLogger)
+
+	got, err := client.FetchCurrent(context.Background(), dto.WeatherAPIRequestDto{Q: "São Paulo"})
+	require.NoError(t, err)
+	assert.Equal(t, 19.5, got.Current.TempC)
+	assert.Equal(t, []string{"key-a", "key-b"}, keys)
+
+	snaps := pool.Snapshot()
+	assert.Equal(t, int64(1), snaps[0].Calls)
+	assert.Equal(t, "rate_limited", snaps[0].Reason)
+	assert.Equal(t, int64(1), snaps[1].Calls)
+}
+
+func TestRetryPolicy(t *testing.T) {
+	got := weatherapi.RetryPolicy(retry.Policy{RetryableStatuses: []int{401, 403, 429, 500, 503}})
+	assert.Equal(t, []int{500, 503}, got.RetryableStatuses)
+}
+
+func TestHTTPClient_FetchCurrent_RotatesKeys(t *testing.T) {
+	var keys []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := r.URL.Query().Get("key")
+		keys = append(keys, key)
+
+		switch key {
+		case "key-a":
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"error": {"code": 2007, "message": "API key has exceeded calls per month quota."}}`))
+		case "key-b":
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"error": {"code": 2006, "message": "API key is invalid."}}`))
+		default:
+			_, _ = w.Write([]byte(`{"current": {"temp_c": 19.5}}`))
+		}
+	}))
+	defer server.Close()
+
+	pool, err := weatherapi.NewKeyPool([]string{"key-a", "key-b", "key-c"}, 0, "", noopLogger)
+	require.NoError(t, err)
+
+	client := weatherapi.NewHTTPClientWithKeyPool(nil, server.URL, pool, time.Second, noopLogger)
+
+	got, err := client.FetchCurrent(context.Background(), dto.WeatherAPIRequestDto{Q: "São Paulo"})
+	require.NoError(t, err)
+	assert.Equal(t, 19.5, got.Current.TempC)
+	assert.Equal(t, []string{"key-a", "key-b", "key-c"}, keys)
+
+	_, err = client.FetchCurrent(context.Background(), dto.WeatherAPIRequestDto{Q: "São Paulo"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"key-a", "key-b", "key-c", "key-c"}, keys)
+}
+
+func TestHTTPClient_FetchCurrent_SoftLimitReached(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		_, _ = w.Write([]byte(`{"current": {"temp_c": 19.5}}`))
+	}))
+	defer server.Close()
+
+	pool, err := weatherapi.NewKeyPool([]string{"key-a"}, 1, "", noopLogger)
+	require.NoError(t, err)
+
+	client := weatherapi.NewHTTPClientWithKeyPool(nil, server.URL, pool, time.Second, noopLogger)
+
+	_, err = client.FetchCurrent(context.Background(), dto.WeatherAPIRequestDto{Q: "São Paulo"})
+	require.NoError(t, err)
+
+	_, err = client.FetchCurrent(context.Background(), dto.WeatherAPIRequestDto{Q: "São Paulo"})
+	require.ErrorIs(t, err, weatherapi.ErrKeysExhausted)
+	assert.ErrorIs(t, err, outbound.ErrUpstreamQuotaExceeded)
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestHTTPClient_FetchCurrent_BudgetExhausted(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+	}))
+	defer server.Close()
+
+	client := weatherapi.NewHTTPClient(nil, server.URL, "token", time.Second, noopLogger)
+
+	ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Millisecond))
+	defer cancel()
+
+	_, err := client.FetchCurrent(ctx, dto.WeatherAPIRequestDto{Q: "São Paulo"})
+	require.Error(t, err)
+	assert.ErrorIs(t, err, outbound.ErrUpstreamTimeout)
+	assert.Zero(t, calls.Load())
 }
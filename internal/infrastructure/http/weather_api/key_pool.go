@@ -0,0 +1,360 @@
+package weatherapi
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/biraneves/fc-labs-weather/internal/application/ports/outbound"
+)
+
+const (
+	rateLimitCoolDown = time.Minute
+	rejectedCoolDown  = time.Hour
+	persistInterval   = 5 * time.Second
+)
+
+var ErrKeysExhausted = fmt.Errorf("weatherapi: every api key is exhausted or rejected: %w", outbound.ErrUpstreamQuotaExceeded)
+
+type keyUsage struct {
+	Month         string    `json:"month"`
+	Calls         int64     `json:"calls"`
+	DisabledUntil time.Time `json:"disabled_until,omitzero"`
+	Reason        string    `json:"reason,omitempty"`
+}
+
+type KeyUsageSnapshot struct {
+	ID            string `json:"id"`
+	Calls         int64  `json:"calls"`
+	SoftLimit     int64  `json:"soft_limit,omitempty"`
+	Available     bool   `json:"available"`
+	DisabledUntil string `json:"disabled_until,omitempty"`
+	Reason        string `json:"reason,omitempty"`
+}
+
+type KeyPool struct {
+	keys      []string
+	softLimit int64
+	statePath string
+	logger    *slog.Logger
+	now       func() time.Time
+
+	mu        sync.Mutex
+	usage     map[string]*keyUsage
+	current   int
+	dirty     bool
+	saving    bool
+	lastSaved time.Time
+	version   uint64
+
+	writeMu sync.Mutex
+	written uint64
+}
+
+func NewKeyPool(keys []string, softLimit int64, statePath string, logger *slog.Logger) (*KeyPool, error) {
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	p := &KeyPool{
+		keys:      keys,
+		softLimit: softLimit,
+		statePath: statePath,
+		logger:    logger,
+		now:       time.Now,
+		usage:     make(map[string]*keyUsage, len(keys)),
+	}
+
+	if err := p.load(); err != nil {
+		return p, err
+	}
+
+	return p, nil
+}
+
+func (p *KeyPool) Len() int {
+	return len(p.keys)
+}
+
+func (p *KeyPool) Acquire() (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if len(p.keys) == 0 {
+		return "", ErrMissingAPIKey
+	}
+
+	now := p.now()
+	for i := range p.keys {
+		idx := (p.current + i) % len(p.keys)
+		key := p.keys[idx]
+
+		if !p.available(p.get(key, now), now) {
+			continue
+		}
+
+		if idx != p.current {
+			p.logger.Warn("weatherapi: rotating api key",
+				slog.String("type", "weatherapi_key_pool"),
+				slog.String("from", keyID(p.keys[p.current])),
+				slog.String("to", keyID(key)),
+			)
+			p.current = idx
+		}
+
+		return key, nil
+	}
+
+	return "", ErrKeysExhausted
+}
+
+func (p *KeyPool) Record(key string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := p.now()
+	u := p.get(key, now)
+	u.Calls++
+
+	if p.softLimit > 0 && u.Calls == p.softLimit {
+		p.logger.Warn("weatherapi: api key reached soft limit",
+			slog.String("type", "weatherapi_key_pool"),
+			slog.String("key", keyID(key)),
+			slog.Int64("calls", u.Calls),
+			slog.Int64("soft_limit", p.softLimit),
+		)
+	}
+
+	p.dirty = true
+	if p.saving || now.Sub(p.lastSaved) < persistInterval {
+		return
+	}
+
+	raw, version, ok := p.encode(now)
+	if !ok {
+		return
+	}
+
+	p.saving = true
+	go p.save(raw, version)
+}
+
+func (p *KeyPool) Reject(key string, status, apiErrorCode int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := p.now()
+	u := p.get(key, now)
+
+	switch {
+	case status == http.StatusTooManyRequests:
+		u.DisabledUntil = now.Add(rateLimitCoolDown)
+		u.Reason = "rate_limited"
+	case apiErrorCode == apiErrorQuotaExceeded:
+		u.DisabledUntil = nextMonth(now)
+		u.Reason = "quota_exceeded"
+	default:
+		u.DisabledUntil = now.Add(rejectedCoolDown)
+		u.Reason = "rejected"
+	}
+
+	p.logger.Warn("weatherapi: api key disabled",
+		slog.String("type", "weatherapi_key_pool"),
+		slog.String("key", keyID(key)),
+		slog.Int("status", status),
+		slog.Int("api_error_code", apiErrorCode),
+		slog.String("reason", u.Reason),
+		slog.Time("disabled_until", u.DisabledUntil),
+	)
+
+	p.dirty = true
+	p.persist(now)
+}
+
+func (p *KeyPool) Flush() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.persist(p.now())
+}
+
+func (p *KeyPool) Snapshot() []KeyUsageSnapshot {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := p.now()
+	snaps := make([]KeyUsageSnapshot, 0, len(p.keys))
+	for _, key := range p.keys {
+		u := p.get(key, now)
+
+		snap := KeyUsageSnapshot{
+			ID:        keyID(key),
+			Calls:     u.Calls,
+			SoftLimit: p.softLimit,
+			Available: p.available(u, now),
+			Reason:    u.Reason,
+		}
+		if now.Before(u.DisabledUntil) {
+			snap.DisabledUntil = u.DisabledUntil.UTC().Format(time.RFC3339)
+		}
+
+		snaps = append(snaps, snap)
+	}
+
+	return snaps
+}
+
+func (p *KeyPool) available(u *keyUsage, now time.Time) bool {
+	if now.Before(u.DisabledUntil) {
+		return false
+	}
+
+	return p.softLimit <= 0 || u.Calls < p.softLimit
+}
+
+func (p *KeyPool) get(key string, now time.Time) *keyUsage {
+	id := keyID(key)
+	month := now.UTC().Format("2006-01")
+
+	u, ok := p.usage[id]
+	if !ok {
+		u = &keyUsage{Month: month}
+		p.usage[id] = u
+	}
+
+	if u.Month != month {
+		u.Month = month
+		u.Calls = 0
+		p.dirty = true
+	}
+
+	return u
+}
+
+func (p *KeyPool) load() error {
+	if p.statePath == "" {
+		return nil
+	}
+
+	raw, err := os.ReadFile(p.statePath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("weatherapi: read key usage: %w", err)
+	}
+
+	if err := json.Unmarshal(raw, &p.usage); err != nil {
+		p.usage = make(map[string]*keyUsage, len(p.keys))
+		return fmt.Errorf("weatherapi: decode key usage: %w", err)
+	}
+
+	return nil
+}
+
+func (p *KeyPool) persist(now time.Time) {
+	raw, version, ok := p.encode(now)
+	if !ok {
+		return
+	}
+
+	if err := p.write(raw, version); err != nil {
+		p.dirty = true
+	}
+}
+
+func (p *KeyPool) save(raw []byte, version uint64) {
+	err := p.write(raw, version)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.saving = false
+	if err != nil {
+		p.dirty = true
+	}
+}
+
+func (p *KeyPool) encode(now time.Time) ([]byte, uint64, bool) {
+	if p.statePath == "" || !p.dirty {
+		return nil, 0, false
+	}
+
+	raw, err := json.MarshalIndent(p.usage, "", "  ")
+	if err != nil {
+		p.logPersistError(err)
+		return nil, 0, false
+	}
+
+	p.version++
+	p.dirty = false
+	p.lastSaved = now
+
+	return raw, p.version, true
+}
+
+func (p *KeyPool) write(raw []byte, version uint64) error {
+	p.writeMu.Lock()
+	defer p.writeMu.Unlock()
+
+	if version <= p.written {
+		return nil
+	}
+
+	if err := writeFileAtomic(p.statePath, raw); err != nil {
+		p.logPersistError(err)
+		return err
+	}
+
+	p.written = version
+	return nil
+}
+
+func (p *KeyPool) logPersistError(err error) {
+	p.logger.Error("weatherapi: persist key usage failed",
+		slog.String("type", "weatherapi_key_pool"),
+		slog.String("path", p.statePath),
+		slog.String("error", err.Error()),
+	)
+}
+
+func writeFileAtomic(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
+
+func keyID(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:4])
+}
+
+func nextMonth(now time.Time) time.Time {
+	now = now.UTC()
+	return time.Date(now.Year(), now.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+}
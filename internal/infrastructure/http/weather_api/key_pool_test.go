@@ -0,0 +1,209 @@
+package weatherapi_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/biraneves/fc-labs-weather/internal/application/ports/outbound"
+	weatherapi "github.com/biraneves/fc-labs-weather/internal/infrastructure/http/weather_api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.now = c.now.Add(d)
+}
+
+func newTestKeyPool(t *testing.T, keys []string, softLimit int64, path string, clock *fakeClock) *weatherapi.KeyPool {
+	t.Helper()
+
+	pool, err := weatherapi.NewKeyPool(keys, softLimit, path, noopLogger)
+	require.NoError(t, err)
+	pool.SetClock(clock.Now)
+
+	return pool
+}
+
+func TestKeyPool_Acquire(t *testing.T) {
+	tests := []struct {
+		name      string
+		keys      []string
+		softLimit int64
+		steps     func(p *weatherapi.KeyPool, clock *fakeClock)
+		wantKey   string
+		wantError error
+	}{
+		{
+			name:      "no keys",
+			wantError: weatherapi.ErrMissingAPIKey,
+		},
+		{
+			name:    "first key by default",
+			keys:    []string{"key-a", "key-b"},
+			wantKey: "key-a",
+		},
+		{
+			name:      "rotates after soft limit",
+			keys:      []string{"key-a", "key-b"},
+			softLimit: 2,
+			steps: func(p *weatherapi.KeyPool, clock *fakeClock) {
+				p.Record("key-a")
+				p.Record("key-a")
+			},
+			wantKey: "key-b",
+		},
+		{
+			name: "rotates after quota rejection",
+			keys: []string{"key-a", "key-b"},
+			steps: func(p *weatherapi.KeyPool, clock *fakeClock) {
+				p.Reject("key-a", http.StatusForbidden, 2007)
+			},
+			wantKey: "key-b",
+		},
+		{
+			name: "rate limited key comes back after cool down",
+			keys: []string{"key-a", "key-b"},
+			steps: func(p *weatherapi.KeyPool, clock *fakeClock) {
+				p.Reject("key-a", http.StatusTooManyRequests, 0)
+				p.Reject("key-b", http.StatusTooManyRequests, 0)
+				clock.Advance(time.Minute)
+			},
+			wantKey: "key-a",
+		},
+		{
+			name:      "counters reset on a new month",
+			keys:      []string{"key-a"},
+			softLimit: 1,
+			steps: func(p *weatherapi.KeyPool, clock *fakeClock) {
+				p.Record("key-a")
+				clock.Advance(31 * 24 * time.Hour)
+			},
+			wantKey: "key-a",
+		},
+		{
+			name:      "every key exhausted",
+			keys:      []string{"key-a", "key-b"},
+			softLimit: 1,
+			steps: func(p *weatherapi.KeyPool, clock *fakeClock) {
+				p.Record("key-a")
+				p.Reject("key-b", http.StatusUnauthorized, 2006)
+			},
+			wantError: outbound.ErrUpstreamQuotaExceeded,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clock := &fakeClock{now: time.Date(2025, 1, 10, 12, 0, 0, 0, time.UTC)}
+			pool := newTestKeyPool(t, tt.keys, tt.softLimit, "", clock)
+
+			if tt.steps != nil {
+				tt.steps(pool, clock)
+			}
+
+			got, err := pool.Acquire()
+			if tt.wantError != nil {
+				require.ErrorIs(t, err, tt.wantError)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantKey, got)
+		})
+	}
+}
+
+func TestKeyPool_PersistsUsage(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state", "usage.json")
+	clock := &fakeClock{now: time.Date(2025, 1, 10, 12, 0, 0, 0, time.UTC)}
+
+	pool := newTestKeyPool(t, []string{"key-a", "key-b"}, 100, path, clock)
+	pool.Record("key-a")
+	pool.Record("key-a")
+	pool.Reject("key-b", http.StatusForbidden, 2007)
+	pool.Record("key-a")
+	pool.Flush()
+
+	raw, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.NotContains(t, string(raw), "key-a", "raw keys must not be persisted")
+
+	restored := newTestKeyPool(t, []string{"key-a", "key-b"}, 100, path, clock)
+	snaps := restored.Snapshot()
+	require.Len(t, snaps, 2)
+
+	assert.Equal(t, weatherapi.KeyUsageSnapshot{
+		ID:        weatherapi.KeyID("key-a"),
+		Calls:     3,
+		SoftLimit: 100,
+		Available: true,
+	}, snaps[0])
+
+	assert.Equal(t, weatherapi.KeyUsageSnapshot{
+		ID:            weatherapi.KeyID("key-b"),
+		SoftLimit:     100,
+		Available:     false,
+		DisabledUntil: "2025-02-01T00:00:00Z",
+		Reason:        "quota_exceeded",
+	}, snaps[1])
+}
+
+func TestKeyPool_SavesRecordsInBackground(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "usage.json")
+	clock := &fakeClock{now: time.Date(2025, 1, 10, 12, 0, 0, 0, time.UTC)}
+	pool := newTestKeyPool(t, []string{"key-a"}, 0, path, clock)
+
+	savedCalls := func() int64 {
+		raw, err := os.ReadFile(path)
+		if err != nil {
+			return -1
+		}
+
+		var usage map[string]struct {
+			Calls int64 `json:"calls"`
+		}
+		if err := json.Unmarshal(raw, &usage); err != nil {
+			return -1
+		}
+
+		return usage[weatherapi.KeyID("key-a")].Calls
+	}
+
+	pool.Record("key-a")
+	require.Eventually(t, func() bool { return savedCalls() == 1 }, time.Second, time.Millisecond)
+
+	pool.Record("key-a")
+	assert.Never(t, func() bool { return savedCalls() == 2 }, 20*time.Millisecond, time.Millisecond)
+
+	clock.Advance(5 * time.Second)
+	pool.Record("key-a")
+	require.Eventually(t, func() bool { return savedCalls() == 3 }, time.Second, time.Millisecond)
+
+	pool.Flush()
+	assert.Equal(t, int64(3), savedCalls())
+}
+
+func TestKeyPool_InvalidStateFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "usage.json")
+	require.NoError(t, os.WriteFile(path, []byte("{"), 0o644))
+
+	pool, err := weatherapi.NewKeyPool([]string{"key-a"}, 0, path, noopLogger)
+	require.Error(t, err)
+	require.NotNil(t, pool)
+
+	key, err := pool.Acquire()
+	require.NoError(t, err)
+	assert.Equal(t, "key-a", key)
+}
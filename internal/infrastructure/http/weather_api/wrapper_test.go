@@ -0,0 +1,11 @@
+package weatherapi
+
+import "time"
+
+func (p *KeyPool) SetClock(now func() time.Time) {
+	p.now = now
+}
+
+func KeyID(key string) string {
+	return keyID(key)
+}
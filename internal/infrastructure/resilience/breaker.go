@@ -0,0 +1,264 @@
+package resilience
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/biraneves/fc-labs-weather/internal/application/ports/outbound"
+)
+
+const (
+	defaultFailureRatio     = 0.5
+	defaultMinRequests      = 10
+	defaultWindow           = 30 * time.Second
+	defaultCoolDown         = 15 * time.Second
+	defaultHalfOpenRequests = 1
+)
+
+type State int
+
+const (
+	StateClosed State = iota
+	StateOpen
+	StateHalfOpen
+)
+
+func (s State) String() string {
+	switch s {
+	case StateClosed:
+		return "closed"
+	case StateOpen:
+		return "open"
+	case StateHalfOpen:
+		return "half_open"
+	default:
+		return "unknown"
+	}
+}
+
+type BreakerSettings struct {
+	Name             string
+	FailureRatio     float64
+	MinRequests      int
+	Window           time.Duration
+	CoolDown         time.Duration
+	HalfOpenRequests int
+}
+
+type BreakerSnapshot struct {
+	State     string `json:"state"`
+	Requests  int    `json:"requests"`
+	Failures  int    `json:"failures"`
+	OpenUntil string `json:"open_until,omitempty"`
+}
+
+type Breaker struct {
+	settings  BreakerSettings
+	isFailure func(err error) bool
+	logger    *slog.Logger
+	now       func() time.Time
+
+	mu          sync.Mutex
+	state       State
+	windowStart time.Time
+	requests    int
+	failures    int
+	openUntil   time.Time
+	probes      int
+	successes   int
+}
+
+func NewBreaker(settings BreakerSettings, isFailure func(err error) bool, logger *slog.Logger) *Breaker {
+	if settings.FailureRatio <= 0 || settings.FailureRatio > 1 {
+		settings.FailureRatio = defaultFailureRatio
+	}
+
+	if settings.MinRequests <= 0 {
+		settings.MinRequests = defaultMinRequests
+	}
+
+	if settings.Window <= 0 {
+		settings.Window = defaultWindow
+	}
+
+	if settings.CoolDown <= 0 {
+		settings.CoolDown = defaultCoolDown
+	}
+
+	if settings.HalfOpenRequests <= 0 {
+		settings.HalfOpenRequests = defaultHalfOpenRequests
+	}
+
+	if isFailure == nil {
+		isFailure = func(err error) bool { return err != nil }
+	}
+
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	return &Breaker{
+		settings:  settings,
+		isFailure: isFailure,
+		logger:    logger,
+		now:       time.Now,
+	}
+}
+
+func (b *Breaker) Execute(ctx context.Context, fn func(ctx context.Context) error) error {
+	if err := b.allow(); err != nil {
+		return err
+	}
+
+	err := fn(ctx)
+	if err != nil && errors.Is(ctx.Err(), context.Canceled) {
+		b.release()
+		return err
+	}
+
+	b.record(b.isFailure(err))
+	return err
+}
+
+func (b *Breaker) State() State {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.advance(b.now())
+	return b.state
+}
+
+func (b *Breaker) Snapshot() BreakerSnapshot {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.advance(b.now())
+
+	snap := BreakerSnapshot{
+		State:    b.state.String(),
+		Requests: b.requests,
+		Failures: b.failures,
+	}
+	if b.state == StateOpen {
+		snap.OpenUntil = b.openUntil.UTC().Format(time.RFC3339)
+	}
+
+	return snap
+}
+
+func (b *Breaker) allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := b.now()
+	b.advance(now)
+
+	switch b.state {
+	case StateOpen:
+		return &outbound.CircuitOpenError{
+			Upstream:   b.settings.Name,
+			RetryAfter: b.openUntil.Sub(now),
+		}
+
+	case StateHalfOpen:
+		if b.probes >= b.settings.HalfOpenRequests {
+			return &outbound.CircuitOpenError{
+				Upstream:   b.settings.Name,
+				RetryAfter: b.settings.CoolDown,
+			}
+		}
+		b.probes++
+	}
+
+	return nil
+}
+
+func (b *Breaker) record(failed bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := b.now()
+	b.advance(now)
+
+	switch b.state {
+	case StateHalfOpen:
+		if failed {
+			b.trip(now)
+			return
+		}
+
+		b.successes++
+		if b.successes >= b.settings.HalfOpenRequests {
+			b.transition(StateClosed, now)
+		}
+
+	case StateClosed:
+		b.requests++
+		if failed {
+			b.failures++
+		}
+
+		if b.requests >= b.settings.MinRequests &&
+			float64(b.failures)/float64(b.requests) >= b.settings.FailureRatio {
+			b.trip(now)
+		}
+	}
+}
+
+func (b *Breaker) release() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == StateHalfOpen && b.probes > 0 {
+		b.probes--
+	}
+}
+
+func (b *Breaker) advance(now time.Time) {
+	switch b.state {
+	case StateOpen:
+		if !now.Before(b.openUntil) {
+			b.transition(StateHalfOpen, now)
+		}
+
+	case StateClosed:
+		if now.Sub(b.windowStart) >= b.settings.Window {
+			b.resetWindow(now)
+		}
+	}
+}
+
+func (b *Breaker) trip(now time.Time) {
+	b.openUntil = now.Add(b.settings.CoolDown)
+	b.transition(StateOpen, now)
+}
+
+func (b *Breaker) transition(to State, now time.Time) {
+	from := b.state
+	if from == to {
+		return
+	}
+
+	b.logger.Warn("circuit breaker: state changed",
+		slog.String("type", "circuit_breaker"),
+		slog.String("upstream", b.settings.Name),
+		slog.String("from", from.String()),
+		slog.String("to", to.String()),
+		slog.Int("requests", b.requests),
+		slog.Int("failures", b.failures),
+	)
+
+	b.state = to
+	b.probes = 0
+	b.successes = 0
+	b.resetWindow(now)
+}
+
+func (b *Breaker) resetWindow(now time.Time) {
+	b.windowStart = now
+	b.requests = 0
+	b.failures = 0
+}
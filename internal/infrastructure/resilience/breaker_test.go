@@ -0,0 +1,253 @@
+package resilience_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/biraneves/fc-labs-weather/internal/application/ports/outbound"
+	"github.com/biraneves/fc-labs-weather/internal/infrastructure/resilience"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var noopLogger = slog.New(slog.NewJSONHandler(io.Discard, nil))
+
+var errUpstream = errors.New("upstream failed")
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.now = c.now.Add(d)
+}
+
+func newTestBreaker(clock *fakeClock, logger *slog.Logger) *resilience.Breaker {
+	b := resilience.NewBreaker(resilience.BreakerSettings{
+		Name:             "weatherapi",
+		FailureRatio:     0.5,
+		MinRequests:      4,
+		Window:           10 * time.Second,
+		CoolDown:         5 * time.Second,
+		HalfOpenRequests: 1,
+	}, resilience.IsUpstreamFailure, logger)
+	b.SetClock(clock.Now)
+	return b
+}
+
+func run(b *resilience.Breaker, err error) error {
+	return b.Execute(context.Background(), func(ctx context.Context) error { return err })
+}
+
+func TestBreaker_Transitions(t *testing.T) {
+	tests := []struct {
+		name      string
+		steps     func(t *testing.T, b *resilience.Breaker, clock *fakeClock)
+		wantState resilience.State
+	}{
+		{
+			name: "stays closed below min requests",
+			steps: func(t *testing.T, b *resilience.Breaker, clock *fakeClock) {
+				for range 3 {
+					require.ErrorIs(t, run(b, errUpstream), errUpstream)
+				}
+			},
+			wantState: resilience.StateClosed,
+		},
+		{
+			name: "stays closed below failure ratio",
+			steps: func(t *testing.T, b *resilience.Breaker, clock *fakeClock) {
+				for _, err := range []error{nil, nil, nil, errUpstream} {
+					_ = run(b, err)
+				}
+			},
+			wantState: resilience.StateClosed,
+		},
+		{
+			name: "not found does not count as failure",
+			steps: func(t *testing.T, b *resilience.Breaker, clock *fakeClock) {
+				for range 5 {
+					_ = run(b, outbound.ErrZipcodeNotFound)
+				}
+			},
+			wantState: resilience.StateClosed,
+		},
+		{
+			name: "unknown weather location does not count as failure",
+			steps: func(t *testing.T, b *resilience.Breaker, clock *fakeClock) {
+				for range 5 {
+					_ = run(b, outbound.ErrWeatherLocationNotFound)
+				}
+			},
+			wantState: resilience.StateClosed,
+		},
+		{
+			name: "opens when failure ratio is reached",
+			steps: func(t *testing.T, b *resilience.Breaker, clock *fakeClock) {
+				for _, err := range []error{nil, errUpstream, nil, errUpstream} {
+					_ = run(b, err)
+				}
+			},
+			wantState: resilience.StateOpen,
+		},
+		{
+			name: "window expiry resets counters",
+			steps: func(t *testing.T, b *resilience.Breaker, clock *fakeClock) {
+				for range 3 {
+					_ = run(b, errUpstream)
+				}
+				clock.Advance(11 * time.Second)
+				_ = run(b, errUpstream)
+			},
+			wantState: resilience.StateClosed,
+		},
+		{
+			name: "half open after cool down",
+			steps: func(t *testing.T, b *resilience.Breaker, clock *fakeClock) {
+				for range 4 {
+					_ = run(b, errUpstream)
+				}
+				clock.Advance(5 * time.Second)
+			},
+			wantState: resilience.StateHalfOpen,
+		},
+		{
+			name: "successful probe closes the circuit",
+			steps: func(t *testing.T, b *resilience.Breaker, clock *fakeClock) {
+				for range 4 {
+					_ = run(b, errUpstream)
+				}
+				clock.Advance(5 * time.Second)
+				require.NoError(t, run(b, nil))
+			},
+			wantState: resilience.StateClosed,
+		},
+		{
+			name: "failed probe reopens the circuit",
+			steps: func(t *testing.T, b *resilience.Breaker, clock *fakeClock) {
+				for range 4 {
+					_ = run(b, errUpstream)
+				}
+				clock.Advance(5 * time.Second)
+				require.ErrorIs(t, run(b, errUpstream), errUpstream)
+			},
+			wantState: resilience.StateOpen,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clock := &fakeClock{now: time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)}
+			b := newTestBreaker(clock, noopLogger)
+
+			tt.steps(t, b, clock)
+
+			assert.Equal(t, tt.wantState, b.State())
+		})
+	}
+}
+
+func TestBreaker_FailsFastWhenOpen(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)}
+	b := newTestBreaker(clock, noopLogger)
+
+	for range 4 {
+		_ = run(b, errUpstream)
+	}
+
+	clock.Advance(2 * time.Second)
+
+	called := false
+	err := b.Execute(context.Background(), func(ctx context.Context) error {
+		called = true
+		return nil
+	})
+
+	var openErr *outbound.CircuitOpenError
+	require.ErrorAs(t, err, &openErr)
+	assert.False(t, called)
+	assert.Equal(t, "weatherapi", openErr.Upstream)
+	assert.Equal(t, 3*time.Second, openErr.RetryAfter)
+}
+
+func TestBreaker_CanceledCallsAreIgnored(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)}
+	b := newTestBreaker(clock, noopLogger)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	for range 5 {
+		_ = b.Execute(ctx, func(ctx context.Context) error { return ctx.Err() })
+	}
+
+	assert.Equal(t, resilience.StateClosed, b.State())
+	assert.Equal(t, 0, b.Snapshot().Failures)
+}
+
+func TestBreaker_HangingUpstreamOpensCircuit(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)}
+	b := newTestBreaker(clock, noopLogger)
+
+	hang := func(ctx context.Context) error {
+		<-ctx.Done()
+		return fmt.Errorf("%w: %w", outbound.ErrUpstreamTimeout, ctx.Err())
+	}
+
+	for range 4 {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Millisecond)
+		err := b.Execute(ctx, hang)
+		cancel()
+		require.ErrorIs(t, err, outbound.ErrUpstreamTimeout)
+	}
+
+	assert.Equal(t, resilience.StateOpen, b.State())
+
+	var openErr *outbound.CircuitOpenError
+	assert.ErrorAs(t, run(b, nil), &openErr)
+}
+
+func TestBreaker_CanceledProbeReleasesHalfOpenSlot(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)}
+	b := newTestBreaker(clock, noopLogger)
+
+	for range 4 {
+		_ = run(b, errUpstream)
+	}
+	clock.Advance(5 * time.Second)
+	require.Equal(t, resilience.StateHalfOpen, b.State())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err := b.Execute(ctx, func(ctx context.Context) error { return ctx.Err() })
+	require.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, resilience.StateHalfOpen, b.State())
+
+	require.ErrorIs(t, run(b, errUpstream), errUpstream)
+	assert.Equal(t, resilience.StateOpen, b.State())
+}
+
+func TestBreaker_LogsTransitions(t *testing.T) {
+	var buf bytes.Buffer
+	clock := &fakeClock{now: time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)}
+	b := newTestBreaker(clock, slog.New(slog.NewJSONHandler(&buf, nil)))
+
+	for range 4 {
+		_ = run(b, errUpstream)
+	}
+
+	assert.Contains(t, buf.String(), `"msg":"circuit breaker: state changed"`)
+	assert.Contains(t, buf.String(), `"from":"closed"`)
+	assert.Contains(t, buf.String(), `"to":"open"`)
+	assert.Contains(t, buf.String(), `"upstream":"weatherapi"`)
+}
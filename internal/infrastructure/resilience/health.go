@@ -0,0 +1,150 @@
+package resilience
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	defaultHealthDecay     = 0.3
+	defaultHealthThreshold = 0.5
+	defaultHealthCoolDown  = 30 * time.Second
+)
+
+type HealthSettings struct {
+	Decay     float64
+	Threshold float64
+	CoolDown  time.Duration
+}
+
+type HealthSnapshot struct {
+	Provider    string  `json:"provider"`
+	Score       float64 `json:"score"`
+	Healthy     bool    `json:"healthy"`
+	Successes   int64   `json:"successes"`
+	Failures    int64   `json:"failures"`
+	LastFailure string  `json:"last_failure,omitempty"`
+}
+
+type providerHealth struct {
+	score       float64
+	successes   int64
+	failures    int64
+	lastFailure time.Time
+}
+
+type HealthTracker struct {
+	settings HealthSettings
+	now      func() time.Time
+
+	mu        sync.Mutex
+	providers map[string]*providerHealth
+}
+
+func NewHealthTracker(settings HealthSettings) *HealthTracker {
+	if settings.Decay <= 0 || settings.Decay > 1 {
+		settings.Decay = defaultHealthDecay
+	}
+
+	if settings.Threshold <= 0 || settings.Threshold > 1 {
+		settings.Threshold = defaultHealthThreshold
+	}
+
+	if settings.CoolDown <= 0 {
+		settings.CoolDown = defaultHealthCoolDown
+	}
+
+	return &HealthTracker{
+		settings:  settings,
+		now:       time.Now,
+		providers: make(map[string]*providerHealth),
+	}
+}
+
+func (h *HealthTracker) Healthy(provider string) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return h.healthy(h.get(provider), h.now())
+}
+
+func (h *HealthTracker) Record(provider string, failed bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	p := h.get(provider)
+
+	outcome := 1.0
+	if failed {
+		outcome = 0
+		p.failures++
+		p.lastFailure = h.now()
+	} else {
+		p.successes++
+	}
+
+	p.score = p.score*(1-h.settings.Decay) + outcome*h.settings.Decay
+}
+
+func (h *HealthTracker) Snapshot(providers []string) []HealthSnapshot {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	now := h.now()
+	snaps := make([]HealthSnapshot, 0, len(providers))
+	for _, name := range providers {
+		p := h.get(name)
+
+		snap := HealthSnapshot{
+			Provider:  name,
+			Score:     p.score,
+			Healthy:   h.healthy(p, now),
+			Successes: p.successes,
+			Failures:  p.failures,
+		}
+		if !p.lastFailure.IsZero() {
+			snap.LastFailure = p.lastFailure.UTC().Format(time.RFC3339)
+		}
+
+		snaps = append(snaps, snap)
+	}
+
+	return snaps
+}
+
+func healthyFirst[P any](h *HealthTracker, providers []P, name func(P) string) []P {
+	healthy := make([]P, 0, len(providers))
+	var skipped []P
+
+	for _, provider := range providers {
+		if h.Healthy(name(provider)) {
+			healthy = append(healthy, provider)
+			continue
+		}
+		skipped = append(skipped, provider)
+	}
+
+	if len(healthy) == 0 {
+		return skipped
+	}
+
+	return healthy
+}
+
+func (h *HealthTracker) healthy(p *providerHealth, now time.Time) bool {
+	if p.score >= h.settings.Threshold {
+		return true
+	}
+
+	return now.Sub(p.lastFailure) >= h.settings.CoolDown
+}
+
+func (h *HealthTracker) get(provider string) *providerHealth {
+	p, ok := h.providers[provider]
+	if !ok {
+		p = &providerHealth{score: 1}
+		h.providers[provider] = p
+	}
+
+	return p
+}
@@ -0,0 +1,95 @@
+package resilience_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/biraneves/fc-labs-weather/internal/infrastructure/resilience"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHealthTracker(t *testing.T) {
+	tests := []struct {
+		name        string
+		steps       func(h *resilience.HealthTracker, clock *fakeClock)
+		wantHealthy bool
+	}{
+		{
+			name:        "unknown provider is healthy",
+			steps:       func(h *resilience.HealthTracker, clock *fakeClock) {},
+			wantHealthy: true,
+		},
+		{
+			name: "single failure keeps provider healthy",
+			steps: func(h *resilience.HealthTracker, clock *fakeClock) {
+				h.Record("viacep", true)
+			},
+			wantHealthy: true,
+		},
+		{
+			name: "consecutive failures mark provider unhealthy",
+			steps: func(h *resilience.HealthTracker, clock *fakeClock) {
+				h.Record("viacep", true)
+				h.Record("viacep", true)
+			},
+			wantHealthy: false,
+		},
+		{
+			name: "provider is probed again after cool down",
+			steps: func(h *resilience.HealthTracker, clock *fakeClock) {
+				h.Record("viacep", true)
+				h.Record("viacep", true)
+				clock.Advance(10 * time.Second)
+			},
+			wantHealthy: true,
+		},
+		{
+			name: "successes restore the score",
+			steps: func(h *resilience.HealthTracker, clock *fakeClock) {
+				h.Record("viacep", true)
+				h.Record("viacep", true)
+				h.Record("viacep", false)
+			},
+			wantHealthy: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clock := &fakeClock{now: time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)}
+			h := resilience.NewHealthTracker(resilience.HealthSettings{CoolDown: 10 * time.Second})
+			h.SetClock(clock.Now)
+
+			tt.steps(h, clock)
+			assert.Equal(t, tt.wantHealthy, h.Healthy("viacep"))
+		})
+	}
+}
+
+func TestHealthTracker_Snapshot(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)}
+	h := resilience.NewHealthTracker(resilience.HealthSettings{Decay: 0.5})
+	h.SetClock(clock.Now)
+
+	h.Record("viacep", false)
+	h.Record("brasilapi", true)
+
+	snaps := h.Snapshot([]string{"viacep", "brasilapi"})
+	require.Len(t, snaps, 2)
+
+	assert.Equal(t, resilience.HealthSnapshot{
+		Provider:  "viacep",
+		Score:     1,
+		Healthy:   true,
+		Successes: 1,
+	}, snaps[0])
+
+	assert.Equal(t, resilience.HealthSnapshot{
+		Provider:    "brasilapi",
+		Score:       0.5,
+		Healthy:     true,
+		Failures:    1,
+		LastFailure: "2025-01-01T12:00:00Z",
+	}, snaps[1])
+}
@@ -0,0 +1,64 @@
+package resilience
+
+import (
+	"math"
+	"slices"
+	"sync"
+	"time"
+)
+
+const (
+	latencyWindowSize = 128
+	latencyMinSamples = 20
+)
+
+type latencyWindow struct {
+	samples []time.Duration
+	next    int
+}
+
+type LatencyTracker struct {
+	mu      sync.Mutex
+	windows map[string]*latencyWindow
+}
+
+func NewLatencyTracker() *LatencyTracker {
+	return &LatencyTracker{windows: make(map[string]*latencyWindow)}
+}
+
+func (l *LatencyTracker) Record(provider string, latency time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	w, ok := l.windows[provider]
+	if !ok {
+		w = &latencyWindow{samples: make([]time.Duration, 0, latencyWindowSize)}
+		l.windows[provider] = w
+	}
+
+	if len(w.samples) < latencyWindowSize {
+		w.samples = append(w.samples, latency)
+		return
+	}
+
+	w.samples[w.next] = latency
+	w.next = (w.next + 1) % latencyWindowSize
+}
+
+func (l *LatencyTracker) Percentile(provider string, p float64) (time.Duration, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	w, ok := l.windows[provider]
+	if !ok || len(w.samples) < latencyMinSamples || p <= 0 || p > 1 {
+		return 0, false
+	}
+
+	sorted := slices.Clone(w.samples)
+	slices.Sort(sorted)
+
+	idx := int(math.Ceil(p*float64(len(sorted)))) - 1
+	idx = max(0, min(idx, len(sorted)-1))
+
+	return sorted[idx], true
+}
@@ -0,0 +1,53 @@
+package resilience_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/biraneves/fc-labs-weather/internal/infrastructure/resilience"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLatencyTracker_Percentile(t *testing.T) {
+	l := resilience.NewLatencyTracker()
+
+	_, ok := l.Percentile("weatherapi", 0.95)
+	assert.False(t, ok)
+
+	for i := 1; i <= 19; i++ {
+		l.Record("weatherapi", time.Duration(i)*time.Millisecond)
+	}
+
+	_, ok = l.Percentile("weatherapi", 0.95)
+	assert.False(t, ok, "not enough samples")
+
+	l.Record("weatherapi", 20*time.Millisecond)
+
+	got, ok := l.Percentile("weatherapi", 0.95)
+	require.True(t, ok)
+	assert.Equal(t, 19*time.Millisecond, got)
+
+	got, ok = l.Percentile("weatherapi", 0.5)
+	require.True(t, ok)
+	assert.Equal(t, 10*time.Millisecond, got)
+
+	_, ok = l.Percentile("weatherapi", 1.5)
+	assert.False(t, ok)
+}
+
+func TestLatencyTracker_KeepsRecentSamples(t *testing.T) {
+	l := resilience.NewLatencyTracker()
+
+	for range 200 {
+		l.Record("weatherapi", time.Second)
+	}
+
+	for range 128 {
+		l.Record("weatherapi", time.Millisecond)
+	}
+
+	got, ok := l.Percentile("weatherapi", 1)
+	require.True(t, ok)
+	assert.Equal(t, time.Millisecond, got)
+}
@@ -0,0 +1,60 @@
+package resilience
+
+import (
+	"context"
+	"errors"
+
+	"github.com/biraneves/fc-labs-weather/internal/application/dto"
+	"github.com/biraneves/fc-labs-weather/internal/application/ports/outbound"
+)
+
+type ZipcodeBreaker struct {
+	next    outbound.ZipcodeLookupPort
+	breaker *Breaker
+}
+
+func NewZipcodeBreaker(next outbound.ZipcodeLookupPort, breaker *Breaker) *ZipcodeBreaker {
+	return &ZipcodeBreaker{next: next, breaker: breaker}
+}
+
+func (z *ZipcodeBreaker) Find(ctx context.Context, request dto.ViaCEPRequestDto) (dto.ViaCEPResponseDto, error) {
+	var resp dto.ViaCEPResponseDto
+
+	err := z.breaker.Execute(ctx, func(ctx context.Context) error {
+		var err error
+		resp, err = z.next.Find(ctx, request)
+		return err
+	})
+
+	return resp, err
+}
+
+type WeatherBreaker struct {
+	next    outbound.WeatherProviderPort
+	breaker *Breaker
+}
+
+func NewWeatherBreaker(next outbound.WeatherProviderPort, breaker *Breaker) *WeatherBreaker {
+	return &WeatherBreaker{next: next, breaker: breaker}
+}
+
+func (w *WeatherBreaker) FetchCurrent(ctx context.Context, request dto.WeatherAPIRequestDto) (dto.WeatherAPIResponseDto, error) {
+	var resp dto.WeatherAPIResponseDto
+
+	err := w.breaker.Execute(ctx, func(ctx context.Context) error {
+		var err error
+		resp, err = w.next.FetchCurrent(ctx, request)
+		return err
+	})
+
+	return resp, err
+}
+
+func IsUpstreamFailure(err error) bool {
+	return err != nil && !errors.Is(err, outbound.ErrZipcodeNotFound) && !errors.Is(err, outbound.ErrWeatherLocationNotFound)
+}
+
+var (
+	_ outbound.ZipcodeLookupPort   = (*ZipcodeBreaker)(nil)
+	_ outbound.WeatherProviderPort = (*WeatherBreaker)(nil)
+)
@@ -0,0 +1,76 @@
+package resilience_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/biraneves/fc-labs-weather/internal/application/dto"
+	"github.com/biraneves/fc-labs-weather/internal/application/ports/outbound"
+	"github.com/biraneves/fc-labs-weather/internal/infrastructure/resilience"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeZipcodePort struct {
+	resp  dto.ViaCEPResponseDto
+	err   error
+	calls int
+}
+
+func (f *fakeZipcodePort) Find(ctx context.Context, request dto.ViaCEPRequestDto) (dto.ViaCEPResponseDto, error) {
+	f.calls++
+	return f.resp, f.err
+}
+
+type fakeWeatherPort struct {
+	resp  dto.WeatherAPIResponseDto
+	err   error
+	calls int
+}
+
+func (f *fakeWeatherPort) FetchCurrent(ctx context.Context, req dto.WeatherAPIRequestDto) (dto.WeatherAPIResponseDto, error) {
+	f.calls++
+	return f.resp, f.err
+}
+
+func TestZipcodeBreaker_Find(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)}
+	upstream := &fakeZipcodePort{resp: dto.ViaCEPResponseDto{Localidade: "São Paulo"}}
+	port := resilience.NewZipcodeBreaker(upstream, newTestBreaker(clock, noopLogger))
+
+	got, err := port.Find(context.Background(), dto.ViaCEPRequestDto{})
+	require.NoError(t, err)
+	assert.Equal(t, "São Paulo", got.Localidade)
+
+	upstream.err = errUpstream
+	for range 4 {
+		_, _ = port.Find(context.Background(), dto.ViaCEPRequestDto{})
+	}
+
+	_, err = port.Find(context.Background(), dto.ViaCEPRequestDto{})
+	var openErr *outbound.CircuitOpenError
+	require.ErrorAs(t, err, &openErr)
+	assert.Equal(t, 4, upstream.calls)
+}
+
+func TestWeatherBreaker_FetchCurrent(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)}
+	upstream := &fakeWeatherPort{}
+	upstream.resp.Current.TempC = 21
+	port := resilience.NewWeatherBreaker(upstream, newTestBreaker(clock, noopLogger))
+
+	got, err := port.FetchCurrent(context.Background(), dto.WeatherAPIRequestDto{Q: "Recife"})
+	require.NoError(t, err)
+	assert.Equal(t, 21.0, got.Current.TempC)
+
+	upstream.err = errUpstream
+	for range 4 {
+		_, _ = port.FetchCurrent(context.Background(), dto.WeatherAPIRequestDto{Q: "Recife"})
+	}
+
+	_, err = port.FetchCurrent(context.Background(), dto.WeatherAPIRequestDto{Q: "Recife"})
+	var openErr *outbound.CircuitOpenError
+	require.ErrorAs(t, err, &openErr)
+	assert.Equal(t, 4, upstream.calls)
+}
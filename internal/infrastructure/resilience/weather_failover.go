@@ -0,0 +1,176 @@
+package resilience
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/biraneves/fc-labs-weather/internal/application/dto"
+	"github.com/biraneves/fc-labs-weather/internal/application/ports/outbound"
+	"github.com/biraneves/fc-labs-weather/internal/infrastructure/http/server"
+)
+
+type WeatherProvider struct {
+	Name string
+	Port outbound.WeatherProviderPort
+}
+
+type WeatherFailoverSettings struct {
+	HedgePercentile float64
+	HedgeMinDelay   time.Duration
+	Health          HealthSettings
+}
+
+type WeatherFailover struct {
+	providers []WeatherProvider
+	settings  WeatherFailoverSettings
+	health    *HealthTracker
+	latency   *LatencyTracker
+	logger    *slog.Logger
+}
+
+type weatherResult struct {
+	provider string
+	hedged   bool
+	resp     dto.WeatherAPIResponseDto
+	err      error
+}
+
+func NewWeatherFailover(providers []WeatherProvider, settings WeatherFailoverSettings, logger *slog.Logger) *WeatherFailover {
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	return &WeatherFailover{
+		providers: providers,
+		settings:  settings,
+		health:    NewHealthTracker(settings.Health),
+		latency:   NewLatencyTracker(),
+		logger:    logger,
+	}
+}
+
+func (w *WeatherFailover) FetchCurrent(ctx context.Context, request dto.WeatherAPIRequestDto) (dto.WeatherAPIResponseDto, error) {
+	if len(w.providers) == 0 {
+		return dto.WeatherAPIResponseDto{}, ErrNoProviders
+	}
+
+	logger := server.LoggerFromContext(ctx, w.logger)
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	providers := w.ordered()
+	results := make(chan weatherResult, len(providers))
+
+	var (
+		next     int
+		inflight int
+		hedge    <-chan time.Time
+		lastErr  error
+	)
+
+	launch := func(hedged bool) {
+		provider := providers[next]
+		next++
+		inflight++
+
+		if delay, ok := w.hedgeDelay(provider.Name); ok && next < len(providers) {
+			hedge = time.After(delay)
+		} else {
+			hedge = nil
+		}
+
+		go func() {
+			start := time.Now()
+			resp, err := provider.Port.FetchCurrent(ctx, request)
+
+			switch {
+			case err == nil:
+				w.health.Record(provider.Name, false)
+				w.latency.Record(provider.Name, time.Since(start))
+			case errors.Is(err, context.Canceled) && ctx.Err() != nil:
+			default:
+				w.health.Record(provider.Name, IsUpstreamFailure(err))
+			}
+
+			results <- weatherResult{provider: provider.Name, hedged: hedged, resp: resp, err: err}
+		}()
+	}
+
+	launch(false)
+
+	for inflight > 0 {
+		select {
+		case result := <-results:
+			inflight--
+
+			if result.err == nil {
+				logger.Info("weather failover: provider answered",
+					slog.String("type", "weather_failover"),
+					slog.String("provider", result.provider),
+					slog.Bool("hedged", result.hedged),
+					slog.String("query", request.Q),
+				)
+				result.resp.Provider = result.provider
+				return result.resp, nil
+			}
+
+			if ctx.Err() != nil {
+				return dto.WeatherAPIResponseDto{}, result.err
+			}
+
+			logger.Warn("weather failover: provider failed",
+				slog.String("type", "weather_failover"),
+				slog.String("provider", result.provider),
+				slog.Bool("hedged", result.hedged),
+				slog.String("query", request.Q),
+				slog.String("error", result.err.Error()),
+			)
+			lastErr = result.err
+
+			if inflight == 0 && next < len(providers) {
+				launch(false)
+			}
+
+		case <-hedge:
+			logger.Info("weather failover: hedging request",
+				slog.String("type", "weather_failover"),
+				slog.String("provider", providers[next].Name),
+				slog.String("query", request.Q),
+			)
+			launch(true)
+		}
+	}
+
+	return dto.WeatherAPIResponseDto{}, lastErr
+}
+
+func (w *WeatherFailover) Health() []HealthSnapshot {
+	names := make([]string, len(w.providers))
+	for i, provider := range w.providers {
+		names[i] = provider.Name
+	}
+
+	return w.health.Snapshot(names)
+}
+
+func (w *WeatherFailover) hedgeDelay(provider string) (time.Duration, bool) {
+	if w.settings.HedgePercentile <= 0 {
+		return 0, false
+	}
+
+	delay, ok := w.latency.Percentile(provider, w.settings.HedgePercentile)
+	if !ok {
+		return 0, false
+	}
+
+	return max(delay, w.settings.HedgeMinDelay), true
+}
+
+func (w *WeatherFailover) ordered() []WeatherProvider {
+	return healthyFirst(w.health, w.providers, func(p WeatherProvider) string { return p.Name })
+}
+
+var _ outbound.WeatherProviderPort = (*WeatherFailover)(nil)
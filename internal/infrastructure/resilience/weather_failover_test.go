@@ -0,0 +1,206 @@
+package resilience_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/biraneves/fc-labs-weather/internal/application/dto"
+	"github.com/biraneves/fc-labs-weather/internal/application/ports/outbound"
+	"github.com/biraneves/fc-labs-weather/internal/infrastructure/resilience"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type slowWeatherPort struct {
+	delay    atomic.Int64
+	tempC    float64
+	err      error
+	calls    atomic.Int32
+	canceled atomic.Int32
+}
+
+func (s *slowWeatherPort) FetchCurrent(ctx context.Context, req dto.WeatherAPIRequestDto) (dto.WeatherAPIResponseDto, error) {
+	s.calls.Add(1)
+
+	select {
+	case <-time.After(time.Duration(s.delay.Load())):
+	case <-ctx.Done():
+		s.canceled.Add(1)
+		return dto.WeatherAPIResponseDto{}, ctx.Err()
+	}
+
+	var resp dto.WeatherAPIResponseDto
+	resp.Current.TempC = s.tempC
+	return resp, s.err
+}
+
+func newTestWeatherFailover(settings resilience.WeatherFailoverSettings, ports ...*slowWeatherPort) *resilience.WeatherFailover {
+	names := []string{"weatherapi", "openmeteo"}
+
+	providers := make([]resilience.WeatherProvider, len(ports))
+	for i, port := range ports {
+		providers[i] = resilience.WeatherProvider{Name: names[i], Port: port}
+	}
+
+	return resilience.NewWeatherFailover(providers, settings, noopLogger)
+}
+
+func TestWeatherFailover_FetchCurrent(t *testing.T) {
+	tests := []struct {
+		name               string
+		primary            *slowWeatherPort
+		secondary          *slowWeatherPort
+		wantTempC          float64
+		wantProvider       string
+		wantError          error
+		wantSecondaryCalls int32
+	}{
+		{
+			name:               "primary answers",
+			primary:            &slowWeatherPort{tempC: 20},
+			secondary:          &slowWeatherPort{tempC: 30},
+			wantTempC:          20,
+			wantProvider:       "weatherapi",
+			wantSecondaryCalls: 0,
+		},
+		{
+			name:               "falls back on failure",
+			primary:            &slowWeatherPort{err: outbound.ErrUpstreamUnavailable},
+			secondary:          &slowWeatherPort{tempC: 30},
+			wantTempC:          30,
+			wantProvider:       "openmeteo",
+			wantSecondaryCalls: 1,
+		},
+		{
+			name:               "every provider fails",
+			primary:            &slowWeatherPort{err: outbound.ErrUpstreamUnavailable},
+			secondary:          &slowWeatherPort{err: outbound.ErrUpstreamQuotaExceeded},
+			wantError:          outbound.ErrUpstreamQuotaExceeded,
+			wantSecondaryCalls: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newTestWeatherFailover(resilience.WeatherFailoverSettings{}, tt.primary, tt.secondary)
+
+			got, err := f.FetchCurrent(context.Background(), dto.WeatherAPIRequestDto{Q: "São Paulo"})
+			if tt.wantError != nil {
+				require.ErrorIs(t, err, tt.wantError)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.wantTempC, got.Current.TempC)
+				assert.Equal(t, tt.wantProvider, got.Provider)
+			}
+
+			assert.Equal(t, int32(1), tt.primary.calls.Load())
+			assert.Equal(t, tt.wantSecondaryCalls, tt.secondary.calls.Load())
+		})
+	}
+}
+
+func TestWeatherFailover_HedgesSlowPrimary(t *testing.T) {
+	primary := &slowWeatherPort{tempC: 20}
+	secondary := &slowWeatherPort{tempC: 30}
+	f := newTestWeatherFailover(resilience.WeatherFailoverSettings{
+		HedgePercentile: 0.95,
+		HedgeMinDelay:   20 * time.Millisecond,
+	}, primary, secondary)
+
+	for range 20 {
+		_, err := f.FetchCurrent(context.Background(), dto.WeatherAPIRequestDto{Q: "São Paulo"})
+		require.NoError(t, err)
+	}
+	require.Equal(t, int32(0), secondary.calls.Load())
+
+	primary.delay.Store(int64(2 * time.Second))
+
+	start := time.Now()
+	got, err := f.FetchCurrent(context.Background(), dto.WeatherAPIRequestDto{Q: "São Paulo"})
+	require.NoError(t, err)
+
+	assert.Less(t, time.Since(start), time.Second)
+	assert.Equal(t, "openmeteo", got.Provider)
+	assert.Equal(t, 30.0, got.Current.TempC)
+	assert.Equal(t, int32(1), secondary.calls.Load())
+
+	assert.Eventually(t, func() bool { return primary.canceled.Load() == 1 }, time.Second, 5*time.Millisecond)
+}
+
+func TestWeatherFailover_NoHedgeWithoutSamples(t *testing.T) {
+	primary := &slowWeatherPort{tempC: 20}
+	primary.delay.Store(int64(50 * time.Millisecond))
+	secondary := &slowWeatherPort{tempC: 30}
+	f := newTestWeatherFailover(resilience.WeatherFailoverSettings{
+		HedgePercentile: 0.95,
+		HedgeMinDelay:   time.Millisecond,
+	}, primary, secondary)
+
+	got, err := f.FetchCurrent(context.Background(), dto.WeatherAPIRequestDto{Q: "São Paulo"})
+	require.NoError(t, err)
+	assert.Equal(t, "weatherapi", got.Provider)
+	assert.Equal(t, int32(0), secondary.calls.Load())
+}
+
+func TestWeatherFailover_SkipsUnhealthyProvider(t *testing.T) {
+	primary := &slowWeatherPort{err: outbound.ErrUpstreamUnavailable}
+	secondary := &slowWeatherPort{tempC: 30}
+	f := newTestWeatherFailover(resilience.WeatherFailoverSettings{
+		Health: resilience.HealthSettings{CoolDown: time.Minute},
+	}, primary, secondary)
+
+	for range 3 {
+		_, err := f.FetchCurrent(context.Background(), dto.WeatherAPIRequestDto{Q: "São Paulo"})
+		require.NoError(t, err)
+	}
+
+	assert.Equal(t, int32(2), primary.calls.Load())
+	assert.Equal(t, int32(3), secondary.calls.Load())
+
+	health := f.Health()
+	require.Len(t, health, 2)
+	assert.False(t, health[0].Healthy)
+	assert.True(t, health[1].Healthy)
+}
+
+func TestWeatherFailover_LocationNotFoundKeepsProviderHealthy(t *testing.T) {
+	primary := &slowWeatherPort{err: outbound.ErrWeatherLocationNotFound}
+	secondary := &slowWeatherPort{tempC: 30}
+	f := newTestWeatherFailover(resilience.WeatherFailoverSettings{
+		Health: resilience.HealthSettings{CoolDown: time.Minute},
+	}, primary, secondary)
+
+	for range 3 {
+		_, err := f.FetchCurrent(context.Background(), dto.WeatherAPIRequestDto{Q: "Nowhere"})
+		require.NoError(t, err)
+	}
+
+	assert.Equal(t, int32(3), primary.calls.Load())
+
+	health := f.Health()
+	require.Len(t, health, 2)
+	assert.True(t, health[0].Healthy)
+}
+
+func TestWeatherFailover_StopsWhenCallerCancels(t *testing.T) {
+	primary := &slowWeatherPort{tempC: 20}
+	primary.delay.Store(int64(time.Second))
+	secondary := &slowWeatherPort{tempC: 30}
+	f := newTestWeatherFailover(resilience.WeatherFailoverSettings{}, primary, secondary)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	_, err := f.FetchCurrent(ctx, dto.WeatherAPIRequestDto{Q: "São Paulo"})
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, int32(0), secondary.calls.Load())
+}
+
+func TestWeatherFailover_NoProviders(t *testing.T) {
+	f := resilience.NewWeatherFailover(nil, resilience.WeatherFailoverSettings{}, noopLogger)
+
+	_, err := f.FetchCurrent(context.Background(), dto.WeatherAPIRequestDto{Q: "São Paulo"})
+	require.ErrorIs(t, err, resilience.ErrNoProviders)
+}
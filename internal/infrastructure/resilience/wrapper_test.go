@@ -0,0 +1,15 @@
+package resilience
+
+import "time"
+
+func (b *Breaker) SetClock(now func() time.Time) {
+	b.now = now
+}
+
+func (h *HealthTracker) SetClock(now func() time.Time) {
+	h.now = now
+}
+
+func (z *ZipcodeFailover) SetClock(now func() time.Time) {
+	z.health.SetClock(now)
+}
@@ -0,0 +1,124 @@
+package resilience
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/biraneves/fc-labs-weather/internal/application/dto"
+	"github.com/biraneves/fc-labs-weather/internal/application/ports/outbound"
+	"github.com/biraneves/fc-labs-weather/internal/infrastructure/http/server"
+)
+
+var ErrNoProviders = errors.New("failover: no providers configured")
+
+type ZipcodeProvider struct {
+	Name string
+	Port outbound.ZipcodeLookupPort
+}
+
+type ZipcodeFailoverSettings struct {
+	NotFoundAuthoritative bool
+	Health                HealthSettings
+}
+
+type ZipcodeFailover struct {
+	providers             []ZipcodeProvider
+	notFoundAuthoritative bool
+	health                *HealthTracker
+	logger                *slog.Logger
+}
+
+func NewZipcodeFailover(providers []ZipcodeProvider, settings ZipcodeFailoverSettings, logger *slog.Logger) *ZipcodeFailover {
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	return &ZipcodeFailover{
+		providers:             providers,
+		notFoundAuthoritative: settings.NotFoundAuthoritative,
+		health:                NewHealthTracker(settings.Health),
+		logger:                logger,
+	}
+}
+
+func (z *ZipcodeFailover) Find(ctx context.Context, request dto.ViaCEPRequestDto) (dto.ViaCEPResponseDto, error) {
+	if len(z.providers) == 0 {
+		return dto.ViaCEPResponseDto{}, ErrNoProviders
+	}
+
+	logger := server.LoggerFromContext(ctx, z.logger)
+
+	var (
+		lastErr     error
+		notFoundErr error
+	)
+
+	for attempt, provider := range z.ordered() {
+		if err := ctx.Err(); err != nil {
+			return dto.ViaCEPResponseDto{}, err
+		}
+
+		resp, err := provider.Port.Find(ctx, request)
+		if err == nil {
+			z.health.Record(provider.Name, false)
+			z.logAnswer(logger, provider.Name, attempt, request, false)
+			return resp, nil
+		}
+
+		if errors.Is(err, context.Canceled) && ctx.Err() != nil {
+			return dto.ViaCEPResponseDto{}, err
+		}
+
+		if errors.Is(err, outbound.ErrZipcodeNotFound) {
+			z.health.Record(provider.Name, false)
+			if z.notFoundAuthoritative {
+				z.logAnswer(logger, provider.Name, attempt, request, true)
+				return dto.ViaCEPResponseDto{}, err
+			}
+			notFoundErr = err
+			continue
+		}
+
+		z.health.Record(provider.Name, true)
+		logger.Warn("zipcode failover: provider failed",
+			slog.String("type", "zipcode_failover"),
+			slog.String("provider", provider.Name),
+			slog.Int("attempt", attempt+1),
+			slog.String("cep", request.CEP.Formatted()),
+			slog.String("error", err.Error()),
+		)
+		lastErr = err
+	}
+
+	if notFoundErr != nil {
+		return dto.ViaCEPResponseDto{}, notFoundErr
+	}
+
+	return dto.ViaCEPResponseDto{}, lastErr
+}
+
+func (z *ZipcodeFailover) Health() []HealthSnapshot {
+	names := make([]string, len(z.providers))
+	for i, provider := range z.providers {
+		names[i] = provider.Name
+	}
+
+	return z.health.Snapshot(names)
+}
+
+func (z *ZipcodeFailover) logAnswer(logger *slog.Logger, provider string, attempt int, request dto.ViaCEPRequestDto, notFound bool) {
+	logger.Info("zipcode failover: provider answered",
+		slog.String("type", "zipcode_failover"),
+		slog.String("provider", provider),
+		slog.Int("attempt", attempt+1),
+		slog.String("cep", request.CEP.Formatted()),
+		slog.Bool("not_found", notFound),
+	)
+}
+
+func (z *ZipcodeFailover) ordered() []ZipcodeProvider {
+	return healthyFirst(z.health, z.providers, func(p ZipcodeProvider) string { return p.Name })
+}
+
+var _ outbound.ZipcodeLookupPort = (*ZipcodeFailover)(nil)
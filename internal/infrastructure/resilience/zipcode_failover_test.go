@@ -0,0 +1,181 @@
+package resilience_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/biraneves/fc-labs-weather/internal/application/dto"
+	"github.com/biraneves/fc-labs-weather/internal/application/ports/outbound"
+	"github.com/biraneves/fc-labs-weather/internal/infrastructure/resilience"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestZipcodeFailover(clock *fakeClock, notFoundAuthoritative bool, ports ...*fakeZipcodePort) *resilience.ZipcodeFailover {
+	names := []string{"viacep", "brasilapi", "opencep"}
+
+	providers := make([]resilience.ZipcodeProvider, len(ports))
+	for i, port := range ports {
+		providers[i] = resilience.ZipcodeProvider{Name: names[i], Port: port}
+	}
+
+	f := resilience.NewZipcodeFailover(providers, resilience.ZipcodeFailoverSettings{
+		NotFoundAuthoritative: notFoundAuthoritative,
+		Health:                resilience.HealthSettings{CoolDown: 10 * time.Second},
+	}, noopLogger)
+	f.SetClock(clock.Now)
+
+	return f
+}
+
+func TestZipcodeFailover_Find(t *testing.T) {
+	tests := []struct {
+		name                  string
+		primary               fakeZipcodePort
+		secondary             fakeZipcodePort
+		notFoundAuthoritative bool
+		want                  dto.ViaCEPResponseDto
+		wantError             error
+		wantPrimaryCalls      int
+		wantSecondaryCalls    int
+	}{
+		{
+			name:               "primary answers",
+			primary:            fakeZipcodePort{resp: dto.ViaCEPResponseDto{Localidade: "São Paulo"}},
+			secondary:          fakeZipcodePort{resp: dto.ViaCEPResponseDto{Localidade: "Campinas"}},
+			want:               dto.ViaCEPResponseDto{Localidade: "São Paulo"},
+			wantPrimaryCalls:   1,
+			wantSecondaryCalls: 0,
+		},
+		{
+			name:               "falls back on upstream failure",
+			primary:            fakeZipcodePort{err: outbound.ErrUpstreamUnavailable},
+			secondary:          fakeZipcodePort{resp: dto.ViaCEPResponseDto{Localidade: "São Paulo"}},
+			want:               dto.ViaCEPResponseDto{Localidade: "São Paulo"},
+			wantPrimaryCalls:   1,
+			wantSecondaryCalls: 1,
+		},
+		{
+			name:                  "authoritative not found stops the chain",
+			primary:               fakeZipcodePort{err: outbound.ErrZipcodeNotFound},
+			secondary:             fakeZipcodePort{resp: dto.ViaCEPResponseDto{Localidade: "São Paulo"}},
+			notFoundAuthoritative: true,
+			wantError:             outbound.ErrZipcodeNotFound,
+			wantPrimaryCalls:      1,
+			wantSecondaryCalls:    0,
+		},
+		{
+			name:               "non authoritative not found asks the next provider",
+			primary:            fakeZipcodePort{err: outbound.ErrZipcodeNotFound},
+			secondary:          fakeZipcodePort{resp: dto.ViaCEPResponseDto{Localidade: "São Paulo"}},
+			want:               dto.ViaCEPResponseDto{Localidade: "São Paulo"},
+			wantPrimaryCalls:   1,
+			wantSecondaryCalls: 1,
+		},
+		{
+			name:               "not found wins over later failures",
+			primary:            fakeZipcodePort{err: outbound.ErrZipcodeNotFound},
+			secondary:          fakeZipcodePort{err: outbound.ErrUpstreamTimeout},
+			wantError:          outbound.ErrZipcodeNotFound,
+			wantPrimaryCalls:   1,
+			wantSecondaryCalls: 1,
+		},
+		{
+			name:               "every provider fails",
+			primary:            fakeZipcodePort{err: outbound.ErrUpstreamUnavailable},
+			secondary:          fakeZipcodePort{err: outbound.ErrUpstreamTimeout},
+			wantError:          outbound.ErrUpstreamTimeout,
+			wantPrimaryCalls:   1,
+			wantSecondaryCalls: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clock := &fakeClock{now: time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)}
+			primary, secondary := tt.primary, tt.secondary
+			f := newTestZipcodeFailover(clock, tt.notFoundAuthoritative, &primary, &secondary)
+
+			got, err := f.Find(context.Background(), dto.ViaCEPRequestDto{})
+			if tt.wantError != nil {
+				require.ErrorIs(t, err, tt.wantError)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+
+			assert.Equal(t, tt.wantPrimaryCalls, primary.calls)
+			assert.Equal(t, tt.wantSecondaryCalls, secondary.calls)
+		})
+	}
+}
+
+func TestZipcodeFailover_SkipsUnhealthyProvider(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)}
+	primary := &fakeZipcodePort{err: outbound.ErrUpstreamUnavailable}
+	secondary := &fakeZipcodePort{resp: dto.ViaCEPResponseDto{Localidade: "São Paulo"}}
+	f := newTestZipcodeFailover(clock, true, primary, secondary)
+
+	for range 2 {
+		_, err := f.Find(context.Background(), dto.ViaCEPRequestDto{})
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 2, primary.calls)
+
+	_, err := f.Find(context.Background(), dto.ViaCEPRequestDto{})
+	require.NoError(t, err)
+	assert.Equal(t, 2, primary.calls)
+	assert.Equal(t, 3, secondary.calls)
+
+	clock.Advance(10 * time.Second)
+	primary.err = nil
+	primary.resp = dto.ViaCEPResponseDto{Localidade: "Campinas"}
+
+	got, err := f.Find(context.Background(), dto.ViaCEPRequestDto{})
+	require.NoError(t, err)
+	assert.Equal(t, "Campinas", got.Localidade)
+	assert.Equal(t, 3, primary.calls)
+
+	health := f.Health()
+	require.Len(t, health, 2)
+	assert.Equal(t, "viacep", health[0].Provider)
+	assert.True(t, health[0].Healthy)
+}
+
+func TestZipcodeFailover_TriesUnhealthyProvidersAsLastResort(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)}
+	primary := &fakeZipcodePort{err: outbound.ErrUpstreamUnavailable}
+	secondary := &fakeZipcodePort{err: outbound.ErrUpstreamUnavailable}
+	f := newTestZipcodeFailover(clock, true, primary, secondary)
+
+	for range 3 {
+		_, err := f.Find(context.Background(), dto.ViaCEPRequestDto{})
+		require.ErrorIs(t, err, outbound.ErrUpstreamUnavailable)
+	}
+
+	assert.Equal(t, 3, primary.calls)
+	assert.Equal(t, 3, secondary.calls)
+}
+
+func TestZipcodeFailover_StopsWhenCallerCancels(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)}
+	primary := &fakeZipcodePort{err: context.Canceled}
+	secondary := &fakeZipcodePort{resp: dto.ViaCEPResponseDto{Localidade: "São Paulo"}}
+	f := newTestZipcodeFailover(clock, true, primary, secondary)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := f.Find(ctx, dto.ViaCEPRequestDto{})
+	require.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, 0, primary.calls)
+	assert.Equal(t, 0, secondary.calls)
+}
+
+func TestZipcodeFailover_NoProviders(t *testing.T) {
+	f := resilience.NewZipcodeFailover(nil, resilience.ZipcodeFailoverSettings{}, noopLogger)
+
+	_, err := f.Find(context.Background(), dto.ViaCEPRequestDto{})
+	require.ErrorIs(t, err, resilience.ErrNoProviders)
+}
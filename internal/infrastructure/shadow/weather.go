@@ -0,0 +1,171 @@
+package shadow
+
+import (
+	"context"
+	"log/slog"
+	"math"
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	"github.com/biraneves/fc-labs-weather/internal/application/dto"
+	"github.com/biraneves/fc-labs-weather/internal/application/ports/outbound"
+)
+
+const (
+	defaultMaxInFlight = 10
+	defaultTimeout     = 5 * time.Second
+)
+
+type Settings struct {
+	Candidate      string
+	SampleRate     float64
+	MaxInFlight    int
+	Timeout        time.Duration
+	TempThresholdC float64
+}
+
+type Stats struct {
+	Candidate           string  `json:"candidate"`
+	Sampled             int64   `json:"sampled"`
+	Dropped             int64   `json:"dropped"`
+	CandidateErrors     int64   `json:"candidate_errors"`
+	Compared            int64   `json:"compared"`
+	TempMismatches      int64   `json:"temp_mismatches"`
+	ConditionMismatches int64   `json:"condition_mismatches"`
+	MeanAbsDiffC        float64 `json:"mean_abs_diff_c"`
+	MaxAbsDiffC         float64 `json:"max_abs_diff_c"`
+}
+
+type WeatherShadow struct {
+	primary   outbound.WeatherProviderPort
+	candidate outbound.WeatherProviderPort
+	settings  Settings
+	logger    *slog.Logger
+	random    func() float64
+	slots     chan struct{}
+	wg        sync.WaitGroup
+
+	mu         sync.Mutex
+	stats      Stats
+	sumAbsDiff float64
+}
+
+func NewWeatherShadow(primary, candidate outbound.WeatherProviderPort, settings Settings, logger *slog.Logger) *WeatherShadow {
+	if settings.MaxInFlight <= 0 {
+		settings.MaxInFlight = defaultMaxInFlight
+	}
+
+	if settings.Timeout <= 0 {
+		settings.Timeout = defaultTimeout
+	}
+
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	return &WeatherShadow{
+		primary:   primary,
+		candidate: candidate,
+		settings:  settings,
+		logger:    logger,
+		random:    rand.Float64,
+		slots:     make(chan struct{}, settings.MaxInFlight),
+		stats:     Stats{Candidate: settings.Candidate},
+	}
+}
+
+func (s *WeatherShadow) FetchCurrent(ctx context.Context, request dto.WeatherAPIRequestDto) (dto.WeatherAPIResponseDto, error) {
+	resp, err := s.primary.FetchCurrent(ctx, request)
+	if err != nil || s.settings.SampleRate <= 0 || s.random() >= s.settings.SampleRate {
+		return resp, err
+	}
+
+	select {
+	case s.slots <- struct{}{}:
+	default:
+		s.mu.Lock()
+		s.stats.Dropped++
+		s.mu.Unlock()
+		return resp, nil
+	}
+
+	s.mu.Lock()
+	s.stats.Sampled++
+	s.mu.Unlock()
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		defer func() { <-s.slots }()
+
+		shadowCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), s.settings.Timeout)
+		defer cancel()
+
+		s.compare(shadowCtx, request, resp)
+	}()
+
+	return resp, nil
+}
+
+func (s *WeatherShadow) Stats() Stats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.stats
+}
+
+func (s *WeatherShadow) Wait() {
+	s.wg.Wait()
+}
+
+func (s *WeatherShadow) compare(ctx context.Context, request dto.WeatherAPIRequestDto, primary dto.WeatherAPIResponseDto) {
+	candidate, err := s.candidate.FetchCurrent(ctx, request)
+	if err != nil {
+		s.mu.Lock()
+		s.stats.CandidateErrors++
+		s.mu.Unlock()
+
+		s.logger.Warn("shadow: candidate failed",
+			slog.String("type", "weather_shadow"),
+			slog.String("candidate", s.settings.Candidate),
+			slog.String("query", request.Q),
+			slog.String("error", err.Error()),
+		)
+		return
+	}
+
+	diff := math.Abs(candidate.Current.TempC - primary.Current.TempC)
+	tempMismatch := diff > s.settings.TempThresholdC
+	conditionMismatch := candidate.Current.Condition.Code != primary.Current.Condition.Code
+
+	s.mu.Lock()
+	s.stats.Compared++
+	s.sumAbsDiff += diff
+	s.stats.MeanAbsDiffC = s.sumAbsDiff / float64(s.stats.Compared)
+	s.stats.MaxAbsDiffC = max(s.stats.MaxAbsDiffC, diff)
+	if tempMismatch {
+		s.stats.TempMismatches++
+	}
+	if conditionMismatch {
+		s.stats.ConditionMismatches++
+	}
+	s.mu.Unlock()
+
+	if !tempMismatch && !conditionMismatch {
+		return
+	}
+
+	s.logger.Info("shadow: candidate differs from primary",
+		slog.String("type", "weather_shadow"),
+		slog.String("candidate", s.settings.Candidate),
+		slog.String("query", request.Q),
+		slog.Float64("primary_temp_c", primary.Current.TempC),
+		slog.Float64("candidate_temp_c", candidate.Current.TempC),
+		slog.Float64("diff_c", diff),
+		slog.Int("primary_condition_code", primary.Current.Condition.Code),
+		slog.Int("candidate_condition_code", candidate.Current.Condition.Code),
+	)
+}
+
+var _ outbound.WeatherProviderPort = (*WeatherShadow)(nil)
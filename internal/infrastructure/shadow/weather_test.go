@@ -0,0 +1,169 @@
+package shadow_test
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/biraneves/fc-labs-weather/internal/application/dto"
+	"github.com/biraneves/fc-labs-weather/internal/application/ports/outbound"
+	"github.com/biraneves/fc-labs-weather/internal/infrastructure/shadow"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var noopLogger = slog.New(slog.NewJSONHandler(io.Discard, nil))
+
+type fakeWeatherPort struct {
+	tempC   float64
+	code    int
+	err     error
+	block   chan struct{}
+	calls   atomic.Int32
+	ctxDone atomic.Bool
+}
+
+func (f *fakeWeatherPort) FetchCurrent(ctx context.Context, req dto.WeatherAPIRequestDto) (dto.WeatherAPIResponseDto, error) {
+	f.calls.Add(1)
+
+	if f.block != nil {
+		select {
+		case <-f.block:
+		case <-ctx.Done():
+			f.ctxDone.Store(true)
+			return dto.WeatherAPIResponseDto{}, ctx.Err()
+		}
+	}
+
+	var resp dto.WeatherAPIResponseDto
+	resp.Current.TempC = f.tempC
+	resp.Current.Condition.Code = f.code
+	return resp, f.err
+}
+
+func newShadow(primary, candidate *fakeWeatherPort, settings shadow.Settings, random float64) *shadow.WeatherShadow {
+	settings.Candidate = "openmeteo"
+	s := shadow.NewWeatherShadow(primary, candidate, settings, noopLogger)
+	s.SetRandom(func() float64 { return random })
+	return s
+}
+
+func TestWeatherShadow_FetchCurrent(t *testing.T) {
+	tests := []struct {
+		name          string
+		primary       *fakeWeatherPort
+		candidate     *fakeWeatherPort
+		sampleRate    float64
+		random        float64
+		wantError     error
+		wantCandidate int32
+		wantStats     shadow.Stats
+	}{
+		{
+			name:          "matching readings",
+			primary:       &fakeWeatherPort{tempC: 20, code: 1000},
+			candidate:     &fakeWeatherPort{tempC: 20.5, code: 1000},
+			sampleRate:    1,
+			wantCandidate: 1,
+			wantStats:     shadow.Stats{Candidate: "openmeteo", Sampled: 1, Compared: 1, MeanAbsDiffC: 0.5, MaxAbsDiffC: 0.5},
+		},
+		{
+			name:          "temperature and condition mismatch",
+			primary:       &fakeWeatherPort{tempC: 20, code: 1000},
+			candidate:     &fakeWeatherPort{tempC: 23, code: 1183},
+			sampleRate:    1,
+			wantCandidate: 1,
+			wantStats: shadow.Stats{
+				Candidate: "openmeteo", Sampled: 1, Compared: 1, TempMismatches: 1, ConditionMismatches: 1,
+				MeanAbsDiffC: 3, MaxAbsDiffC: 3,
+			},
+		},
+		{
+			name:          "candidate failure",
+			primary:       &fakeWeatherPort{tempC: 20},
+			candidate:     &fakeWeatherPort{err: outbound.ErrUpstreamUnavailable},
+			sampleRate:    1,
+			wantCandidate: 1,
+			wantStats:     shadow.Stats{Candidate: "openmeteo", Sampled: 1, CandidateErrors: 1},
+		},
+		{
+			name:          "not sampled",
+			primary:       &fakeWeatherPort{tempC: 20},
+			candidate:     &fakeWeatherPort{tempC: 20},
+			sampleRate:    0.1,
+			random:        0.5,
+			wantCandidate: 0,
+			wantStats:     shadow.Stats{Candidate: "openmeteo"},
+		},
+		{
+			name:          "primary failure is not mirrored",
+			primary:       &fakeWeatherPort{err: outbound.ErrUpstreamTimeout},
+			candidate:     &fakeWeatherPort{tempC: 20},
+			sampleRate:    1,
+			wantError:     outbound.ErrUpstreamTimeout,
+			wantCandidate: 0,
+			wantStats:     shadow.Stats{Candidate: "openmeteo"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newShadow(tt.primary, tt.candidate, shadow.Settings{SampleRate: tt.sampleRate, TempThresholdC: 1}, tt.random)
+
+			got, err := s.FetchCurrent(context.Background(), dto.WeatherAPIRequestDto{Q: "São Paulo"})
+			if tt.wantError != nil {
+				require.ErrorIs(t, err, tt.wantError)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.primary.tempC, got.Current.TempC)
+			}
+
+			s.Wait()
+			assert.Equal(t, tt.wantCandidate, tt.candidate.calls.Load())
+			assert.Equal(t, tt.wantStats, s.Stats())
+		})
+	}
+}
+
+func TestWeatherShadow_DoesNotDelayPrimary(t *testing.T) {
+	primary := &fakeWeatherPort{tempC: 20}
+	candidate := &fakeWeatherPort{tempC: 20, block: make(chan struct{})}
+	s := newShadow(primary, candidate, shadow.Settings{SampleRate: 1, MaxInFlight: 1, Timeout: time.Minute}, 0)
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	start := time.Now()
+	_, err := s.FetchCurrent(ctx, dto.WeatherAPIRequestDto{Q: "São Paulo"})
+	require.NoError(t, err)
+	assert.Less(t, time.Since(start), 100*time.Millisecond)
+
+	cancel()
+
+	_, err = s.FetchCurrent(context.Background(), dto.WeatherAPIRequestDto{Q: "São Paulo"})
+	require.NoError(t, err)
+
+	close(candidate.block)
+	s.Wait()
+
+	stats := s.Stats()
+	assert.Equal(t, int64(1), stats.Sampled)
+	assert.Equal(t, int64(1), stats.Dropped)
+	assert.Equal(t, int64(1), stats.Compared)
+	assert.False(t, candidate.ctxDone.Load(), "shadow call must outlive the caller context")
+}
+
+func TestWeatherShadow_CandidateTimeout(t *testing.T) {
+	primary := &fakeWeatherPort{tempC: 20}
+	candidate := &fakeWeatherPort{block: make(chan struct{})}
+	s := newShadow(primary, candidate, shadow.Settings{SampleRate: 1, Timeout: 10 * time.Millisecond}, 0)
+
+	_, err := s.FetchCurrent(context.Background(), dto.WeatherAPIRequestDto{Q: "São Paulo"})
+	require.NoError(t, err)
+
+	s.Wait()
+	assert.True(t, candidate.ctxDone.Load())
+	assert.Equal(t, int64(1), s.Stats().CandidateErrors)
+}
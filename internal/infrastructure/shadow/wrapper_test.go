@@ -0,0 +1,5 @@
+package shadow
+
+func (s *WeatherShadow) SetRandom(random func() float64) {
+	s.random = random
+}